@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"e-memo-job-reservation-api/internal/auth"
@@ -50,14 +51,35 @@ func main() {
 	workflowStepRepo := repository.NewWorkflowStepRepository(db)
 	specifiedLocationRepo := repository.NewSpecifiedLocationRepository(db)
 	statusTransitionRepo := repository.NewStatusTransitionRepository(db)
+	workflowVersionRepo := repository.NewWorkflowVersionRepository(db)
 	rejectedTicketRepo := repository.NewRejectedTicketRepository(db)
 	ticketActionLogRepo := repository.NewTicketActionLogRepository(db)
 	actionRepo := repository.NewActionRepository(db)
+	transitionPrerequisiteRepo := repository.NewTransitionPrerequisiteRepository(db)
+	slaPolicyRepo := repository.NewSlaPolicyRepository(db)
+	workCalendarRepo := repository.NewWorkCalendarRepository(db)
+	editingLockRepo := repository.NewEditingLockRepository(db)
+	notificationPreferenceRepo := repository.NewNotificationPreferenceRepository(db)
+	notificationRecipientRepo := repository.NewNotificationRecipientRepository(db)
+	emailQueueRepo := repository.NewEmailQueueRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	webhookEndpointRepo := repository.NewWebhookEndpointRepository(db)
+	webhookDeliveryRepo := repository.NewWebhookDeliveryRepository(db)
+	outboxRepo := repository.NewOutboxRepository(db)
+	employeeDelegationRepo := repository.NewEmployeeDelegationRepository(db)
+	escalationRuleRepo := repository.NewEscalationRuleRepository(db)
+	actionReasonCodeRepo := repository.NewActionReasonCodeRepository(db)
+	ticketLinkRepo := repository.NewTicketLinkRepository(db)
+	ticketCommentRepo := repository.NewTicketCommentRepository(db)
+	ticketWatcherRepo := repository.NewTicketWatcherRepository(db)
 
-	hub := websocket.NewHub(authRepo)
+	hub := websocket.NewHub(authRepo, editingLockRepo)
 	go hub.Run()
+	backplane := websocket.NewBackplane(db, hub)
+	go backplane.Listen(context.Background())
 
 	// SERVICE
+	webhookService := service.NewWebhookService(webhookEndpointRepo, webhookDeliveryRepo)
 	authService := service.NewAuthService(authRepo, appUserRepo, positionPermissionRepo, employeeRepo)
 	departmentService := service.NewDepartmentService(departmentRepo)
 	employeeService := service.NewEmployeeService(employeeRepo)
@@ -78,6 +100,10 @@ func main() {
 		StatusTransitionRepo:  statusTransitionRepo,
 		ActorRoleMappingRepo:  actorRoleMappingRepo,
 		ActorRoleRepo:         actorRoleRepo,
+		WorkflowVersionRepo:   workflowVersionRepo,
+		DelegationRepo:        employeeDelegationRepo,
+		ReasonCodeRepo:        actionReasonCodeRepo,
+		TicketLinkRepo:        ticketLinkRepo,
 	})
 	employeePositionService := service.NewEmployeePositionService(
 		employeePositionRepo,
@@ -94,13 +120,19 @@ func main() {
 		db,
 	)
 
-	ticketQueryService := service.NewTicketQueryService(ticketRepo, trackStatusTicketRepo, ticketActionLogRepo)
+	ticketQueryService := service.NewTicketQueryService(ticketRepo, trackStatusTicketRepo, ticketActionLogRepo, ticketLinkRepo)
 
+	editingLockService := service.NewEditingLockService(editingLockRepo, hub)
+	emailNotificationService := service.NewEmailNotificationService(notificationRecipientRepo, emailQueueRepo, employeeRepo)
+	notificationService := service.NewNotificationService(notificationRepo, notificationRecipientRepo, appUserRepo, employeeRepo, hub)
+	outboxService := service.NewOutboxService(outboxRepo, ticketRepo, ticketQueryService, hub, emailNotificationService, notificationService, webhookService)
+	go outboxService.Run(context.Background())
 	ticketCommandService := service.NewTicketCommandService(&service.TicketCommandServiceConfig{
 		DB:                    db,
 		TicketRepo:            ticketRepo,
 		JobRepo:               jobRepo,
 		WorkflowRepo:          workflowRepo,
+		WorkflowVersionRepo:   workflowVersionRepo,
 		TrackStatusTicketRepo: trackStatusTicketRepo,
 		EmployeeRepo:          employeeRepo,
 		DepartmentRepo:        departmentRepo,
@@ -110,6 +142,8 @@ func main() {
 		SpecifiedLocationRepo: specifiedLocationRepo,
 		Hub:                   hub,
 		QueryService:          ticketQueryService,
+		LockService:           editingLockService,
+		OutboxService:         outboxService,
 	})
 
 	ticketWorkflowService := service.NewTicketWorkflowService(&service.TicketWorkflowServiceConfig{
@@ -124,13 +158,40 @@ func main() {
 		ActorRoleMappingRepo:  actorRoleMappingRepo,
 		TicketActionLogRepo:   ticketActionLogRepo,
 		WorkflowRepo:          workflowRepo,
+		WorkflowVersionRepo:   workflowVersionRepo,
 		ActionService:         ticketActionService,
 		QueryService:          ticketQueryService,
 		Hub:                   hub,
+		OutboxService:         outboxService,
 	})
 
-	ticketPriorityService := service.NewTicketPriorityService(db, hub, ticketRepo, employeeRepo)
-	jobService := service.NewJobService(jobRepo, jobQueryRepo, employeeRepo, positionPermissionRepo, db, hub, ticketQueryService)
+	ticketLinkService := service.NewTicketLinkService(&service.TicketLinkServiceConfig{
+		DB:              db,
+		TicketLinkRepo:  ticketLinkRepo,
+		TicketRepo:      ticketRepo,
+		EmployeeRepo:    employeeRepo,
+		WorkflowService: ticketWorkflowService,
+		OutboxService:   outboxService,
+	})
+	ticketCommentService := service.NewTicketCommentService(&service.TicketCommentServiceConfig{
+		DB:            db,
+		CommentRepo:   ticketCommentRepo,
+		TicketRepo:    ticketRepo,
+		JobRepo:       jobRepo,
+		EmployeeRepo:  employeeRepo,
+		OutboxService: outboxService,
+	})
+	ticketWatcherService := service.NewTicketWatcherService(&service.TicketWatcherServiceConfig{
+		DB:            db,
+		WatcherRepo:   ticketWatcherRepo,
+		TicketRepo:    ticketRepo,
+		JobRepo:       jobRepo,
+		EmployeeRepo:  employeeRepo,
+		OutboxService: outboxService,
+	})
+
+	ticketPriorityService := service.NewTicketPriorityService(db, hub, ticketRepo, employeeRepo, editingLockService, outboxService)
+	jobService := service.NewJobService(jobRepo, jobQueryRepo, employeeRepo, positionPermissionRepo, db, hub, ticketQueryService, editingLockService, outboxService, ticketWorkflowService)
 
 	ticketHandler := handler.NewTicketHandler(&handler.TicketHandlerConfig{
 		QueryService:    ticketQueryService,
@@ -141,31 +202,91 @@ func main() {
 	})
 
 	actionService := service.NewActionService(actionRepo)
+	actorRoleService := service.NewActorRoleService(actorRoleRepo)
+	actorRoleMappingService := service.NewActorRoleMappingService(actorRoleMappingRepo)
+	statusTransitionService := service.NewStatusTransitionService(db, statusTransitionRepo, statusTicketRepo, workflowRepo)
+	workflowGraphService := service.NewWorkflowGraphService(&service.WorkflowGraphServiceConfig{
+		DB:                      db,
+		WorkflowRepo:            workflowRepo,
+		StepRepo:                workflowStepRepo,
+		StatusTicketRepo:        statusTicketRepo,
+		StatusTransitionRepo:    statusTransitionRepo,
+		ActionRepo:              actionRepo,
+		ActorRoleRepo:           actorRoleRepo,
+		StatusTransitionService: statusTransitionService,
+	})
+	workflowSimulationService := service.NewWorkflowSimulationService(&service.WorkflowSimulationServiceConfig{
+		TicketRepo:            ticketRepo,
+		JobRepo:               jobRepo,
+		EmployeeRepo:          employeeRepo,
+		EmployeePositionRepo:  employeePositionRepo,
+		TrackStatusTicketRepo: trackStatusTicketRepo,
+		StatusTicketRepo:      statusTicketRepo,
+		StatusTransitionRepo:  statusTransitionRepo,
+		WorkflowRepo:          workflowRepo,
+		WorkflowVersionRepo:   workflowVersionRepo,
+		ActionService:         ticketActionService,
+	})
+	workflowVersionService := service.NewWorkflowVersionService(&service.WorkflowVersionServiceConfig{
+		DB:                      db,
+		VersionRepo:             workflowVersionRepo,
+		WorkflowRepo:            workflowRepo,
+		StepRepo:                workflowStepRepo,
+		TrackStatusTicketRepo:   trackStatusTicketRepo,
+		StatusTransitionService: statusTransitionService,
+		OutboxService:           outboxService,
+	})
 	fileService := service.NewFileService(ticketRepo, jobRepo)
 	systemService := service.NewSystemService(authRepo, hub)
+	transitionPrerequisiteService := service.NewTransitionPrerequisiteService(transitionPrerequisiteRepo)
+	slaPolicyService := service.NewSlaPolicyService(slaPolicyRepo)
+	notificationPreferenceService := service.NewNotificationPreferenceService(notificationPreferenceRepo, employeeRepo)
+	workCalendarService := service.NewWorkCalendarService(workCalendarRepo, db)
+	employeeDelegationService := service.NewEmployeeDelegationService(employeeDelegationRepo, employeeRepo)
+	escalationRuleService := service.NewEscalationRuleService(escalationRuleRepo, actionRepo, statusTransitionRepo)
+	actionReasonCodeService := service.NewActionReasonCodeService(actionReasonCodeRepo)
 
 	// HANDLER
-	wsHandler := handler.NewWebSocketHandler(hub, authRepo)
+	wsHandler := handler.NewWebSocketHandler(hub, authRepo, appUserRepo)
 
 	allHandlers := &router.AllHandlers{
-		AuthHandler:                handler.NewAuthHandler(authService),
-		DepartmentHandler:          handler.NewDepartmentHandler(departmentService),
-		EmployeeHandler:            handler.NewEmployeeHandler(employeeService),
-		AreaHandler:                handler.NewAreaHandler(areaService),
-		PhysicalLocationHandler:    handler.NewPhysicalLocationHandler(physicalLocationService),
-		AccessPermissionHandler:    handler.NewAccessPermissionHandler(accessPermissionService),
-		SectionStatusTicketHandler: handler.NewSectionStatusTicketHandler(sectionStatusTicketService),
-		StatusTicketHandler:        handler.NewStatusTicketHandler(statusTicketService),
-		PositionPermissionHandler:  handler.NewPositionPermissionHandler(positionPermissionService),
-		EmployeePositionHandler:    handler.NewEmployeePositionHandler(employeePositionService),
-		WorkflowHandler:            handler.NewWorkflowHandler(workflowService),
-		SpecifiedLocationHandler:   handler.NewSpecifiedLocationHandler(specifiedLocationService),
-		RejectedTicketHandler:      handler.NewRejectedTicketHandler(rejectedTicketService),
-		JobHandler:                 handler.NewJobHandler(jobService, jobQueryService),
-		ActionHandler:              handler.NewActionHandler(actionService),
-		TicketHandler:              ticketHandler,
-		FileHandler:                handler.NewFileHandler(fileService),
-		SystemHandler:              handler.NewSystemHandler(systemService),
+		AuthHandler:                   handler.NewAuthHandler(authService),
+		DepartmentHandler:             handler.NewDepartmentHandler(departmentService),
+		EmployeeHandler:               handler.NewEmployeeHandler(employeeService),
+		AreaHandler:                   handler.NewAreaHandler(areaService),
+		PhysicalLocationHandler:       handler.NewPhysicalLocationHandler(physicalLocationService),
+		AccessPermissionHandler:       handler.NewAccessPermissionHandler(accessPermissionService),
+		SectionStatusTicketHandler:    handler.NewSectionStatusTicketHandler(sectionStatusTicketService),
+		StatusTicketHandler:           handler.NewStatusTicketHandler(statusTicketService),
+		PositionPermissionHandler:     handler.NewPositionPermissionHandler(positionPermissionService),
+		EmployeePositionHandler:       handler.NewEmployeePositionHandler(employeePositionService),
+		WorkflowHandler:               handler.NewWorkflowHandler(workflowService),
+		SpecifiedLocationHandler:      handler.NewSpecifiedLocationHandler(specifiedLocationService),
+		RejectedTicketHandler:         handler.NewRejectedTicketHandler(rejectedTicketService),
+		JobHandler:                    handler.NewJobHandler(jobService, jobQueryService),
+		ActionHandler:                 handler.NewActionHandler(actionService),
+		TicketHandler:                 ticketHandler,
+		FileHandler:                   handler.NewFileHandler(fileService),
+		SystemHandler:                 handler.NewSystemHandler(systemService),
+		TransitionPrerequisiteHandler: handler.NewTransitionPrerequisiteHandler(transitionPrerequisiteService),
+		SlaPolicyHandler:              handler.NewSlaPolicyHandler(slaPolicyService),
+		WorkCalendarHandler:           handler.NewWorkCalendarHandler(workCalendarService),
+		EditingLockHandler:            handler.NewEditingLockHandler(editingLockService),
+		NotificationPreferenceHandler: handler.NewNotificationPreferenceHandler(notificationPreferenceService),
+		NotificationHandler:           handler.NewNotificationHandler(notificationService),
+		WebhookHandler:                handler.NewWebhookHandler(webhookService),
+		ActorRoleHandler:              handler.NewActorRoleHandler(actorRoleService),
+		ActorRoleMappingHandler:       handler.NewActorRoleMappingHandler(actorRoleMappingService),
+		StatusTransitionHandler:       handler.NewStatusTransitionHandler(statusTransitionService),
+		WorkflowGraphHandler:          handler.NewWorkflowGraphHandler(workflowGraphService),
+		WorkflowSimulationHandler:     handler.NewWorkflowSimulationHandler(workflowSimulationService),
+		WorkflowVersionHandler:        handler.NewWorkflowVersionHandler(workflowVersionService),
+		EmployeeDelegationHandler:     handler.NewEmployeeDelegationHandler(employeeDelegationService),
+		EscalationRuleHandler:         handler.NewEscalationRuleHandler(escalationRuleService),
+		ActionReasonCodeHandler:       handler.NewActionReasonCodeHandler(actionReasonCodeService),
+		TicketLinkHandler:             handler.NewTicketLinkHandler(ticketLinkService),
+		TicketCommentHandler:          handler.NewTicketCommentHandler(ticketCommentService),
+		TicketWatcherHandler:          handler.NewTicketWatcherHandler(ticketWatcherService),
 	}
 
 	allRepositories := &router.AllRepositories{
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Local SMTP stand-in for development, accepts every message and prints it (or stores it in FAKE_SMTP_DIR).
+// FAKE_SMTP_FAIL_RATE (0-1) rejects that share of messages with a temporary error to exercise the retries.
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found")
+	}
+
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "2525"
+	}
+	outputDir := os.Getenv("FAKE_SMTP_DIR")
+	if outputDir != "" {
+		if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
+			log.Fatalf("Could not create output directory: %v", err)
+		}
+	}
+	failRate, _ := strconv.ParseFloat(os.Getenv("FAKE_SMTP_FAIL_RATE"), 64)
+
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		log.Fatalf("Could not listen on port %s: %v", port, err)
+	}
+	log.Printf("Fake SMTP server listening on :%s", port)
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			log.Printf("Accept error: %v", err)
+			continue
+		}
+		go handleSession(conn, outputDir, failRate)
+	}
+}
+
+func handleSession(conn net.Conn, outputDir string, failRate float64) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		fmt.Fprintf(conn, "%s\r\n", line)
+	}
+
+	reply("220 fakesmtp ready")
+
+	var from string
+	var recipients []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(command)
+
+		switch {
+		case strings.HasPrefix(verb, "EHLO"):
+			reply("250-fakesmtp")
+			reply("250 AUTH PLAIN LOGIN")
+		case strings.HasPrefix(verb, "HELO"):
+			reply("250 fakesmtp")
+		case strings.HasPrefix(verb, "AUTH"):
+			reply("235 authentication accepted")
+		case strings.HasPrefix(verb, "MAIL FROM:"):
+			from = strings.TrimSpace(command[len("MAIL FROM:"):])
+			recipients = nil
+			reply("250 OK")
+		case strings.HasPrefix(verb, "RCPT TO:"):
+			recipients = append(recipients, strings.TrimSpace(command[len("RCPT TO:"):]))
+			reply("250 OK")
+		case verb == "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+			data, err := readData(reader)
+			if err != nil {
+				return
+			}
+			if failRate > 0 && rand.Float64() < failRate {
+				reply("451 temporary failure (simulated)")
+				continue
+			}
+			storeMessage(outputDir, from, recipients, data)
+			reply("250 OK message accepted")
+		case verb == "RSET":
+			from, recipients = "", nil
+			reply("250 OK")
+		case verb == "NOOP":
+			reply("250 OK")
+		case verb == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 command not implemented")
+		}
+	}
+}
+
+func readData(reader *bufio.Reader) (string, error) {
+	var sb strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		if strings.TrimRight(line, "\r\n") == "." {
+			return sb.String(), nil
+		}
+		sb.WriteString(strings.TrimPrefix(line, "."))
+	}
+}
+
+func storeMessage(outputDir string, from string, recipients []string, data string) {
+	log.Printf("Received message from %s to %s", from, strings.Join(recipients, ", "))
+	if outputDir == "" {
+		fmt.Println(data)
+		return
+	}
+
+	fileName := filepath.Join(outputDir, fmt.Sprintf("%d.eml", time.Now().UnixNano()))
+	if err := os.WriteFile(fileName, []byte(data), 0644); err != nil {
+		log.Printf("Could not store message: %v", err)
+	}
+}
@@ -0,0 +1,685 @@
+package main
+
+type migration struct {
+	Name string
+	SQL  string
+}
+
+// migrations are executed in order on every run, each statement must be idempotent
+var migrations = []migration{
+	{
+		Name: "create websocket_tickets table",
+		SQL: `
+-- Create websocket_tickets table if it doesn't exist
+-- This table stores temporary tickets for WebSocket connections
+-- Supports both authenticated users (with user_id) and public/anonymous connections (user_id = NULL)
+
+CREATE TABLE IF NOT EXISTS public.websocket_tickets (
+    ticket TEXT PRIMARY KEY,
+    user_id BIGINT,  -- Nullable to support public/anonymous WebSocket connections
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+-- Add foreign key constraint if not exists
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint 
+        WHERE conname = 'websocket_tickets_user_id_fkey'
+    ) THEN
+        ALTER TABLE public.websocket_tickets
+        ADD CONSTRAINT websocket_tickets_user_id_fkey 
+        FOREIGN KEY (user_id) REFERENCES public.app_user(id) ON DELETE CASCADE;
+    END IF;
+END $$;
+
+-- Add index on expires_at for cleanup operations
+CREATE INDEX IF NOT EXISTS idx_websocket_tickets_expires_at 
+ON public.websocket_tickets(expires_at);
+
+-- Add index on user_id for user lookup
+CREATE INDEX IF NOT EXISTS idx_websocket_tickets_user_id 
+ON public.websocket_tickets(user_id);
+
+-- Add comment to explain the nullable user_id
+COMMENT ON COLUMN public.websocket_tickets.user_id IS 'User ID for authenticated users, NULL for public/anonymous connections';
+COMMENT ON TABLE public.websocket_tickets IS 'Temporary tickets for WebSocket connections. Supports both authenticated and anonymous users.';
+`,
+	},
+	{
+		Name: "create sla_policy table",
+		SQL: `
+-- SLA target for how long a ticket may stay in a status
+-- department_id NULL means the policy applies to every target department without a specific policy
+-- max_days is counted in working days once the work calendar is installed
+
+CREATE TABLE IF NOT EXISTS public.sla_policy (
+    id SERIAL PRIMARY KEY,
+    status_ticket_id SMALLINT NOT NULL REFERENCES public.status_ticket(id) ON DELETE CASCADE,
+    department_id SMALLINT REFERENCES public.department(id) ON DELETE CASCADE,
+    max_days SMALLINT NOT NULL CHECK (max_days > 0),
+    warning_percentage SMALLINT DEFAULT 80 NOT NULL CHECK (warning_percentage BETWEEN 1 AND 99),
+    is_active BOOLEAN DEFAULT false NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_sla_policy_status_department
+ON public.sla_policy(status_ticket_id, COALESCE(department_id, 0));
+
+-- Remember which status periods were already reported so the worker only notifies once
+ALTER TABLE public.track_status_ticket ADD COLUMN IF NOT EXISTS sla_warning_sent_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE public.track_status_ticket ADD COLUMN IF NOT EXISTS sla_breached_at TIMESTAMP WITH TIME ZONE;
+`,
+	},
+	{
+		Name: "create work calendar tables and functions",
+		SQL: `
+-- Weekly work days, day_of_week follows EXTRACT(DOW): 0 = Sunday ... 6 = Saturday
+CREATE TABLE IF NOT EXISTS public.work_day (
+    day_of_week SMALLINT PRIMARY KEY CHECK (day_of_week BETWEEN 0 AND 6),
+    is_work_day BOOLEAN DEFAULT false NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+INSERT INTO public.work_day (day_of_week, is_work_day) VALUES
+    (0, false), (1, true), (2, true), (3, true), (4, true), (5, true), (6, false)
+ON CONFLICT (day_of_week) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS public.holiday (
+    id SERIAL PRIMARY KEY,
+    holiday_date DATE NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    is_active BOOLEAN DEFAULT true NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE OR REPLACE FUNCTION public.is_working_day(check_date DATE) RETURNS BOOLEAN
+LANGUAGE sql STABLE STRICT AS $$
+    SELECT EXISTS (
+        SELECT 1 FROM public.work_day wd
+        WHERE wd.day_of_week = EXTRACT(DOW FROM check_date)::smallint AND wd.is_work_day = true
+    ) AND NOT EXISTS (
+        SELECT 1 FROM public.holiday h
+        WHERE h.holiday_date = check_date AND h.is_active = true
+    )
+$$;
+
+-- Signed number of working days in (from_date, to_date], negative when to_date is before from_date
+-- Counted from whole weeks and the leftover week days, then minus the holidays on work days, so no day is visited
+CREATE OR REPLACE FUNCTION public.working_days_between(from_date DATE, to_date DATE) RETURNS INTEGER
+LANGUAGE sql STABLE STRICT AS $$
+    WITH span AS (
+        SELECT LEAST(from_date, to_date) AS lo, GREATEST(from_date, to_date) AS hi,
+               GREATEST(from_date, to_date) - LEAST(from_date, to_date) AS days
+    )
+    SELECT (CASE WHEN to_date >= from_date THEN 1 ELSE -1 END) * (
+        (span.days / 7) * (SELECT COUNT(*) FROM public.work_day WHERE is_work_day = true)
+        + (SELECT COUNT(*) FROM public.work_day wd
+           WHERE wd.is_work_day = true
+             AND (wd.day_of_week - EXTRACT(DOW FROM span.lo)::integer + 6) % 7 < span.days % 7)
+        - (SELECT COUNT(*) FROM public.holiday h
+           JOIN public.work_day wd ON wd.day_of_week = EXTRACT(DOW FROM h.holiday_date)::smallint AND wd.is_work_day = true
+           WHERE h.is_active = true AND h.holiday_date > span.lo AND h.holiday_date <= span.hi)
+    )::integer
+    FROM span
+$$;
+
+-- Moves start_at forward by the given number of working days, keeping the time of day
+-- The candidate days are joined once against the calendar; each needed working day or holiday
+-- is at most a week away, which bounds the series
+CREATE OR REPLACE FUNCTION public.add_working_days(start_at TIMESTAMP WITH TIME ZONE, days INTEGER) RETURNS TIMESTAMP WITH TIME ZONE
+LANGUAGE sql STABLE STRICT AS $$
+    SELECT CASE WHEN days <= 0 THEN start_at ELSE COALESCE((
+        SELECT start_at + make_interval(days => d.day::date - start_at::date)
+        FROM generate_series(
+            start_at::date + 1,
+            start_at::date + 7 * (days + (
+                SELECT COUNT(*)::integer FROM public.holiday
+                WHERE is_active = true AND holiday_date > start_at::date
+            )),
+            interval '1 day'
+        ) d(day)
+        JOIN public.work_day wd ON wd.day_of_week = EXTRACT(DOW FROM d.day)::smallint AND wd.is_work_day = true
+        LEFT JOIN public.holiday h ON h.holiday_date = d.day::date AND h.is_active = true
+        WHERE h.id IS NULL
+        ORDER BY d.day
+        OFFSET days - 1 LIMIT 1
+    ), start_at + make_interval(days => days)) END
+$$;
+`,
+	},
+	{
+		Name: "create websocket_event table",
+		SQL: `
+-- Events too large for a NOTIFY payload, the backplane sends their id instead
+CREATE TABLE IF NOT EXISTS public.websocket_event (
+    id BIGSERIAL PRIMARY KEY,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_websocket_event_created_at
+ON public.websocket_event(created_at);
+`,
+	},
+	{
+		Name: "create editing_lock table",
+		SQL: `
+-- Editing locks outlive a websocket hub restart, a lock is only valid until expires_at
+CREATE TABLE IF NOT EXISTS public.editing_lock (
+    lock_key TEXT PRIMARY KEY,
+    entity TEXT NOT NULL,
+    context_id INTEGER NOT NULL,
+    user_id BIGINT NOT NULL REFERENCES public.app_user(id) ON DELETE CASCADE,
+    holder_npk TEXT,
+    client_id TEXT,
+    acquired_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    heartbeat_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_editing_lock_expires_at
+ON public.editing_lock(expires_at);
+
+CREATE INDEX IF NOT EXISTS idx_editing_lock_client_id
+ON public.editing_lock(client_id);
+`,
+	},
+	{
+		Name: "create email notification tables",
+		SQL: `
+CREATE TABLE IF NOT EXISTS public.notification_preference (
+    employee_npk TEXT PRIMARY KEY REFERENCES public.employee(npk) ON DELETE CASCADE,
+    email TEXT,
+    language VARCHAR(2) DEFAULT 'id' NOT NULL CHECK (language IN ('id', 'en')),
+    email_enabled BOOLEAN DEFAULT true NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+-- Outgoing emails, the worker sends due rows and retries failures with backoff
+CREATE TABLE IF NOT EXISTS public.email_queue (
+    id BIGSERIAL PRIMARY KEY,
+    recipient_npk TEXT REFERENCES public.employee(npk) ON DELETE SET NULL,
+    recipient_email TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    event TEXT NOT NULL,
+    ticket_id BIGINT REFERENCES public.ticket(id) ON DELETE SET NULL,
+    status VARCHAR(20) DEFAULT 'PENDING' NOT NULL CHECK (status IN ('PENDING', 'SENT', 'FAILED')),
+    attempts INTEGER DEFAULT 0 NOT NULL,
+    next_attempt_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    last_error TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_queue_pending
+ON public.email_queue(next_attempt_at) WHERE status = 'PENDING';
+`,
+	},
+	{
+		Name: "create notification table",
+		SQL: `
+-- In-app notification inbox, one row per recipient
+CREATE TABLE IF NOT EXISTS public.notification (
+    id BIGSERIAL PRIMARY KEY,
+    recipient_npk TEXT NOT NULL REFERENCES public.employee(npk) ON DELETE CASCADE,
+    ticket_id BIGINT REFERENCES public.ticket(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    title TEXT NOT NULL,
+    message TEXT NOT NULL,
+    actor_npk TEXT REFERENCES public.employee(npk) ON DELETE SET NULL,
+    is_read BOOLEAN DEFAULT false NOT NULL,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_recipient_created_at
+ON public.notification(recipient_npk, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_notification_recipient_unread
+ON public.notification(recipient_npk) WHERE is_read = false;
+`,
+	},
+	{
+		Name: "create webhook tables",
+		SQL: `
+CREATE TABLE IF NOT EXISTS public.webhook_endpoint (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    events TEXT[] NOT NULL,
+    is_active BOOLEAN DEFAULT false NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+-- One row per event and endpoint, the worker posts due rows and retries failures with backoff
+CREATE TABLE IF NOT EXISTS public.webhook_delivery (
+    id BIGSERIAL PRIMARY KEY,
+    endpoint_id INTEGER NOT NULL REFERENCES public.webhook_endpoint(id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) DEFAULT 'PENDING' NOT NULL CHECK (status IN ('PENDING', 'SUCCESS', 'FAILED')),
+    attempts INTEGER DEFAULT 0 NOT NULL,
+    next_attempt_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    last_status_code INTEGER,
+    last_error TEXT,
+    redelivery_of BIGINT REFERENCES public.webhook_delivery(id) ON DELETE SET NULL,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_pending
+ON public.webhook_delivery(next_attempt_at) WHERE status = 'PENDING';
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_endpoint_id
+ON public.webhook_delivery(endpoint_id, created_at DESC);
+`,
+	},
+	{
+		Name: "create outbox event table",
+		SQL: `
+-- Written in the same transaction as the ticket change and relayed by the API dispatcher
+CREATE TABLE IF NOT EXISTS public.outbox_event (
+    id BIGSERIAL PRIMARY KEY,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id BIGINT NOT NULL,
+    event TEXT NOT NULL,
+    payload JSONB DEFAULT '{}'::jsonb NOT NULL,
+    status VARCHAR(20) DEFAULT 'PENDING' NOT NULL CHECK (status IN ('PENDING', 'PROCESSED', 'FAILED')),
+    attempts INTEGER DEFAULT 0 NOT NULL,
+    next_attempt_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    last_error TEXT,
+    processed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_event_pending
+ON public.outbox_event(aggregate_type, aggregate_id, id) WHERE status = 'PENDING';
+`,
+	},
+	{
+		Name: "add is_terminal to status_ticket",
+		SQL: `
+-- Terminal statuses end a ticket, the workflow designer allows them to have no outgoing transition
+ALTER TABLE public.status_ticket ADD COLUMN IF NOT EXISTS is_terminal BOOLEAN DEFAULT false NOT NULL;
+`,
+	},
+	{
+		Name: "create workflow version tables",
+		SQL: `
+-- A published workflow version is a frozen copy of its steps and of the transitions leaving them,
+-- tickets are pinned to the version that was current when they were created
+CREATE TABLE IF NOT EXISTS public.workflow_version (
+    id SERIAL PRIMARY KEY,
+    workflow_id SMALLINT NOT NULL REFERENCES public.workflow(id),
+    version_number INTEGER NOT NULL,
+    note TEXT,
+    published_by_npk TEXT REFERENCES public.employee(npk),
+    published_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    UNIQUE (workflow_id, version_number)
+);
+
+CREATE TABLE IF NOT EXISTS public.workflow_version_step (
+    id SERIAL PRIMARY KEY,
+    workflow_version_id INTEGER NOT NULL REFERENCES public.workflow_version(id) ON DELETE CASCADE,
+    status_ticket_id SMALLINT NOT NULL REFERENCES public.status_ticket(id),
+    step_sequence INTEGER NOT NULL,
+    UNIQUE (workflow_version_id, step_sequence)
+);
+
+-- Active prerequisites of the transition are kept in required_status_ids
+CREATE TABLE IF NOT EXISTS public.workflow_version_transition (
+    id SERIAL PRIMARY KEY,
+    workflow_version_id INTEGER NOT NULL REFERENCES public.workflow_version(id) ON DELETE CASCADE,
+    from_status_id SMALLINT REFERENCES public.status_ticket(id),
+    to_status_id SMALLINT NOT NULL REFERENCES public.status_ticket(id),
+    action_id SMALLINT NOT NULL REFERENCES public.action(id),
+    actor_role_id SMALLINT NOT NULL REFERENCES public.actor_role(id),
+    require_reason BOOLEAN DEFAULT false NOT NULL,
+    reason_label TEXT,
+    require_file BOOLEAN DEFAULT false NOT NULL,
+    required_status_ids INTEGER[] DEFAULT '{}' NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_version_transition_from
+ON public.workflow_version_transition(workflow_version_id, from_status_id);
+
+-- Tickets created before their workflow was first published keep following the live transitions
+ALTER TABLE public.ticket ADD COLUMN IF NOT EXISTS workflow_version_id INTEGER REFERENCES public.workflow_version(id);
+
+CREATE TABLE IF NOT EXISTS public.workflow_ticket_migration (
+    id BIGSERIAL PRIMARY KEY,
+    ticket_id BIGINT NOT NULL REFERENCES public.ticket(id) ON DELETE CASCADE,
+    from_version_id INTEGER REFERENCES public.workflow_version(id),
+    to_version_id INTEGER NOT NULL REFERENCES public.workflow_version(id),
+    from_status_id SMALLINT NOT NULL,
+    to_status_id SMALLINT NOT NULL,
+    migrated_by_npk TEXT REFERENCES public.employee(npk),
+    migrated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+`,
+	},
+	{
+		Name: "add approval quorum to transitions",
+		SQL: `
+-- A transition whose required_approvals is above one only moves the ticket once that many distinct actor roles
+-- allowed to perform the action from the same status have approved, the highest value of the group applies
+ALTER TABLE public.status_transition ADD COLUMN IF NOT EXISTS required_approvals SMALLINT DEFAULT 1 NOT NULL;
+ALTER TABLE public.workflow_version_transition ADD COLUMN IF NOT EXISTS required_approvals SMALLINT DEFAULT 1 NOT NULL;
+
+-- Approvals that did not complete the quorum are logged without moving the ticket
+ALTER TABLE public.ticket_action_log ADD COLUMN IF NOT EXISTS approval_actor_role_id SMALLINT REFERENCES public.actor_role(id);
+ALTER TABLE public.ticket_action_log ADD COLUMN IF NOT EXISTS is_partial_approval BOOLEAN DEFAULT false NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_ticket_action_log_approval
+ON public.ticket_action_log(ticket_id, from_status_id, action_id) WHERE approval_actor_role_id IS NOT NULL;
+`,
+	},
+	{
+		Name: "create employee_delegation table",
+		SQL: `
+-- An employee hands their actor roles to another employee for a date range, e.g. while on leave
+CREATE TABLE IF NOT EXISTS public.employee_delegation (
+    id SERIAL PRIMARY KEY,
+    delegator_npk TEXT NOT NULL REFERENCES public.employee(npk),
+    delegate_npk TEXT NOT NULL REFERENCES public.employee(npk),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    reason TEXT,
+    is_active BOOLEAN DEFAULT true NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    CHECK (delegator_npk <> delegate_npk),
+    CHECK (end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_employee_delegation_delegate
+ON public.employee_delegation(delegate_npk, start_date, end_date) WHERE is_active = true;
+
+-- Actions performed with a delegated role keep the employee the delegate acted for
+ALTER TABLE public.ticket_action_log ADD COLUMN IF NOT EXISTS on_behalf_of_npk TEXT REFERENCES public.employee(npk);
+`,
+	},
+	{
+		Name: "create escalation tables",
+		SQL: `
+-- Escalation rule for tickets stalled in a status, NOTIFY tells the holders of an actor role,
+-- AUTO_ACTION executes the action as the system. department_id NULL applies to every target department.
+CREATE TABLE IF NOT EXISTS public.escalation_rule (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    status_ticket_id SMALLINT NOT NULL REFERENCES public.status_ticket(id) ON DELETE CASCADE,
+    department_id SMALLINT REFERENCES public.department(id) ON DELETE CASCADE,
+    after_hours INTEGER NOT NULL CHECK (after_hours > 0),
+    escalation_type TEXT NOT NULL CHECK (escalation_type IN ('NOTIFY', 'AUTO_ACTION')),
+    notify_actor_role_id SMALLINT REFERENCES public.actor_role(id),
+    action_id SMALLINT REFERENCES public.action(id),
+    is_active BOOLEAN DEFAULT false NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    CHECK (
+        (escalation_type = 'NOTIFY' AND notify_actor_role_id IS NOT NULL)
+        OR (escalation_type = 'AUTO_ACTION' AND action_id IS NOT NULL)
+    )
+);
+
+-- A rule fires at most once per status period of a ticket
+CREATE TABLE IF NOT EXISTS public.ticket_escalation (
+    id BIGSERIAL PRIMARY KEY,
+    escalation_rule_id INTEGER NOT NULL REFERENCES public.escalation_rule(id) ON DELETE CASCADE,
+    track_status_ticket_id BIGINT NOT NULL,
+    ticket_id BIGINT NOT NULL REFERENCES public.ticket(id) ON DELETE CASCADE,
+    escalated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    UNIQUE (escalation_rule_id, track_status_ticket_id)
+);
+
+-- Entries written by the worker have no employee, system_actor names what performed them instead.
+-- An escalation notice leaves the ticket in its status and has no action.
+ALTER TABLE public.ticket_action_log ALTER COLUMN performed_by_npk DROP NOT NULL;
+ALTER TABLE public.ticket_action_log ALTER COLUMN action_id DROP NOT NULL;
+ALTER TABLE public.ticket_action_log ADD COLUMN IF NOT EXISTS system_actor TEXT;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint
+        WHERE conname = 'ticket_action_log_performer_check'
+    ) THEN
+        ALTER TABLE public.ticket_action_log
+        ADD CONSTRAINT ticket_action_log_performer_check
+        CHECK (system_actor IS NOT NULL OR (performed_by_npk IS NOT NULL AND action_id IS NOT NULL));
+    END IF;
+END $$;
+`,
+	},
+	{
+		Name: "add reminders to escalation rules",
+		SQL: `
+-- An automatic action can be announced reminder_hours before it happens, typically to the requestor
+-- who still has to confirm a finished job. on_behalf_of_requestor logs the action for the requestor.
+ALTER TABLE public.escalation_rule ADD COLUMN IF NOT EXISTS reminder_hours INTEGER CHECK (reminder_hours > 0);
+ALTER TABLE public.escalation_rule ADD COLUMN IF NOT EXISTS on_behalf_of_requestor BOOLEAN DEFAULT false NOT NULL;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint
+        WHERE conname = 'escalation_rule_reminder_check'
+    ) THEN
+        ALTER TABLE public.escalation_rule
+        ADD CONSTRAINT escalation_rule_reminder_check
+        CHECK (
+            (reminder_hours IS NULL OR (escalation_type = 'AUTO_ACTION' AND reminder_hours < after_hours))
+            AND (on_behalf_of_requestor = false OR escalation_type = 'AUTO_ACTION')
+        );
+    END IF;
+END $$;
+
+-- The row is created by the reminder, escalated_at stays empty until the rule itself fires
+ALTER TABLE public.ticket_escalation ADD COLUMN IF NOT EXISTS reminder_sent_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE public.ticket_escalation ALTER COLUMN escalated_at DROP NOT NULL;
+ALTER TABLE public.ticket_escalation ALTER COLUMN escalated_at DROP DEFAULT;
+`,
+	},
+	{
+		Name: "create action reason codes",
+		SQL: `
+-- Structured reasons for an action, once an action has active codes one of them must be given when it is executed
+CREATE TABLE IF NOT EXISTS public.action_reason_code (
+    id SERIAL PRIMARY KEY,
+    action_id SMALLINT NOT NULL REFERENCES public.action(id) ON DELETE CASCADE,
+    code TEXT NOT NULL,
+    name TEXT NOT NULL,
+    is_active BOOLEAN DEFAULT false NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    UNIQUE (action_id, code)
+);
+
+ALTER TABLE public.ticket_action_log ADD COLUMN IF NOT EXISTS reason_code_id INTEGER REFERENCES public.action_reason_code(id);
+
+-- Actions behind the cancel and reopen commands, their transitions and actor roles are configured like any other
+INSERT INTO public.action (name, is_active, hex_code)
+SELECT 'Batalkan', true, '#6B7280'
+WHERE NOT EXISTS (SELECT 1 FROM public.action WHERE name = 'Batalkan');
+
+INSERT INTO public.action (name, is_active, hex_code)
+SELECT 'Buka Kembali', true, '#F59E0B'
+WHERE NOT EXISTS (SELECT 1 FROM public.action WHERE name = 'Buka Kembali');
+`,
+	},
+	{
+		Name: "support multiple jobs per ticket",
+		SQL: `
+-- A ticket owns one or more jobs, each with its own PIC, priority, report files and spending amount.
+-- A job is DONE once it is completed on its own, the ticket can only be finished when none is OPEN.
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = 'public' AND table_name = 'job' AND column_name = 'status'
+    ) THEN
+        ALTER TABLE public.job ADD COLUMN status TEXT DEFAULT 'OPEN' NOT NULL;
+
+        -- The single job of a ticket is done when its last Selesaikan Job was not undone afterwards,
+        -- by Tolak Hasil Job, by reopening the ticket or by any move back into a status the job is worked in
+        UPDATE public.job j SET status = 'DONE'
+        FROM (
+            SELECT l.ticket_id, MAX(l.performed_at) as completed_at
+            FROM public.ticket_action_log l
+            JOIN public.action a ON l.action_id = a.id
+            WHERE a.name = 'Selesaikan Job'
+              AND l.is_partial_approval = false
+            GROUP BY l.ticket_id
+        ) completed
+        WHERE j.ticket_id = completed.ticket_id
+          AND NOT EXISTS (
+              SELECT 1 FROM public.ticket_action_log later
+              LEFT JOIN public.action la ON later.action_id = la.id
+              WHERE later.ticket_id = j.ticket_id
+                AND later.performed_at > completed.completed_at
+                AND later.is_partial_approval = false
+                AND (
+                    la.name IN ('Tolak Hasil Job', 'Buka Kembali')
+                    OR EXISTS (
+                        SELECT 1 FROM public.status_transition st
+                        JOIN public.action sa ON st.action_id = sa.id
+                        WHERE st.from_status_id = later.to_status_id
+                          AND st.is_active = true
+                          AND sa.name = 'Selesaikan Job'
+                    )
+                    OR EXISTS (
+                        SELECT 1 FROM public.workflow_version_transition vt
+                        JOIN public.action va ON vt.action_id = va.id
+                        WHERE vt.from_status_id = later.to_status_id
+                          AND va.name = 'Selesaikan Job'
+                    )
+                )
+          );
+    END IF;
+END $$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint
+        WHERE conname = 'job_status_check'
+    ) THEN
+        ALTER TABLE public.job
+        ADD CONSTRAINT job_status_check CHECK (status IN ('OPEN', 'DONE'));
+    END IF;
+END $$;
+
+ALTER TABLE public.job ADD COLUMN IF NOT EXISTS title TEXT;
+ALTER TABLE public.job ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE public.job ADD COLUMN IF NOT EXISTS completed_by_npk TEXT REFERENCES public.employee(npk);
+
+CREATE INDEX IF NOT EXISTS idx_job_ticket_id ON public.job(ticket_id);
+`,
+	},
+	{
+		Name: "create ticket_link table",
+		SQL: `
+-- Typed links between tickets, stored in one direction. The inverse (duplicated by, blocked by, child of)
+-- is derived when the links of a ticket are read. Two tickets are linked at most once per type.
+CREATE TABLE IF NOT EXISTS public.ticket_link (
+    id SERIAL PRIMARY KEY,
+    ticket_id BIGINT NOT NULL REFERENCES public.ticket(id) ON DELETE CASCADE,
+    linked_ticket_id BIGINT NOT NULL REFERENCES public.ticket(id) ON DELETE CASCADE,
+    link_type TEXT NOT NULL CHECK (link_type IN ('DUPLICATE_OF', 'BLOCKS', 'PARENT_OF', 'RELATED')),
+    created_by_npk TEXT NOT NULL REFERENCES public.employee(npk),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    CHECK (ticket_id <> linked_ticket_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_ticket_link_pair
+ON public.ticket_link (LEAST(ticket_id, linked_ticket_id), GREATEST(ticket_id, linked_ticket_id), link_type);
+
+-- A ticket is the duplicate of one original at most
+CREATE UNIQUE INDEX IF NOT EXISTS uq_ticket_link_duplicate
+ON public.ticket_link (ticket_id) WHERE link_type = 'DUPLICATE_OF';
+
+CREATE INDEX IF NOT EXISTS idx_ticket_link_linked_ticket_id ON public.ticket_link(linked_ticket_id);
+
+-- Action closing a duplicate into its original, its transitions and actor roles are configured like any other
+INSERT INTO public.action (name, is_active, hex_code)
+SELECT 'Tandai Duplikat', true, '#9CA3AF'
+WHERE NOT EXISTS (SELECT 1 FROM public.action WHERE name = 'Tandai Duplikat');
+`,
+	},
+	{
+		Name: "create ticket comment tables",
+		SQL: `
+-- Discussion on a ticket. A reply points to the comment it answers, deleted comments keep their row
+-- so their replies stay in the thread. Every edit and the deletion store the previous body in the history.
+CREATE TABLE IF NOT EXISTS public.ticket_comment (
+    id BIGSERIAL PRIMARY KEY,
+    ticket_id BIGINT NOT NULL REFERENCES public.ticket(id) ON DELETE CASCADE,
+    parent_id BIGINT REFERENCES public.ticket_comment(id),
+    author_npk TEXT NOT NULL REFERENCES public.employee(npk),
+    body TEXT NOT NULL,
+    attachments JSONB DEFAULT '[]'::jsonb NOT NULL,
+    mentioned_npks TEXT[] DEFAULT '{}' NOT NULL,
+    edited_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_comment_ticket_id ON public.ticket_comment(ticket_id, created_at);
+
+CREATE TABLE IF NOT EXISTS public.ticket_comment_history (
+    id BIGSERIAL PRIMARY KEY,
+    comment_id BIGINT NOT NULL REFERENCES public.ticket_comment(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    change_type TEXT NOT NULL CHECK (change_type IN ('EDITED', 'DELETED')),
+    changed_by_npk TEXT NOT NULL REFERENCES public.employee(npk),
+    changed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_comment_history_comment_id ON public.ticket_comment_history(comment_id);
+`,
+	},
+	{
+		Name: "create ticket_watcher table",
+		SQL: `
+-- Employees following a ticket besides its requestor and PICs, they receive the ticket's events
+CREATE TABLE IF NOT EXISTS public.ticket_watcher (
+    ticket_id BIGINT NOT NULL REFERENCES public.ticket(id) ON DELETE CASCADE,
+    employee_npk TEXT NOT NULL REFERENCES public.employee(npk) ON DELETE CASCADE,
+    added_by_npk TEXT NOT NULL REFERENCES public.employee(npk),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (ticket_id, employee_npk)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_watcher_employee_npk ON public.ticket_watcher(employee_npk);
+`,
+	},
+	{
+		Name: "deduplicate outbox deliveries",
+		SQL: `
+-- A retried outbox event must not queue the same email, notification or webhook delivery again.
+-- Rows created outside the outbox keep a NULL outbox_event_id, which never conflicts.
+ALTER TABLE public.email_queue ADD COLUMN IF NOT EXISTS outbox_event_id BIGINT;
+ALTER TABLE public.notification ADD COLUMN IF NOT EXISTS outbox_event_id BIGINT;
+ALTER TABLE public.webhook_delivery ADD COLUMN IF NOT EXISTS outbox_event_id BIGINT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_email_queue_outbox_event
+ON public.email_queue(outbox_event_id, recipient_email);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_notification_outbox_event
+ON public.notification(outbox_event_id, recipient_npk);
+
+-- One outbox event can publish several webhook events, e.g. TICKET_STATUS_CHANGED and JOB_COMPLETED
+CREATE UNIQUE INDEX IF NOT EXISTS uq_webhook_delivery_outbox_event
+ON public.webhook_delivery(outbox_event_id, endpoint_id, event);
+`,
+	},
+}
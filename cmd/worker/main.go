@@ -9,8 +9,10 @@ import (
 
 	"e-memo-job-reservation-api/internal/repository"
 	"e-memo-job-reservation-api/internal/scheduler"
+	"e-memo-job-reservation-api/internal/service"
 	"e-memo-job-reservation-api/internal/websocket"
 	"e-memo-job-reservation-api/pkg/database"
+	"e-memo-job-reservation-api/pkg/mailer"
 
 	"github.com/joho/godotenv"
 	"github.com/robfig/cron/v3"
@@ -29,13 +31,43 @@ func main() {
 	authRepo := repository.NewAuthRepository(db)
 	ticketRepo := repository.NewTicketRepository(db)
 	jobRepo := repository.NewJobRepository(db)
+	workCalendarRepo := repository.NewWorkCalendarRepository(db)
+	emailQueueRepo := repository.NewEmailQueueRepository(db)
+	webhookEndpointRepo := repository.NewWebhookEndpointRepository(db)
+	webhookDeliveryRepo := repository.NewWebhookDeliveryRepository(db)
+	escalationRuleRepo := repository.NewEscalationRuleRepository(db)
+	trackStatusTicketRepo := repository.NewTrackStatusTicketRepository(db)
+	statusTransitionRepo := repository.NewStatusTransitionRepository(db)
+	workflowVersionRepo := repository.NewWorkflowVersionRepository(db)
+	ticketActionLogRepo := repository.NewTicketActionLogRepository(db)
+	outboxRepo := repository.NewOutboxRepository(db)
 
-	hub := websocket.NewHub(authRepo)
+	// The worker has no websocket clients, events reach the API instances through the backplane
+	hub := websocket.NewHub(authRepo, nil)
 	go hub.Run()
+	websocket.NewBackplane(db, hub)
+	webhookService := service.NewWebhookService(webhookEndpointRepo, webhookDeliveryRepo)
+
+	// The worker only records outbox events, the API instances relay them
+	outboxService := service.NewOutboxService(outboxRepo, ticketRepo, nil, hub, nil, nil, nil)
+	ticketEscalationService := service.NewTicketEscalationService(&service.TicketEscalationServiceConfig{
+		DB:                    db,
+		EscalationRuleRepo:    escalationRuleRepo,
+		TicketRepo:            ticketRepo,
+		TrackStatusTicketRepo: trackStatusTicketRepo,
+		StatusTransitionRepo:  statusTransitionRepo,
+		WorkflowVersionRepo:   workflowVersionRepo,
+		TicketActionLogRepo:   ticketActionLogRepo,
+		OutboxService:         outboxService,
+	})
 
 	// CREATE INSTANCE
-	ticketReorderJob := scheduler.NewTicketReorderJob(db, ticketRepo, hub)
-	jobReorderJob := scheduler.NewJobReorderJob(db, jobRepo, hub)
+	ticketReorderJob := scheduler.NewTicketReorderJob(db, ticketRepo, workCalendarRepo, hub)
+	jobReorderJob := scheduler.NewJobReorderJob(db, jobRepo, workCalendarRepo, hub)
+	ticketSlaJob := scheduler.NewTicketSlaJob(db, hub, webhookService)
+	ticketEscalationJob := scheduler.NewTicketEscalationJob(ticketEscalationService)
+	emailDispatchJob := scheduler.NewEmailDispatchJob(emailQueueRepo, mailer.NewFromEnv())
+	webhookDispatchJob := scheduler.NewWebhookDispatchJob(webhookDeliveryRepo, webhookEndpointRepo)
 
 	// INIT SCHEDULER
 	jakartaLocation, err := time.LoadLocation("Asia/Jakarta")
@@ -46,6 +78,10 @@ func main() {
 
 	c.AddJob("*/30 * * * *", ticketReorderJob)
 	c.AddJob("1-59/30 * * * *", jobReorderJob)
+	c.AddJob("*/5 * * * *", ticketSlaJob)
+	c.AddJob("2-59/5 * * * *", cron.NewChain(cron.SkipIfStillRunning(cron.DefaultLogger)).Then(ticketEscalationJob))
+	c.AddJob("@every 30s", cron.NewChain(cron.SkipIfStillRunning(cron.DefaultLogger)).Then(emailDispatchJob))
+	c.AddJob("@every 15s", cron.NewChain(cron.SkipIfStillRunning(cron.DefaultLogger)).Then(webhookDispatchJob))
 
 	c.Start()
 	log.Println("Cron job scheduler started.")
@@ -12,16 +12,42 @@ type ActionResponse struct {
 }
 
 type AvailableTicketActionResponse struct {
-	ActionName    string  `json:"action_name"`
-	ActionID      int     `json:"-"`
-	ToStatusID    int     `json:"-"`
-	HexCode       *string `json:"hex_code"`
-	RequireReason bool    `json:"require_reason"`
-	ReasonLabel   *string `json:"reason_label"`
-	RequireFile   bool    `json:"require_file"`
+	ActionName         string  `json:"action_name"`
+	ActionID           int     `json:"-"`
+	FromStatusID       int     `json:"-"`
+	ToStatusID         int     `json:"-"`
+	HexCode            *string `json:"hex_code"`
+	RequireReason      bool    `json:"require_reason"`
+	ReasonLabel        *string `json:"reason_label"`
+	RequireFile        bool    `json:"require_file"`
+	RequiredStatusID   *int    `json:"-"`
+	RequiredStatusName *string `json:"required_status_name,omitempty"`
+	ActorRoleID        int     `json:"-"`
+	RequiredApprovals  int     `json:"required_approvals"`
+	OnBehalfOfNPK      *string `json:"on_behalf_of_npk,omitempty"`
+	IsBlocked          bool    `json:"is_blocked"`
+	BlockedReason      *string `json:"blocked_reason,omitempty"`
+	IsBlockedByTicket  bool    `json:"-"`
+
+	ReasonCodes []ReasonCodeOption `json:"reason_codes,omitempty"`
 }
 
 type TransitionDetail struct {
 	RequiredActorRole string
 	Action            AvailableTicketActionResponse
 }
+
+type CreateActionRequest struct {
+	Name    string  `json:"name" binding:"required"`
+	HexCode *string `json:"hex_code"`
+}
+
+type UpdateActionRequest struct {
+	Name     string  `json:"name" binding:"required"`
+	HexCode  *string `json:"hex_code"`
+	IsActive bool    `json:"is_active"`
+}
+
+type UpdateActionStatusRequest struct {
+	IsActive bool `json:"is_active"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+type CreateActionReasonCodeRequest struct {
+	ActionID int    `json:"action_id" binding:"required,gt=0"`
+	Code     string `json:"code" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+}
+
+type UpdateActionReasonCodeRequest struct {
+	ActionID int    `json:"action_id" binding:"required,gt=0"`
+	Code     string `json:"code" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	IsActive bool   `json:"is_active"`
+}
+
+type UpdateActionReasonCodeStatusRequest struct {
+	IsActive bool `json:"is_active"`
+}
+
+type ActionReasonCodeFilter struct {
+	ActionID int   `form:"action_id"`
+	IsActive *bool `form:"is_active"`
+}
+
+// ReasonCodeOption is an active reason code offered with an available action
+type ReasonCodeOption struct {
+	ID   int    `json:"id"`
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// TicketReasonRequest is the body of the cancel and reopen commands
+type TicketReasonRequest struct {
+	ReasonCodeID *int   `json:"reason_code_id"`
+	Reason       string `json:"reason"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+type CreateActorRoleRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type UpdateActorRoleRequest struct {
+	Name     string `json:"name" binding:"required"`
+	IsActive bool   `json:"is_active"`
+}
+
+type UpdateActorRoleStatusRequest struct {
+	IsActive bool `json:"is_active"`
+}
+
+type ActorRoleMappingRequest struct {
+	EmployeePositionID int    `json:"employee_position_id" binding:"required,gt=0"`
+	Context            string `json:"context" binding:"required,oneof=SELF REQUESTOR_DEPT TARGET_DEPT ASSIGNED"`
+	ActorRoleID        int    `json:"actor_role_id" binding:"required,gt=0"`
+}
+
+type ActorRoleMappingFilter struct {
+	EmployeePositionID int    `form:"employee_position_id"`
+	ActorRoleID        int    `form:"actor_role_id"`
+	Context            string `form:"context"`
+}
+
+type ActorRoleMappingDetailResponse struct {
+	ID                   int       `json:"id"`
+	EmployeePositionID   int       `json:"employee_position_id"`
+	EmployeePositionName string    `json:"employee_position_name"`
+	Context              string    `json:"context"`
+	ActorRoleID          int       `json:"actor_role_id"`
+	ActorRoleName        string    `json:"actor_role_name"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
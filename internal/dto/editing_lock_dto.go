@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+type EditingLockRequest struct {
+	Entity    string `json:"entity" binding:"required"`
+	ContextID int    `json:"context_id" binding:"required,gt=0"`
+}
+
+type EditingLockFilter struct {
+	Entity string `form:"entity"`
+	UserID int    `form:"user_id"`
+}
+
+type EditingLockResponse struct {
+	LockKey     string    `json:"lock_key"`
+	Entity      string    `json:"entity"`
+	ContextID   int       `json:"context_id"`
+	UserID      int       `json:"user_id"`
+	HolderNPK   *string   `json:"holder_npk"`
+	HolderName  string    `json:"holder_name"`
+	ClientID    *string   `json:"client_id"`
+	AcquiredAt  time.Time `json:"acquired_at"`
+	HeartbeatAt time.Time `json:"heartbeat_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
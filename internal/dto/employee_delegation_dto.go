@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+type CreateEmployeeDelegationRequest struct {
+	DelegateNPK string  `json:"delegate_npk" binding:"required"`
+	StartDate   string  `json:"start_date" binding:"required"` // "YYYY-MM-DD"
+	EndDate     string  `json:"end_date" binding:"required"`   // "YYYY-MM-DD"
+	Reason      *string `json:"reason"`
+}
+
+// EmployeeDelegationFilter lists the delegations the user gave, received, or both when Direction is empty
+type EmployeeDelegationFilter struct {
+	Direction string `form:"direction" binding:"omitempty,oneof=given received"`
+	IsActive  *bool  `form:"is_active"`
+}
+
+type EmployeeDelegationResponse struct {
+	ID            int       `json:"id"`
+	DelegatorNPK  string    `json:"delegator_npk"`
+	DelegatorName string    `json:"delegator_name"`
+	DelegateNPK   string    `json:"delegate_npk"`
+	DelegateName  string    `json:"delegate_name"`
+	StartDate     time.Time `json:"start_date"`
+	EndDate       time.Time `json:"end_date"`
+	Reason        *string   `json:"reason"`
+	IsActive      bool      `json:"is_active"`
+	IsCurrent     bool      `json:"is_current"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+type CreateEscalationRuleRequest struct {
+	Name                string `json:"name" binding:"required"`
+	StatusTicketID      int    `json:"status_ticket_id" binding:"required,gt=0"`
+	DepartmentID        *int   `json:"department_id"`
+	AfterHours          int    `json:"after_hours" binding:"required,gt=0"`
+	EscalationType      string `json:"escalation_type" binding:"required,oneof=NOTIFY AUTO_ACTION"`
+	NotifyActorRoleID   *int   `json:"notify_actor_role_id"`
+	ActionID            *int   `json:"action_id"`
+	ReminderHours       *int   `json:"reminder_hours" binding:"omitempty,gt=0"`
+	OnBehalfOfRequestor bool   `json:"on_behalf_of_requestor"`
+}
+
+type UpdateEscalationRuleRequest struct {
+	Name                string `json:"name" binding:"required"`
+	StatusTicketID      int    `json:"status_ticket_id" binding:"required,gt=0"`
+	DepartmentID        *int   `json:"department_id"`
+	AfterHours          int    `json:"after_hours" binding:"required,gt=0"`
+	EscalationType      string `json:"escalation_type" binding:"required,oneof=NOTIFY AUTO_ACTION"`
+	NotifyActorRoleID   *int   `json:"notify_actor_role_id"`
+	ActionID            *int   `json:"action_id"`
+	ReminderHours       *int   `json:"reminder_hours" binding:"omitempty,gt=0"`
+	OnBehalfOfRequestor bool   `json:"on_behalf_of_requestor"`
+	IsActive            bool   `json:"is_active"`
+}
+
+type UpdateEscalationRuleStatusRequest struct {
+	IsActive bool `json:"is_active"`
+}
+
+type EscalationRuleFilter struct {
+	StatusTicketID int    `form:"status_ticket_id"`
+	DepartmentID   int    `form:"department_id"`
+	EscalationType string `form:"escalation_type" binding:"omitempty,oneof=NOTIFY AUTO_ACTION"`
+	IsActive       *bool  `form:"is_active"`
+}
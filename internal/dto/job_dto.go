@@ -2,6 +2,16 @@ package dto
 
 import "time"
 
+type CreateJobRequest struct {
+	TicketID int     `json:"ticket_id" binding:"required"`
+	Title    string  `json:"title" binding:"required"`
+	PicJob   *string `json:"pic_job"`
+}
+
+type CompleteJobRequest struct {
+	SpendingAmount *int64 `form:"spending_amount"`
+}
+
 type AssignPICRequest struct {
 	PicJob string `json:"pic_job" binding:"required"`
 }
@@ -18,13 +28,16 @@ type ReorderJobItem struct {
 
 type JobDetailResponse struct {
 	// CORE INFORMATION
-	JobID          int    `json:"job_id"`
-	TicketID       int    `json:"ticket_id"`
-	Description    string `json:"description"`
-	JobPriority    int    `json:"job_priority"`
-	TicketPriority int    `json:"ticket_priority"`
-	SpendingAmount *int64 `json:"spending_amount"`
-	Version        int    `json:"version"`
+	JobID          int        `json:"job_id"`
+	TicketID       int        `json:"ticket_id"`
+	Description    string     `json:"description"`
+	Title          *string    `json:"title"`
+	JobStatus      string     `json:"job_status"`
+	CompletedAt    *time.Time `json:"completed_at"`
+	JobPriority    int        `json:"job_priority"`
+	TicketPriority int        `json:"ticket_priority"`
+	SpendingAmount *int64     `json:"spending_amount"`
+	Version        int        `json:"version"`
 
 	// DEPARTMENT INFORMATION
 	AssignedDepartmentID   int    `json:"assigned_department_id"`
@@ -53,6 +66,8 @@ type JobFilter struct {
 	AssignedDepartmentID int    `form:"assigned_department_id"`
 	PicNPK               string `form:"pic_npk"`
 	RequestorNPK         string `form:"requestor_npk"`
+	TicketID             int    `form:"ticket_id"`
+	JobStatus            string `form:"job_status"`
 
 	// FILTER BY SEARCH QUERY
 	SearchQuery string `form:"search"`
@@ -0,0 +1,60 @@
+package dto
+
+import "time"
+
+type UpdateNotificationPreferenceRequest struct {
+	Email        *string `json:"email" binding:"omitempty,email"`
+	Language     string  `json:"language" binding:"required,oneof=id en"`
+	EmailEnabled bool    `json:"email_enabled"`
+}
+
+// NotificationRecipient is an employee involved in a ticket, Roles tells why they are notified
+type NotificationRecipient struct {
+	NPK          string
+	Name         string
+	Email        *string
+	Language     string
+	EmailEnabled bool
+	Roles        []string
+}
+
+// TicketEventNotification carries a ticket event to the notification channels
+type TicketEventNotification struct {
+	OutboxEventID      int64 // lets each channel skip what it already stored when the event is retried
+	Event              string
+	Ticket             *TicketDetailResponse
+	ActorNPK           string
+	ActionName         string
+	Reason             string
+	NotifyActorRoleIDs []int
+	DueAt              *time.Time
+	Comment            *TicketCommentResponse
+	MentionedNPKs      []string
+}
+
+type NotificationFilter struct {
+	IsRead *bool `form:"is_read"`
+
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+type NotificationResponse struct {
+	ID        int64      `json:"id"`
+	TicketID  *int       `json:"ticket_id"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Message   string     `json:"message"`
+	ActorNPK  *string    `json:"actor_npk"`
+	ActorName *string    `json:"actor_name"`
+	IsRead    bool       `json:"is_read"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type PaginatedNotificationResponse struct {
+	Data        []NotificationResponse `json:"data"`
+	UnreadCount int                    `json:"unread_count"`
+
+	Pagination Pagination `json:"pagination"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+// TicketOutboxPayload is stored with a ticket outbox event, the ticket itself is read when the event is relayed
+// An empty ActorNPK means the worker performed the event
+type TicketOutboxPayload struct {
+	ActorNPK           string     `json:"actor_npk"`
+	ActionName         string     `json:"action_name,omitempty"`
+	Reason             string     `json:"reason,omitempty"`
+	NotifyActorRoleIDs []int      `json:"notify_actor_role_ids,omitempty"`
+	DueAt              *time.Time `json:"due_at,omitempty"`
+	CompletedJobID     int        `json:"completed_job_id,omitempty"`
+
+	// Comment events carry the comment as written, MentionedNPKs only lists the newly mentioned employees
+	Comment       *TicketCommentResponse `json:"comment,omitempty"`
+	MentionedNPKs []string               `json:"mentioned_npks,omitempty"`
+}
+
+// JobCompletedEventResponse is the JOB_COMPLETED webhook payload, the ticket with the job that was completed
+type JobCompletedEventResponse struct {
+	CompletedJobID int `json:"completed_job_id"`
+	*TicketDetailResponse
+}
@@ -0,0 +1,26 @@
+package dto
+
+type CreateSlaPolicyRequest struct {
+	StatusTicketID    int  `json:"status_ticket_id" binding:"required,gt=0"`
+	DepartmentID      *int `json:"department_id"`
+	MaxDays           int  `json:"max_days" binding:"required,gt=0"`
+	WarningPercentage int  `json:"warning_percentage" binding:"omitempty,min=1,max=99"`
+}
+
+type UpdateSlaPolicyRequest struct {
+	StatusTicketID    int  `json:"status_ticket_id" binding:"required,gt=0"`
+	DepartmentID      *int `json:"department_id"`
+	MaxDays           int  `json:"max_days" binding:"required,gt=0"`
+	WarningPercentage int  `json:"warning_percentage" binding:"required,min=1,max=99"`
+	IsActive          bool `json:"is_active"`
+}
+
+type UpdateSlaPolicyStatusRequest struct {
+	IsActive bool `json:"is_active"`
+}
+
+type SlaPolicyFilter struct {
+	StatusTicketID int   `form:"status_ticket_id"`
+	DepartmentID   int   `form:"department_id"`
+	IsActive       *bool `form:"is_active"`
+}
@@ -9,6 +9,10 @@ type UpdateStatusTicketStatusRequest struct {
 	IsActive bool `json:"is_active"`
 }
 
+type UpdateStatusTicketTerminalRequest struct {
+	IsTerminal bool `json:"is_terminal"`
+}
+
 type ReorderStatusTicketsRequest struct {
 	DeleteSectionOrder   []int `json:"delete_section_order"`
 	ApprovalSectionOrder []int `json:"approval_section_order"`
@@ -18,4 +22,4 @@ type ReorderStatusTicketsRequest struct {
 type StatusTicketFilter struct {
 	SectionID int   `form:"section_id"`
 	IsActive  *bool `form:"is_active"`
-}
\ No newline at end of file
+}
@@ -0,0 +1,93 @@
+package dto
+
+import "time"
+
+type CreateStatusTransitionRequest struct {
+	FromStatusID      *int    `json:"from_status_id"`
+	ToStatusID        int     `json:"to_status_id" binding:"required,gt=0"`
+	ActionID          int     `json:"action_id" binding:"required,gt=0"`
+	ActorRoleID       int     `json:"actor_role_id" binding:"required,gt=0"`
+	RequireReason     bool    `json:"require_reason"`
+	ReasonLabel       *string `json:"reason_label"`
+	RequireFile       bool    `json:"require_file"`
+	RequiredApprovals int     `json:"required_approvals" binding:"omitempty,gte=1"`
+}
+
+type UpdateStatusTransitionRequest struct {
+	FromStatusID      *int    `json:"from_status_id"`
+	ToStatusID        int     `json:"to_status_id" binding:"required,gt=0"`
+	ActionID          int     `json:"action_id" binding:"required,gt=0"`
+	ActorRoleID       int     `json:"actor_role_id" binding:"required,gt=0"`
+	RequireReason     bool    `json:"require_reason"`
+	ReasonLabel       *string `json:"reason_label"`
+	RequireFile       bool    `json:"require_file"`
+	IsActive          bool    `json:"is_active"`
+	RequiredApprovals int     `json:"required_approvals" binding:"omitempty,gte=1"`
+}
+
+type UpdateStatusTransitionStatusRequest struct {
+	IsActive bool `json:"is_active"`
+}
+
+type StatusTransitionFilter struct {
+	FromStatusID int   `form:"from_status_id"`
+	ToStatusID   int   `form:"to_status_id"`
+	ActionID     int   `form:"action_id"`
+	ActorRoleID  int   `form:"actor_role_id"`
+	IsActive     *bool `form:"is_active"`
+}
+
+type StatusTransitionDetailResponse struct {
+	ID                int       `json:"id"`
+	FromStatusID      *int      `json:"from_status_id"`
+	FromStatusName    *string   `json:"from_status_name"`
+	ToStatusID        int       `json:"to_status_id"`
+	ToStatusName      string    `json:"to_status_name"`
+	ActionID          int       `json:"action_id"`
+	ActionName        string    `json:"action_name"`
+	ActorRoleID       int       `json:"actor_role_id"`
+	ActorRoleName     string    `json:"actor_role_name"`
+	RequireReason     bool      `json:"require_reason"`
+	ReasonLabel       *string   `json:"reason_label"`
+	RequireFile       bool      `json:"require_file"`
+	RequiredApprovals int       `json:"required_approvals"`
+	IsActive          bool      `json:"is_active"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// WorkflowGraphTransition is one edge of a graph saved in bulk, edges are matched to
+// existing rows by from status, action and actor role
+type WorkflowGraphTransition struct {
+	FromStatusID      *int    `json:"from_status_id"`
+	ToStatusID        int     `json:"to_status_id" binding:"required,gt=0"`
+	ActionID          int     `json:"action_id" binding:"required,gt=0"`
+	ActorRoleID       int     `json:"actor_role_id" binding:"required,gt=0"`
+	RequireReason     bool    `json:"require_reason"`
+	ReasonLabel       *string `json:"reason_label"`
+	RequireFile       bool    `json:"require_file"`
+	IsActive          bool    `json:"is_active"`
+	RequiredApprovals int     `json:"required_approvals" binding:"omitempty,gte=1"`
+}
+
+type SaveWorkflowGraphRequest struct {
+	Transitions []WorkflowGraphTransition `json:"transitions" binding:"required,dive"`
+}
+
+type SaveWorkflowGraphResponse struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Deleted int `json:"deleted"`
+}
+
+type WorkflowGraphIssue struct {
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	StatusID *int   `json:"status_id,omitempty"`
+	ActionID *int   `json:"action_id,omitempty"`
+}
+
+type WorkflowGraphValidationResponse struct {
+	IsValid bool                 `json:"is_valid"`
+	Issues  []WorkflowGraphIssue `json:"issues"`
+}
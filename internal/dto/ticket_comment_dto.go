@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+// CreateTicketCommentRequest is sent as multipart form so attachments can be uploaded with the comment
+type CreateTicketCommentRequest struct {
+	Body     string `json:"body" form:"body" binding:"required"`
+	ParentID *int64 `json:"parent_id" form:"parent_id"`
+}
+
+type UpdateTicketCommentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// TicketCommentResponse holds a comment with its replies, a deleted comment keeps its place in the thread without its content
+type TicketCommentResponse struct {
+	ID            int64                   `json:"id"`
+	TicketID      int                     `json:"ticket_id"`
+	ParentID      *int64                  `json:"parent_id"`
+	AuthorNPK     string                  `json:"author_npk"`
+	AuthorName    string                  `json:"author_name"`
+	Body          string                  `json:"body"`
+	Attachments   []FileResponse          `json:"attachments"`
+	MentionedNPKs []string                `json:"mentioned_npks"`
+	IsEdited      bool                    `json:"is_edited"`
+	EditedAt      *time.Time              `json:"edited_at"`
+	IsDeleted     bool                    `json:"is_deleted"`
+	CreatedAt     time.Time               `json:"created_at"`
+	Replies       []TicketCommentResponse `json:"replies"`
+}
+
+type TicketCommentHistoryResponse struct {
+	ID            int64     `json:"id"`
+	Body          string    `json:"body"`
+	ChangeType    string    `json:"change_type"`
+	ChangedByNPK  string    `json:"changed_by_npk"`
+	ChangedByName string    `json:"changed_by_name"`
+	ChangedAt     time.Time `json:"changed_at"`
+}
@@ -35,6 +35,7 @@ type RejectTicketRequest struct {
 type ExecuteActionRequest struct {
 	ActionName     string `json:"action_name" binding:"required"`
 	Reason         string `json:"reason"`
+	ReasonCodeID   *int   `json:"reason_code_id" form:"reason_code_id"`
 	SpendingAmount *int64 `form:"spending_amount"`
 }
 
@@ -55,8 +56,12 @@ type TicketDetailResponse struct {
 	DepartmentTargetName string `json:"department_target_name"`
 
 	// JOB INFOMATION
-	JobID       *int `json:"job_id"`
-	JobPriority *int `json:"job_priority"`
+	// JobID and JobPriority belong to the first job of the ticket, the counts cover all of them
+	JobID             *int     `json:"job_id"`
+	JobPriority       *int     `json:"job_priority"`
+	JobCount          int      `json:"job_count"`
+	CompletedJobCount int      `json:"completed_job_count"`
+	JobPicNPKs        []string `json:"job_pic_npks"`
 
 	// LOCATION INFORMATION
 	LocationName          *string `json:"location_name"`
@@ -69,17 +74,70 @@ type TicketDetailResponse struct {
 	DaysRemaining *int       `json:"days_remaining"`
 
 	// PEOPLE INFORMATION
-	RequestorName       string  `json:"requestor_name"`
-	RequestorNPK        string  `json:"requestor_npk"`
-	RequestorDepartment *string `json:"requestor_department"`
-	PicName             *string `json:"pic_name"`
-	PicNPK              *string `json:"pic_npk"`
-	PicAreaName         *string `json:"pic_area_name"`
+	RequestorName       string   `json:"requestor_name"`
+	RequestorNPK        string   `json:"requestor_npk"`
+	RequestorDepartment *string  `json:"requestor_department"`
+	PicName             *string  `json:"pic_name"`
+	PicNPK              *string  `json:"pic_npk"`
+	PicAreaName         *string  `json:"pic_area_name"`
+	WatcherNPKs         []string `json:"watcher_npks"`
 
 	// STATUS IFNORMATION
 	CurrentStatus        *string `json:"current_status"`
 	CurrentStatusHexCode *string `json:"current_status_hex_code"`
 	CurrentSectionName   *string `json:"current_section_name"`
+
+	// SLA INFORMATION
+	SlaDueAt      *time.Time `json:"sla_due_at"`
+	IsSlaBreached bool       `json:"is_sla_breached"`
+
+	// APPROVAL INFORMATION
+	Approvals []TicketApprovalProgressResponse `json:"approvals,omitempty"`
+
+	// LINK INFORMATION
+	Links []TicketLinkResponse `json:"links,omitempty"`
+}
+
+// TicketApprovalProgressResponse lists the approvals an action needing a quorum has collected in the current status
+type TicketApprovalProgressResponse struct {
+	ActionName         string                   `json:"action_name"`
+	RequiredApprovals  int                      `json:"required_approvals"`
+	CollectedApprovals int                      `json:"collected_approvals"`
+	Approvals          []TicketApprovalResponse `json:"approvals"`
+}
+
+type TicketApprovalResponse struct {
+	ActorRoleName  string    `json:"actor_role_name"`
+	ApprovedByNPK  string    `json:"approved_by_npk"`
+	ApprovedByName *string   `json:"approved_by_name"`
+	OnBehalfOfNPK  *string   `json:"on_behalf_of_npk"`
+	OnBehalfOfName *string   `json:"on_behalf_of_name"`
+	ApprovedAt     time.Time `json:"approved_at"`
+}
+
+// PublicTicketDetailResponse is the redacted ticket sent to public websocket clients, it has no people information
+type PublicTicketDetailResponse struct {
+	TicketID             int        `json:"ticket_id"`
+	Description          string     `json:"description"`
+	TicketPriority       int        `json:"ticket_priority"`
+	Version              int        `json:"version"`
+	DepartmentTargetID   int        `json:"department_target_id"`
+	DepartmentTargetName string     `json:"department_target_name"`
+	JobID                *int       `json:"job_id"`
+	JobPriority          *int       `json:"job_priority"`
+	JobCount             int        `json:"job_count"`
+	CompletedJobCount    int        `json:"completed_job_count"`
+	LocationName         *string    `json:"location_name"`
+	CreatedAt            time.Time  `json:"created_at"`
+	TicketAgeDays        *int       `json:"ticket_age_days"`
+	Deadline             *time.Time `json:"deadline"`
+	DaysRemaining        *int       `json:"days_remaining"`
+	RequestorDepartment  *string    `json:"requestor_department"`
+	CurrentStatus        *string    `json:"current_status"`
+	CurrentStatusHexCode *string    `json:"current_status_hex_code"`
+	CurrentSectionName   *string    `json:"current_section_name"`
+	SlaDueAt             *time.Time `json:"sla_due_at"`
+	IsSlaBreached        bool       `json:"is_sla_breached"`
 }
 
 type TicketFilter struct {
@@ -90,6 +148,7 @@ type TicketFilter struct {
 	RequestorDepartmentID []int    `form:"requestor_department_id"`
 	Requestor             []string `form:"requestor"`
 	PicNPK                []string `form:"pic_npk"`
+	WatcherNPK            []string `form:"watcher_npk"`
 
 	// FILTER BY SEARCH QUERY
 	SearchQuery string `form:"search"`
@@ -128,6 +187,32 @@ type OldestTicketResponse struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type TicketTimelineEntryResponse struct {
+	ActionName          *string    `json:"action_name"`
+	ActionHexCode       *string    `json:"action_hex_code"`
+	PerformedByNPK      *string    `json:"performed_by_npk"`
+	PerformedByName     *string    `json:"performed_by_name"`
+	PerformedByPosition *string    `json:"performed_by_position"`
+	OnBehalfOfNPK       *string    `json:"on_behalf_of_npk"`
+	OnBehalfOfName      *string    `json:"on_behalf_of_name"`
+	SystemActor         *string    `json:"system_actor"`
+	ReasonCode          *string    `json:"reason_code"`
+	ReasonCodeName      *string    `json:"reason_code_name"`
+	FromStatusID        *int       `json:"from_status_id"`
+	FromStatusName      *string    `json:"from_status_name"`
+	FromStatusHexColor  *string    `json:"from_status_hex_color"`
+	ToStatusID          int        `json:"to_status_id"`
+	ToStatusName        string     `json:"to_status_name"`
+	ToStatusHexColor    *string    `json:"to_status_hex_color"`
+	Reason              *string    `json:"reason"`
+	FilePaths           []string   `json:"file_paths"`
+	StartedAt           time.Time  `json:"started_at"`
+	FinishedAt          *time.Time `json:"finished_at"`
+	TimeSpentSeconds    int64      `json:"time_spent_seconds"`
+	IsCurrent           bool       `json:"is_current"`
+	IsPartialApproval   bool       `json:"is_partial_approval"`
+}
+
 type RejectionDetailResponse struct {
 	Reason             string    `json:"reason"`
 	RejectorNPK        string    `json:"rejector_npk"`
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// CreateTicketLinkRequest links the ticket of the URL to another one. BLOCKED_BY and CHILD_OF are
+// stored as BLOCKS and PARENT_OF from the other ticket.
+type CreateTicketLinkRequest struct {
+	LinkedTicketID int    `json:"linked_ticket_id" binding:"required"`
+	LinkType       string `json:"link_type" binding:"required,oneof=DUPLICATE_OF BLOCKS BLOCKED_BY PARENT_OF CHILD_OF RELATED"`
+	Reason         string `json:"reason"`
+}
+
+// TicketLinkResponse describes the link as seen from the ticket it is listed for
+type TicketLinkResponse struct {
+	ID                     int       `json:"id"`
+	LinkType               string    `json:"link_type"`
+	LinkedTicketID         int       `json:"linked_ticket_id"`
+	LinkedTicketDesc       string    `json:"linked_ticket_description"`
+	LinkedTicketStatus     *string   `json:"linked_ticket_status"`
+	IsLinkedTicketFinished bool      `json:"is_linked_ticket_finished"`
+	CreatedByNPK           string    `json:"created_by_npk"`
+	CreatedAt              time.Time `json:"created_at"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// AddTicketWatcherRequest follows the ticket as the user when EmployeeNPK is empty
+type AddTicketWatcherRequest struct {
+	EmployeeNPK string `json:"employee_npk"`
+}
+
+type TicketWatcherResponse struct {
+	EmployeeNPK  string    `json:"employee_npk"`
+	EmployeeName string    `json:"employee_name"`
+	AddedByNPK   string    `json:"added_by_npk"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,34 @@
+package dto
+
+type CreateTransitionPrerequisiteRequest struct {
+	TransitionID     int `json:"transition_id" binding:"required,gt=0"`
+	RequiredStatusID int `json:"required_status_id" binding:"required,gt=0"`
+}
+
+type UpdateTransitionPrerequisiteRequest struct {
+	RequiredStatusID int  `json:"required_status_id" binding:"required,gt=0"`
+	IsActive         bool `json:"is_active"`
+}
+
+type UpdateTransitionPrerequisiteStatusRequest struct {
+	IsActive bool `json:"is_active"`
+}
+
+type TransitionPrerequisiteFilter struct {
+	FromStatusID     int   `form:"from_status_id"`
+	RequiredStatusID int   `form:"required_status_id"`
+	IsActive         *bool `form:"is_active"`
+}
+
+type TransitionPrerequisiteDetailResponse struct {
+	TransitionID       int     `json:"transition_id"`
+	FromStatusID       *int    `json:"from_status_id"`
+	FromStatusName     *string `json:"from_status_name"`
+	ToStatusID         int     `json:"to_status_id"`
+	ToStatusName       string  `json:"to_status_name"`
+	ActionName         string  `json:"action_name"`
+	ActorRoleName      string  `json:"actor_role_name"`
+	RequiredStatusID   int     `json:"required_status_id"`
+	RequiredStatusName string  `json:"required_status_name"`
+	IsActive           bool    `json:"is_active"`
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type CreateWebhookEndpointRequest struct {
+	Name   string   `json:"name" binding:"required"`
+	URL    string   `json:"url" binding:"required,url"`
+	Secret string   `json:"secret" binding:"required,min=16"`
+	Events []string `json:"events" binding:"required,min=1"`
+}
+
+type UpdateWebhookEndpointRequest struct {
+	Name     string   `json:"name" binding:"required"`
+	URL      string   `json:"url" binding:"required,url"`
+	Secret   string   `json:"secret" binding:"omitempty,min=16"` // empty keeps the current secret
+	Events   []string `json:"events" binding:"required,min=1"`
+	IsActive bool     `json:"is_active"`
+}
+
+type UpdateWebhookEndpointStatusRequest struct {
+	IsActive bool `json:"is_active"`
+}
+
+type WebhookDeliveryFilter struct {
+	EndpointID int    `form:"endpoint_id"`
+	Event      string `form:"event"`
+	Status     string `form:"status"`
+
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+type PaginatedWebhookDeliveryResponse struct {
+	Data []WebhookDeliveryResponse `json:"data"`
+
+	Pagination Pagination `json:"pagination"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             int64           `json:"id"`
+	EndpointID     int             `json:"endpoint_id"`
+	EndpointName   string          `json:"endpoint_name"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastStatusCode *int            `json:"last_status_code"`
+	LastError      *string         `json:"last_error"`
+	RedeliveryOf   *int64          `json:"redelivery_of"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
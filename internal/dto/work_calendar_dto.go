@@ -0,0 +1,30 @@
+package dto
+
+type WorkDayItem struct {
+	DayOfWeek *int `json:"day_of_week" binding:"required,min=0,max=6"`
+	IsWorkDay bool `json:"is_work_day"`
+}
+
+type UpdateWorkDaysRequest struct {
+	WorkDays []WorkDayItem `json:"work_days" binding:"required,min=1,dive"`
+}
+
+type CreateHolidayRequest struct {
+	HolidayDate string `json:"holiday_date" binding:"required"` // "YYYY-MM-DD"
+	Name        string `json:"name" binding:"required"`
+}
+
+type UpdateHolidayRequest struct {
+	HolidayDate string `json:"holiday_date" binding:"required"` // "YYYY-MM-DD"
+	Name        string `json:"name" binding:"required"`
+	IsActive    bool   `json:"is_active"`
+}
+
+type UpdateHolidayStatusRequest struct {
+	IsActive bool `json:"is_active"`
+}
+
+type HolidayFilter struct {
+	Year     int   `form:"year"`
+	IsActive *bool `form:"is_active"`
+}
@@ -17,4 +17,36 @@ type UpdateWorkflowStatusRequest struct {
 
 type UpdateWorkflowRequest struct {
 	Name string `json:"name" binding:"required"`
-}
\ No newline at end of file
+}
+
+// WorkflowGraphExport describes a workflow by names only so it can be imported into another environment.
+// Statuses with a step_sequence are the workflow steps, the others are only reached by a transition.
+type WorkflowGraphExport struct {
+	Workflow    string                    `json:"workflow"`
+	Statuses    []WorkflowGraphStatus     `json:"statuses" binding:"required,min=1,dive"`
+	Transitions []WorkflowGraphEdgeByName `json:"transitions" binding:"dive"`
+}
+
+type WorkflowGraphStatus struct {
+	Name         string `json:"name" binding:"required"`
+	StepSequence *int   `json:"step_sequence"`
+	HexColor     string `json:"hex_color"`
+	IsTerminal   bool   `json:"is_terminal"`
+}
+
+type WorkflowGraphEdgeByName struct {
+	FromStatus        *string `json:"from_status"`
+	ToStatus          string  `json:"to_status" binding:"required"`
+	Action            string  `json:"action" binding:"required"`
+	ActorRole         string  `json:"actor_role" binding:"required"`
+	RequireReason     bool    `json:"require_reason"`
+	ReasonLabel       *string `json:"reason_label"`
+	RequireFile       bool    `json:"require_file"`
+	IsActive          bool    `json:"is_active"`
+	RequiredApprovals int     `json:"required_approvals" binding:"omitempty,gte=1"`
+}
+
+type ImportWorkflowGraphResponse struct {
+	Steps       int                       `json:"steps"`
+	Transitions SaveWorkflowGraphResponse `json:"transitions"`
+}
@@ -0,0 +1,42 @@
+package dto
+
+type SimulateWorkflowRequest struct {
+	TicketID        int                      `json:"ticket_id" binding:"required,gt=0"`
+	NPK             string                   `json:"npk" binding:"required"`
+	PositionID      *int                     `json:"position_id"`
+	IncludeInactive bool                     `json:"include_inactive"`
+	Actions         []SimulatedActionRequest `json:"actions" binding:"required,min=1,dive"`
+}
+
+type SimulatedActionRequest struct {
+	ActionName string `json:"action_name" binding:"required"`
+	Reason     string `json:"reason"`
+	HasFile    bool   `json:"has_file"`
+}
+
+type SimulatedStepResponse struct {
+	Sequence          int     `json:"sequence"`
+	ActionName        string  `json:"action_name"`
+	FromStatusID      int     `json:"from_status_id"`
+	FromStatusName    string  `json:"from_status_name"`
+	ToStatusID        *int    `json:"to_status_id"`
+	ToStatusName      *string `json:"to_status_name"`
+	RequiredApprovals int     `json:"required_approvals,omitempty"`
+	IsBlocked         bool    `json:"is_blocked"`
+	BlockedReason     *string `json:"blocked_reason,omitempty"`
+}
+
+type SimulateWorkflowResponse struct {
+	TicketID         int                             `json:"ticket_id"`
+	NPK              string                          `json:"npk"`
+	PositionID       int                             `json:"position_id"`
+	UserContexts     []string                        `json:"user_contexts"`
+	ActorRoleIDs     []int                           `json:"actor_role_ids"`
+	StartStatusID    int                             `json:"start_status_id"`
+	StartStatusName  string                          `json:"start_status_name"`
+	FinalStatusID    int                             `json:"final_status_id"`
+	FinalStatusName  string                          `json:"final_status_name"`
+	IsCompleted      bool                            `json:"is_completed"`
+	Steps            []SimulatedStepResponse         `json:"steps"`
+	AvailableActions []AvailableTicketActionResponse `json:"available_actions"`
+}
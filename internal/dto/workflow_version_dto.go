@@ -0,0 +1,67 @@
+package dto
+
+import "time"
+
+type PublishWorkflowVersionRequest struct {
+	Note *string `json:"note"`
+}
+
+type WorkflowVersionStepResponse struct {
+	StatusTicketID int    `json:"status_ticket_id"`
+	StatusName     string `json:"status_name"`
+	StepSequence   int    `json:"step_sequence"`
+}
+
+type WorkflowVersionTransitionResponse struct {
+	FromStatusID      *int    `json:"from_status_id"`
+	FromStatusName    *string `json:"from_status_name"`
+	ToStatusID        int     `json:"to_status_id"`
+	ToStatusName      string  `json:"to_status_name"`
+	ActionID          int     `json:"action_id"`
+	ActionName        string  `json:"action_name"`
+	ActorRoleID       int     `json:"actor_role_id"`
+	ActorRoleName     string  `json:"actor_role_name"`
+	RequireReason     bool    `json:"require_reason"`
+	ReasonLabel       *string `json:"reason_label"`
+	RequireFile       bool    `json:"require_file"`
+	RequiredApprovals int     `json:"required_approvals"`
+	RequiredStatusIDs []int   `json:"required_status_ids"`
+}
+
+type WorkflowVersionDetailResponse struct {
+	ID             int                                 `json:"id"`
+	WorkflowID     int                                 `json:"workflow_id"`
+	VersionNumber  int                                 `json:"version_number"`
+	Note           *string                             `json:"note"`
+	PublishedByNPK *string                             `json:"published_by_npk"`
+	PublishedAt    time.Time                           `json:"published_at"`
+	Steps          []WorkflowVersionStepResponse       `json:"steps"`
+	Transitions    []WorkflowVersionTransitionResponse `json:"transitions"`
+}
+
+type WorkflowStatusMapping struct {
+	FromStatusID int `json:"from_status_id" binding:"required,gt=0"`
+	ToStatusID   int `json:"to_status_id" binding:"required,gt=0"`
+}
+
+// MigrateWorkflowTicketsRequest moves the open tickets of one version, or the unpinned tickets when
+// from_version_id is empty, to another version of the same workflow
+type MigrateWorkflowTicketsRequest struct {
+	FromVersionID  *int                    `json:"from_version_id"`
+	ToVersionID    int                     `json:"to_version_id" binding:"required,gt=0"`
+	StatusMappings []WorkflowStatusMapping `json:"status_mappings" binding:"dive"`
+	DryRun         bool                    `json:"dry_run"`
+}
+
+type MigratedTicketResponse struct {
+	TicketID     int     `json:"ticket_id"`
+	FromStatusID int     `json:"from_status_id"`
+	ToStatusID   *int    `json:"to_status_id,omitempty"`
+	Reason       *string `json:"reason,omitempty"`
+}
+
+type MigrateWorkflowTicketsResponse struct {
+	DryRun   bool                     `json:"dry_run"`
+	Migrated []MigratedTicketResponse `json:"migrated"`
+	Skipped  []MigratedTicketResponse `json:"skipped"`
+}
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"database/sql"
 	"net/http"
+	"strconv"
 
 	"e-memo-job-reservation-api/internal/dto"
 	"e-memo-job-reservation-api/internal/service"
@@ -18,6 +20,27 @@ func NewActionHandler(service *service.ActionService) *ActionHandler {
 	return &ActionHandler{service: service}
 }
 
+// POST /action
+func (h *ActionHandler) CreateAction(c *gin.Context) {
+	var req dto.CreateActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	newAction, err := h.service.CreateAction(req)
+	if err != nil {
+		if err.Error() == "action name already exists" {
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to create action", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusCreated, newAction)
+}
+
 // GET /actions
 func (h *ActionHandler) GetAllActions(c *gin.Context) {
 	actions, err := h.service.GetAllActions()
@@ -33,3 +56,105 @@ func (h *ActionHandler) GetAllActions(c *gin.Context) {
 
 	util.SuccessResponse(c, http.StatusOK, actions)
 }
+
+// GET /action/:id
+func (h *ActionHandler) GetActionByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid action ID format", nil)
+		return
+	}
+
+	action, err := h.service.GetActionByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Action not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve action", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, action)
+}
+
+// PUT /action/:id
+func (h *ActionHandler) UpdateAction(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid action ID format", nil)
+		return
+	}
+
+	var req dto.UpdateActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	updated, err := h.service.UpdateAction(id, req)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Action not found", nil)
+			return
+		}
+		if err.Error() == "action name already exists" {
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update action", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, updated)
+}
+
+// PATCH /action/:id/status
+func (h *ActionHandler) UpdateActionActiveStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid action ID format", nil)
+		return
+	}
+
+	var req dto.UpdateActionStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	if err := h.service.UpdateActionActiveStatus(id, req); err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Action not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update action status", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, gin.H{"message": "Action status updated successfully"})
+}
+
+// DELETE /action/:id
+func (h *ActionHandler) DeleteAction(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid action ID format", nil)
+		return
+	}
+
+	if err := h.service.DeleteAction(id); err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Action not found or already deleted", nil)
+			return
+		}
+		if err.Error() == "action is still used by status transitions or action logs" {
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete action", nil)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
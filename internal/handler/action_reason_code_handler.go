@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ActionReasonCodeHandler struct {
+	service *service.ActionReasonCodeService
+}
+
+func NewActionReasonCodeHandler(service *service.ActionReasonCodeService) *ActionReasonCodeHandler {
+	return &ActionReasonCodeHandler{service: service}
+}
+
+// POST /action-reason-code
+func (h *ActionReasonCodeHandler) CreateActionReasonCode(c *gin.Context) {
+	var req dto.CreateActionReasonCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	newReasonCode, err := h.service.CreateActionReasonCode(req)
+	if err != nil {
+		switch err.Error() {
+		case "reason code already exists for this action":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		case "invalid action_id":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to create action reason code", nil)
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusCreated, newReasonCode)
+}
+
+// GET /action-reason-code
+func (h *ActionReasonCodeHandler) GetAllActionReasonCodes(c *gin.Context) {
+	var filters dto.ActionReasonCodeFilter
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	reasonCodes, err := h.service.GetAllActionReasonCodes(filters)
+	if err != nil {
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve action reason codes", err.Error())
+		return
+	}
+
+	if reasonCodes == nil {
+		util.SuccessResponse(c, http.StatusOK, []model.ActionReasonCode{})
+		return
+	}
+	util.SuccessResponse(c, http.StatusOK, reasonCodes)
+}
+
+// GET /action-reason-code/:id
+func (h *ActionReasonCodeHandler) GetActionReasonCodeByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid action reason code ID format", nil)
+		return
+	}
+
+	reasonCode, err := h.service.GetActionReasonCodeByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Action reason code not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve action reason code", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, reasonCode)
+}
+
+// PUT /action-reason-code/:id
+func (h *ActionReasonCodeHandler) UpdateActionReasonCode(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid action reason code ID format", nil)
+		return
+	}
+
+	var req dto.UpdateActionReasonCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	updated, err := h.service.UpdateActionReasonCode(id, req)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Action reason code not found", nil)
+			return
+		}
+		switch err.Error() {
+		case "reason code already exists for this action":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		case "invalid action_id":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update action reason code", nil)
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, updated)
+}
+
+// PATCH /action-reason-code/:id/status
+func (h *ActionReasonCodeHandler) UpdateActionReasonCodeActiveStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid action reason code ID format", nil)
+		return
+	}
+
+	var req dto.UpdateActionReasonCodeStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	err = h.service.UpdateActionReasonCodeActiveStatus(id, req)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Action reason code not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update action reason code status", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, gin.H{"message": "Action reason code status updated successfully"})
+}
+
+// DELETE /action-reason-code/:id
+func (h *ActionReasonCodeHandler) DeleteActionReasonCode(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid action reason code ID format", nil)
+		return
+	}
+
+	err = h.service.DeleteActionReasonCode(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Action reason code not found or already deleted", nil)
+			return
+		}
+		if err.Error() == "reason code is used by ticket actions, deactivate it instead" {
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete action reason code", nil)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ActorRoleHandler struct {
+	service *service.ActorRoleService
+}
+
+func NewActorRoleHandler(service *service.ActorRoleService) *ActorRoleHandler {
+	return &ActorRoleHandler{service: service}
+}
+
+// POST /actor-role
+func (h *ActorRoleHandler) CreateActorRole(c *gin.Context) {
+	var req dto.CreateActorRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	newRole, err := h.service.CreateActorRole(req)
+	if err != nil {
+		if err.Error() == "actor role name already exists" {
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to create actor role", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusCreated, newRole)
+}
+
+// GET /actor-role
+func (h *ActorRoleHandler) GetAllActorRoles(c *gin.Context) {
+	roles, err := h.service.GetAllActorRoles()
+	if err != nil {
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve actor roles", err.Error())
+		return
+	}
+
+	if roles == nil {
+		util.SuccessResponse(c, http.StatusOK, []model.ActorRole{})
+		return
+	}
+	util.SuccessResponse(c, http.StatusOK, roles)
+}
+
+// GET /actor-role/:id
+func (h *ActorRoleHandler) GetActorRoleByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid actor role ID format", nil)
+		return
+	}
+
+	role, err := h.service.GetActorRoleByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Actor role not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve actor role", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, role)
+}
+
+// PUT /actor-role/:id
+func (h *ActorRoleHandler) UpdateActorRole(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid actor role ID format", nil)
+		return
+	}
+
+	var req dto.UpdateActorRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	updated, err := h.service.UpdateActorRole(id, req)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Actor role not found", nil)
+			return
+		}
+		if err.Error() == "actor role name already exists" {
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update actor role", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, updated)
+}
+
+// PATCH /actor-role/:id/status
+func (h *ActorRoleHandler) UpdateActorRoleActiveStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid actor role ID format", nil)
+		return
+	}
+
+	var req dto.UpdateActorRoleStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	if err := h.service.UpdateActorRoleActiveStatus(id, req); err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Actor role not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update actor role status", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, gin.H{"message": "Actor role status updated successfully"})
+}
+
+// DELETE /actor-role/:id
+func (h *ActorRoleHandler) DeleteActorRole(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid actor role ID format", nil)
+		return
+	}
+
+	if err := h.service.DeleteActorRole(id); err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Actor role not found or already deleted", nil)
+			return
+		}
+		if err.Error() == "actor role is still used by status transitions or mappings" {
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete actor role", nil)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ActorRoleMappingHandler struct {
+	service *service.ActorRoleMappingService
+}
+
+func NewActorRoleMappingHandler(service *service.ActorRoleMappingService) *ActorRoleMappingHandler {
+	return &ActorRoleMappingHandler{service: service}
+}
+
+// POST /actor-role-mapping
+func (h *ActorRoleMappingHandler) CreateActorRoleMapping(c *gin.Context) {
+	var req dto.ActorRoleMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	newMapping, err := h.service.CreateActorRoleMapping(req)
+	if err != nil {
+		switch err.Error() {
+		case "position already has an actor role for this context":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		case "invalid employee_position_id or actor_role_id":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to create actor role mapping", nil)
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusCreated, newMapping)
+}
+
+// GET /actor-role-mapping
+func (h *ActorRoleMappingHandler) GetAllActorRoleMappings(c *gin.Context) {
+	var filters dto.ActorRoleMappingFilter
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	mappings, err := h.service.GetAllActorRoleMappings(filters)
+	if err != nil {
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve actor role mappings", err.Error())
+		return
+	}
+
+	if mappings == nil {
+		util.SuccessResponse(c, http.StatusOK, []dto.ActorRoleMappingDetailResponse{})
+		return
+	}
+	util.SuccessResponse(c, http.StatusOK, mappings)
+}
+
+// GET /actor-role-mapping/:id
+func (h *ActorRoleMappingHandler) GetActorRoleMappingByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid actor role mapping ID format", nil)
+		return
+	}
+
+	mapping, err := h.service.GetActorRoleMappingByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Actor role mapping not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve actor role mapping", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, mapping)
+}
+
+// PUT /actor-role-mapping/:id
+func (h *ActorRoleMappingHandler) UpdateActorRoleMapping(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid actor role mapping ID format", nil)
+		return
+	}
+
+	var req dto.ActorRoleMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	updated, err := h.service.UpdateActorRoleMapping(id, req)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Actor role mapping not found", nil)
+			return
+		}
+		switch err.Error() {
+		case "position already has an actor role for this context":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		case "invalid employee_position_id or actor_role_id":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update actor role mapping", nil)
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, updated)
+}
+
+// DELETE /actor-role-mapping/:id
+func (h *ActorRoleMappingHandler) DeleteActorRoleMapping(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid actor role mapping ID format", nil)
+		return
+	}
+
+	if err := h.service.DeleteActorRoleMapping(id); err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Actor role mapping not found or already deleted", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete actor role mapping", nil)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EditingLockHandler struct {
+	service *service.EditingLockService
+}
+
+func NewEditingLockHandler(service *service.EditingLockService) *EditingLockHandler {
+	return &EditingLockHandler{service: service}
+}
+
+// HELPER
+// respondEditingLocked answers 423 Locked with the lock holder when err is an editing lock conflict
+func respondEditingLocked(c *gin.Context, err error) bool {
+	var lockedErr *service.EditingLockedError
+	if !errors.As(err, &lockedErr) {
+		return false
+	}
+	util.ErrorResponse(c, http.StatusLocked, err.Error(), gin.H{
+		"holder_name": lockedErr.Lock.HolderName,
+		"holder_npk":  lockedErr.Lock.HolderNPK,
+		"expires_at":  lockedErr.Lock.ExpiresAt,
+	})
+	return true
+}
+
+// POST /editing-lock
+func (h *EditingLockHandler) AcquireLock(c *gin.Context) {
+	var req dto.EditingLockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	lock, err := h.service.AcquireLock(c.Request.Context(), req, c.GetInt("user_id"), c.GetString("user_npk"))
+	if err != nil {
+		if respondEditingLocked(c, err) {
+			return
+		}
+		switch err.Error() {
+		case "editing lock is held by another user, please retry":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to acquire editing lock", err.Error())
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, lock)
+}
+
+// PUT /editing-lock/heartbeat
+func (h *EditingLockHandler) HeartbeatLock(c *gin.Context) {
+	var req dto.EditingLockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	lock, err := h.service.HeartbeatLock(c.Request.Context(), req, c.GetInt("user_id"))
+	if err != nil {
+		switch err.Error() {
+		case "editing lock not found or expired":
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to extend editing lock", err.Error())
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, lock)
+}
+
+// DELETE /editing-lock
+func (h *EditingLockHandler) ReleaseLock(c *gin.Context) {
+	var req dto.EditingLockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	err := h.service.ReleaseLock(c.Request.Context(), req, c.GetInt("user_id"))
+	if err != nil {
+		switch err.Error() {
+		case "editing lock not found or expired":
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to release editing lock", err.Error())
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GET /editing-lock/active
+func (h *EditingLockHandler) GetActiveLocks(c *gin.Context) {
+	var filters dto.EditingLockFilter
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	locks, err := h.service.GetActiveLocks(c.Request.Context(), filters)
+	if err != nil {
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve editing locks", err.Error())
+		return
+	}
+
+	if locks == nil {
+		util.SuccessResponse(c, http.StatusOK, []dto.EditingLockResponse{})
+		return
+	}
+	util.SuccessResponse(c, http.StatusOK, locks)
+}
+
+// DELETE /editing-lock/:entity/:contextId
+func (h *EditingLockHandler) ForceReleaseLock(c *gin.Context) {
+	contextID, err := strconv.Atoi(c.Param("contextId"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid context ID format", nil)
+		return
+	}
+
+	err = h.service.ForceReleaseLock(c.Request.Context(), c.Param("entity"), contextID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Editing lock not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to release editing lock", err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
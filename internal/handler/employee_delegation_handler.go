@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EmployeeDelegationHandler struct {
+	service *service.EmployeeDelegationService
+}
+
+func NewEmployeeDelegationHandler(service *service.EmployeeDelegationService) *EmployeeDelegationHandler {
+	return &EmployeeDelegationHandler{service: service}
+}
+
+// POST /delegation
+func (h *EmployeeDelegationHandler) CreateDelegation(c *gin.Context) {
+	var req dto.CreateEmployeeDelegationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	delegation, err := h.service.CreateDelegation(c.Request.Context(), c.GetString("user_npk"), req)
+	if err != nil {
+		switch err.Error() {
+		case "employee not found", "delegate employee not found":
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		case "cannot delegate to yourself", "invalid date format, expected YYYY-MM-DD", "end date must not be before start date",
+			"end date must not be in the past", "delegate employee is not active":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		case "delegation overlaps an existing delegation to the same employee":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to create delegation", err.Error())
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusCreated, delegation)
+}
+
+// GET /delegation?direction=given|received
+func (h *EmployeeDelegationHandler) GetDelegations(c *gin.Context) {
+	var filters dto.EmployeeDelegationFilter
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	delegations, err := h.service.GetDelegations(c.Request.Context(), c.GetString("user_npk"), filters)
+	if err != nil {
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve delegations", err.Error())
+		return
+	}
+	if delegations == nil {
+		delegations = []dto.EmployeeDelegationResponse{}
+	}
+
+	util.SuccessResponse(c, http.StatusOK, delegations)
+}
+
+// DELETE /delegation/:id
+func (h *EmployeeDelegationHandler) RevokeDelegation(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid delegation ID format", nil)
+		return
+	}
+
+	err = h.service.RevokeDelegation(c.Request.Context(), id, c.GetString("user_npk"))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Delegation not found", nil)
+			return
+		}
+		if err.Error() == "delegation is already revoked" {
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to revoke delegation", err.Error())
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, gin.H{"message": "Delegation revoked successfully"})
+}
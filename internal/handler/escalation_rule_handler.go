@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EscalationRuleHandler struct {
+	service *service.EscalationRuleService
+}
+
+func NewEscalationRuleHandler(service *service.EscalationRuleService) *EscalationRuleHandler {
+	return &EscalationRuleHandler{service: service}
+}
+
+// POST /escalation-rule
+func (h *EscalationRuleHandler) CreateEscalationRule(c *gin.Context) {
+	var req dto.CreateEscalationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	newRule, err := h.service.CreateEscalationRule(req)
+	if err != nil {
+		switch err.Error() {
+		case "notify_actor_role_id is required for NOTIFY rules",
+			"action_id, reminder_hours and on_behalf_of_requestor are only used by AUTO_ACTION rules",
+			"action_id is required for AUTO_ACTION rules",
+			"notify_actor_role_id is only used by NOTIFY rules",
+			"reminder_hours must be less than after_hours",
+			"action cannot be performed automatically",
+			"action is not allowed from the status of the rule",
+			"invalid status_ticket_id, department_id, notify_actor_role_id or action_id":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to create escalation rule", nil)
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusCreated, newRule)
+}
+
+// GET /escalation-rule
+func (h *EscalationRuleHandler) GetAllEscalationRules(c *gin.Context) {
+	var filters dto.EscalationRuleFilter
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	rules, err := h.service.GetAllEscalationRules(filters)
+	if err != nil {
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve escalation rules", err.Error())
+		return
+	}
+
+	if rules == nil {
+		util.SuccessResponse(c, http.StatusOK, []model.EscalationRule{})
+		return
+	}
+	util.SuccessResponse(c, http.StatusOK, rules)
+}
+
+// GET /escalation-rule/:id
+func (h *EscalationRuleHandler) GetEscalationRuleByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid escalation rule ID format", nil)
+		return
+	}
+
+	rule, err := h.service.GetEscalationRuleByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Escalation rule not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve escalation rule", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, rule)
+}
+
+// PUT /escalation-rule/:id
+func (h *EscalationRuleHandler) UpdateEscalationRule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid escalation rule ID format", nil)
+		return
+	}
+
+	var req dto.UpdateEscalationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	updated, err := h.service.UpdateEscalationRule(id, req)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Escalation rule not found", nil)
+			return
+		}
+		switch err.Error() {
+		case "notify_actor_role_id is required for NOTIFY rules",
+			"action_id, reminder_hours and on_behalf_of_requestor are only used by AUTO_ACTION rules",
+			"action_id is required for AUTO_ACTION rules",
+			"notify_actor_role_id is only used by NOTIFY rules",
+			"reminder_hours must be less than after_hours",
+			"action cannot be performed automatically",
+			"action is not allowed from the status of the rule",
+			"invalid status_ticket_id, department_id, notify_actor_role_id or action_id":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update escalation rule", nil)
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, updated)
+}
+
+// PATCH /escalation-rule/:id/status
+func (h *EscalationRuleHandler) UpdateEscalationRuleActiveStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid escalation rule ID format", nil)
+		return
+	}
+
+	var req dto.UpdateEscalationRuleStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	err = h.service.UpdateEscalationRuleActiveStatus(id, req)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Escalation rule not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update escalation rule status", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, gin.H{"message": "Escalation rule status updated successfully"})
+}
+
+// DELETE /escalation-rule/:id
+func (h *EscalationRuleHandler) DeleteEscalationRule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid escalation rule ID format", nil)
+		return
+	}
+
+	err = h.service.DeleteEscalationRule(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Escalation rule not found or already deleted", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete escalation rule", nil)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -3,11 +3,14 @@ package handler
 import (
 	"database/sql"
 	"net/http"
+	"os"
 	"strconv"
 
 	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
 	"e-memo-job-reservation-api/internal/service"
 	"e-memo-job-reservation-api/internal/util"
+	"e-memo-job-reservation-api/pkg/filehandler"
 
 	"github.com/gin-gonic/gin"
 )
@@ -64,6 +67,114 @@ func (h *JobHandler) GetJobByID(c *gin.Context) {
 	util.SuccessResponse(c, http.StatusOK, job)
 }
 
+// POST /jobs
+func (h *JobHandler) CreateJob(c *gin.Context) {
+	userNPK := c.GetString("user_npk")
+
+	var req dto.CreateJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	job, err := h.commandService.CreateJob(c.Request.Context(), req, userNPK)
+	if err != nil {
+		switch err.Error() {
+		case "ticket not found", "action performer not found", "new PIC employee data not found":
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		case "user is not authorized to manage jobs of this ticket's department":
+			util.ErrorResponse(c, http.StatusForbidden, err.Error(), nil)
+		case "new PIC must be from the same department as the job":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		case "jobs cannot be added to a finished ticket":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to create job", err.Error())
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusCreated, job)
+}
+
+// POST /jobs/:id/complete
+func (h *JobHandler) CompleteJob(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid job ID format", nil)
+		return
+	}
+	userNPK := c.GetString("user_npk")
+
+	var req dto.CompleteJobRequest
+	if err := c.ShouldBind(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	var filesMetadata []model.FileMetadata
+	form, err := c.MultipartForm()
+	if err == nil {
+		files := form.File["Files"]
+		if len(files) > 0 {
+			savedMetadata, saveErr := filehandler.SaveFiles(c, files)
+			if saveErr != nil {
+				util.ErrorResponse(c, http.StatusInternalServerError, "Failed to save uploaded files", nil)
+				return
+			}
+			filesMetadata = savedMetadata
+		}
+	}
+
+	err = h.commandService.CompleteJob(c.Request.Context(), id, req, filesMetadata, userNPK)
+	if err != nil {
+		for _, metadata := range filesMetadata {
+			os.Remove(metadata.FilePath)
+		}
+		switch err.Error() {
+		case "job not found":
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		case "only the PIC of the job can complete it":
+			util.ErrorResponse(c, http.StatusForbidden, err.Error(), nil)
+		case "job is already completed", "job has no PIC assigned yet", "job can only be completed while the ticket is being worked on",
+			"file upload is required for this action":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to complete job", err.Error())
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, gin.H{"message": "Job completed successfully"})
+}
+
+// DELETE /jobs/:id
+func (h *JobHandler) DeleteJob(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid job ID format", nil)
+		return
+	}
+	userNPK := c.GetString("user_npk")
+
+	err = h.commandService.DeleteJob(c.Request.Context(), id, userNPK)
+	if err != nil {
+		switch err.Error() {
+		case "job not found", "action performer not found":
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		case "user is not authorized to manage jobs of this ticket's department":
+			util.ErrorResponse(c, http.StatusForbidden, err.Error(), nil)
+		case "only an open job of a ticket with other jobs can be deleted":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete job", err.Error())
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // PUT /jobs/:id/assign
 func (h *JobHandler) AssignPIC(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -109,6 +220,9 @@ func (h *JobHandler) ReorderJobs(c *gin.Context) {
 
 	err := h.commandService.ReorderJobs(c.Request.Context(), req, userNPK)
 	if err != nil {
+		if respondEditingLocked(c, err) {
+			return
+		}
 		switch err.Error() {
 		case "user can only reorder jobs within their own department", "one or more job IDs do not belong to the specified department":
 			util.ErrorResponse(c, http.StatusForbidden, err.Error(), nil)
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	service *service.NotificationService
+}
+
+func NewNotificationHandler(service *service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{service: service}
+}
+
+// GET /notifications
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	var filters dto.NotificationFilter
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	notifications, err := h.service.GetNotifications(c.Request.Context(), c.GetString("user_npk"), filters)
+	if err != nil {
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve notifications", err.Error())
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, notifications)
+}
+
+// GET /notifications/unread-count
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	count, err := h.service.GetUnreadCount(c.Request.Context(), c.GetString("user_npk"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to count unread notifications", err.Error())
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, gin.H{"unread_count": count})
+}
+
+// PATCH /notifications/:id/read
+func (h *NotificationHandler) MarkAsRead(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid notification ID format", nil)
+		return
+	}
+
+	err = h.service.MarkAsRead(c.Request.Context(), id, c.GetString("user_npk"))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Notification not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to mark notification as read", err.Error())
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, gin.H{"message": "Notification marked as read"})
+}
+
+// POST /notifications/read-all
+func (h *NotificationHandler) MarkAllAsRead(c *gin.Context) {
+	updated, err := h.service.MarkAllAsRead(c.Request.Context(), c.GetString("user_npk"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to mark notifications as read", err.Error())
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, gin.H{"updated_count": updated})
+}
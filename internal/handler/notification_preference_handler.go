@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationPreferenceHandler struct {
+	service *service.NotificationPreferenceService
+}
+
+func NewNotificationPreferenceHandler(service *service.NotificationPreferenceService) *NotificationPreferenceHandler {
+	return &NotificationPreferenceHandler{service: service}
+}
+
+// GET /notification-preference
+func (h *NotificationPreferenceHandler) GetPreference(c *gin.Context) {
+	preference, err := h.service.GetPreference(c.Request.Context(), c.GetString("user_npk"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve notification preference", err.Error())
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, preference)
+}
+
+// PUT /notification-preference
+func (h *NotificationPreferenceHandler) UpdatePreference(c *gin.Context) {
+	var req dto.UpdateNotificationPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	preference, err := h.service.UpdatePreference(c.Request.Context(), c.GetString("user_npk"), req)
+	if err != nil {
+		switch err.Error() {
+		case "employee not found":
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update notification preference", err.Error())
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, preference)
+}
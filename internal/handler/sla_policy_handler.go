@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SlaPolicyHandler struct {
+	service *service.SlaPolicyService
+}
+
+func NewSlaPolicyHandler(service *service.SlaPolicyService) *SlaPolicyHandler {
+	return &SlaPolicyHandler{service: service}
+}
+
+// POST /sla-policy
+func (h *SlaPolicyHandler) CreateSlaPolicy(c *gin.Context) {
+	var req dto.CreateSlaPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	newPolicy, err := h.service.CreateSlaPolicy(req)
+	if err != nil {
+		switch err.Error() {
+		case "sla policy for this status and department already exists":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		case "invalid status_ticket_id or department_id":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to create sla policy", nil)
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusCreated, newPolicy)
+}
+
+// GET /sla-policy
+func (h *SlaPolicyHandler) GetAllSlaPolicies(c *gin.Context) {
+	var filters dto.SlaPolicyFilter
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	policies, err := h.service.GetAllSlaPolicies(filters)
+	if err != nil {
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve sla policies", err.Error())
+		return
+	}
+
+	if policies == nil {
+		util.SuccessResponse(c, http.StatusOK, []model.SlaPolicy{})
+		return
+	}
+	util.SuccessResponse(c, http.StatusOK, policies)
+}
+
+// GET /sla-policy/:id
+func (h *SlaPolicyHandler) GetSlaPolicyByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid sla policy ID format", nil)
+		return
+	}
+
+	policy, err := h.service.GetSlaPolicyByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Sla policy not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve sla policy", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, policy)
+}
+
+// PUT /sla-policy/:id
+func (h *SlaPolicyHandler) UpdateSlaPolicy(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid sla policy ID format", nil)
+		return
+	}
+
+	var req dto.UpdateSlaPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	updated, err := h.service.UpdateSlaPolicy(id, req)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Sla policy not found", nil)
+			return
+		}
+		switch err.Error() {
+		case "sla policy for this status and department already exists":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		case "invalid status_ticket_id or department_id":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update sla policy", nil)
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, updated)
+}
+
+// PATCH /sla-policy/:id/status
+func (h *SlaPolicyHandler) UpdateSlaPolicyActiveStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid sla policy ID format", nil)
+		return
+	}
+
+	var req dto.UpdateSlaPolicyStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	err = h.service.UpdateSlaPolicyActiveStatus(id, req)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Sla policy not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update sla policy status", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, gin.H{"message": "Sla policy status updated successfully"})
+}
+
+// DELETE /sla-policy/:id
+func (h *SlaPolicyHandler) DeleteSlaPolicy(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid sla policy ID format", nil)
+		return
+	}
+
+	err = h.service.DeleteSlaPolicy(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Sla policy not found or already deleted", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete sla policy", nil)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -127,6 +127,31 @@ func (h *StatusTicketHandler) UpdateStatusTicketActiveStatus(c *gin.Context) {
 	util.SuccessResponse(c, http.StatusOK, gin.H{"message": "Status ticket status updated successfully"})
 }
 
+// PATCH /status-ticket/:id/terminal
+func (h *StatusTicketHandler) UpdateStatusTicketTerminal(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid status ticket ID format", nil)
+		return
+	}
+
+	var req dto.UpdateStatusTicketTerminalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	if err := h.service.UpdateStatusTicketTerminal(id, req); err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Status ticket not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update status ticket", nil)
+		return
+	}
+	util.SuccessResponse(c, http.StatusOK, gin.H{"message": "Status ticket terminal flag updated successfully"})
+}
+
 // POST /status-ticket/reorder
 func (h *StatusTicketHandler) ReorderStatusTickets(c *gin.Context) {
 	var req dto.ReorderStatusTicketsRequest
@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StatusTransitionHandler struct {
+	service *service.StatusTransitionService
+}
+
+func NewStatusTransitionHandler(service *service.StatusTransitionService) *StatusTransitionHandler {
+	return &StatusTransitionHandler{service: service}
+}
+
+// POST /status-transition
+func (h *StatusTransitionHandler) CreateStatusTransition(c *gin.Context) {
+	var req dto.CreateStatusTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	newTransition, err := h.service.CreateStatusTransition(req)
+	if err != nil {
+		switch err.Error() {
+		case "action already leads to a different status from this status":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		case "invalid status, action or actor role":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to create status transition", nil)
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusCreated, newTransition)
+}
+
+// GET /status-transition
+func (h *StatusTransitionHandler) GetAllStatusTransitions(c *gin.Context) {
+	var filters dto.StatusTransitionFilter
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	transitions, err := h.service.GetAllStatusTransitions(filters)
+	if err != nil {
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve status transitions", err.Error())
+		return
+	}
+
+	if transitions == nil {
+		util.SuccessResponse(c, http.StatusOK, []dto.StatusTransitionDetailResponse{})
+		return
+	}
+	util.SuccessResponse(c, http.StatusOK, transitions)
+}
+
+// GET /status-transition/:id
+func (h *StatusTransitionHandler) GetStatusTransitionByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid status transition ID format", nil)
+		return
+	}
+
+	transition, err := h.service.GetStatusTransitionByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Status transition not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve status transition", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, transition)
+}
+
+// PUT /status-transition/:id
+func (h *StatusTransitionHandler) UpdateStatusTransition(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid status transition ID format", nil)
+		return
+	}
+
+	var req dto.UpdateStatusTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	updated, err := h.service.UpdateStatusTransition(id, req)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Status transition not found", nil)
+			return
+		}
+		switch err.Error() {
+		case "action already leads to a different status from this status":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		case "invalid status, action or actor role":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update status transition", nil)
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, updated)
+}
+
+// PATCH /status-transition/:id/status
+func (h *StatusTransitionHandler) UpdateStatusTransitionActiveStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid status transition ID format", nil)
+		return
+	}
+
+	var req dto.UpdateStatusTransitionStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	if err := h.service.UpdateStatusTransitionActiveStatus(id, req); err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Status transition not found", nil)
+			return
+		}
+		if err.Error() == "action already leads to a different status from this status" {
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update status transition status", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, gin.H{"message": "Status transition status updated successfully"})
+}
+
+// DELETE /status-transition/:id
+func (h *StatusTransitionHandler) DeleteStatusTransition(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid status transition ID format", nil)
+		return
+	}
+
+	if err := h.service.DeleteStatusTransition(id); err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Status transition not found or already deleted", nil)
+			return
+		}
+		if err.Error() == "transition still has a prerequisite" {
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete status transition", nil)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GET /status-transition/validate
+func (h *StatusTransitionHandler) ValidateGraph(c *gin.Context) {
+	result, err := h.service.ValidateGraph(c.Request.Context())
+	if err != nil {
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to validate workflow graph", err.Error())
+		return
+	}
+	if result.Issues == nil {
+		result.Issues = []dto.WorkflowGraphIssue{}
+	}
+	util.SuccessResponse(c, http.StatusOK, result)
+}
+
+// PUT /status-transition/graph
+func (h *StatusTransitionHandler) SaveGraph(c *gin.Context) {
+	var req dto.SaveWorkflowGraphRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	result, err := h.service.SaveGraph(c.Request.Context(), req)
+	if err != nil {
+		respondWorkflowGraphError(c, err, "Failed to save workflow graph")
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, result)
+}
+
+// HELPER
+func respondWorkflowGraphError(c *gin.Context, err error, fallbackMessage string) {
+	var invalidErr *service.WorkflowGraphInvalidError
+	if errors.As(err, &invalidErr) {
+		util.ErrorResponse(c, http.StatusUnprocessableEntity, err.Error(), invalidErr.Issues)
+		return
+	}
+	if err.Error() == "invalid status, action or actor role" {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	util.ErrorResponse(c, http.StatusInternalServerError, fallbackMessage, nil)
+}
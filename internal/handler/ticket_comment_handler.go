@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"os"
+	"strconv"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+	"e-memo-job-reservation-api/pkg/filehandler"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TicketCommentHandler struct {
+	service *service.TicketCommentService
+}
+
+func NewTicketCommentHandler(service *service.TicketCommentService) *TicketCommentHandler {
+	return &TicketCommentHandler{service: service}
+}
+
+// POST /tickets/:id/comments
+func (h *TicketCommentHandler) CreateComment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid ticket ID format", nil)
+		return
+	}
+
+	var req dto.CreateTicketCommentRequest
+	if err := c.ShouldBind(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	var filesMetadata []model.FileMetadata
+	form, err := c.MultipartForm()
+	if err == nil {
+		files := form.File["Files"]
+		if len(files) > 0 {
+			savedMetadata, saveErr := filehandler.SaveFiles(c, files)
+			if saveErr != nil {
+				util.ErrorResponse(c, http.StatusInternalServerError, "Failed to save uploaded files", nil)
+				return
+			}
+			filesMetadata = savedMetadata
+		}
+	}
+
+	comment, err := h.service.CreateComment(c.Request.Context(), id, c.GetString("user_npk"), req, filesMetadata)
+	if err != nil {
+		for _, metadata := range filesMetadata {
+			os.Remove(metadata.FilePath)
+		}
+		switch err.Error() {
+		case "ticket not found", "parent comment not found", "user not found", "requestor employee not found":
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		case "user is not involved in this ticket":
+			util.ErrorResponse(c, http.StatusForbidden, err.Error(), nil)
+		case "comment body is required":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to create comment", err.Error())
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusCreated, comment)
+}
+
+// GET /tickets/:id/comments
+func (h *TicketCommentHandler) GetComments(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid ticket ID format", nil)
+		return
+	}
+
+	comments, err := h.service.GetComments(c.Request.Context(), id, c.GetString("user_npk"))
+	if err != nil {
+		switch err.Error() {
+		case "ticket not found", "user not found", "requestor employee not found":
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		case "user is not involved in this ticket":
+			util.ErrorResponse(c, http.StatusForbidden, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve comments", err.Error())
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, comments)
+}
+
+// PUT /tickets/:id/comments/:commentId
+func (h *TicketCommentHandler) UpdateComment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid ticket ID format", nil)
+		return
+	}
+	commentID, err := strconv.ParseInt(c.Param("commentId"), 10, 64)
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid comment ID format", nil)
+		return
+	}
+
+	var req dto.UpdateTicketCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	comment, err := h.service.UpdateComment(c.Request.Context(), id, commentID, c.GetString("user_npk"), req)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Comment not found", nil)
+			return
+		}
+		switch err.Error() {
+		case "only the author can change this comment":
+			util.ErrorResponse(c, http.StatusForbidden, err.Error(), nil)
+		case "comment has been deleted":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		case "comment body is required":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update comment", err.Error())
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, comment)
+}
+
+// DELETE /tickets/:id/comments/:commentId
+func (h *TicketCommentHandler) DeleteComment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid ticket ID format", nil)
+		return
+	}
+	commentID, err := strconv.ParseInt(c.Param("commentId"), 10, 64)
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid comment ID format", nil)
+		return
+	}
+
+	err = h.service.DeleteComment(c.Request.Context(), id, commentID, c.GetString("user_npk"))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Comment not found", nil)
+			return
+		}
+		switch err.Error() {
+		case "only the author can change this comment":
+			util.ErrorResponse(c, http.StatusForbidden, err.Error(), nil)
+		case "comment has been deleted":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete comment", err.Error())
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GET /tickets/:id/comments/:commentId/history
+func (h *TicketCommentHandler) GetCommentHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid ticket ID format", nil)
+		return
+	}
+	commentID, err := strconv.ParseInt(c.Param("commentId"), 10, 64)
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid comment ID format", nil)
+		return
+	}
+
+	history, err := h.service.GetCommentHistory(c.Request.Context(), id, commentID, c.GetString("user_npk"))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Comment not found", nil)
+			return
+		}
+		switch err.Error() {
+		case "ticket not found", "user not found", "requestor employee not found":
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		case "user is not involved in this ticket":
+			util.ErrorResponse(c, http.StatusForbidden, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve comment history", err.Error())
+		}
+		return
+	}
+	if history == nil {
+		history = []dto.TicketCommentHistoryResponse{}
+	}
+
+	util.SuccessResponse(c, http.StatusOK, history)
+}
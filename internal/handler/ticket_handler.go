@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 
 	"e-memo-job-reservation-api/internal/dto"
 	"e-memo-job-reservation-api/internal/model"
@@ -113,7 +114,7 @@ func (h *TicketHandler) GetAllTickets(c *gin.Context) {
 func (h *TicketHandler) GetTicketByID(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
 
-	ticket, err := h.queryService.GetTicketByID(id)
+	ticket, err := h.queryService.GetTicketByID(c.Request.Context(), id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			util.ErrorResponse(c, http.StatusNotFound, "Ticket not found", nil)
@@ -142,6 +143,9 @@ func (h *TicketHandler) UpdateTicket(c *gin.Context) {
 
 	err = h.commandService.UpdateTicket(c.Request.Context(), id, req, userNPK)
 	if err != nil {
+		if respondEditingLocked(c, err) {
+			return
+		}
 		switch err.Error() {
 		case "ticket not found", "user not found", "original requestor not found":
 			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
@@ -172,6 +176,9 @@ func (h *TicketHandler) ReorderTickets(c *gin.Context) {
 	}
 	err := h.priorityService.ReorderTickets(c.Request.Context(), req, userNPK)
 	if err != nil {
+		if respondEditingLocked(c, err) {
+			return
+		}
 		switch err.Error() {
 		case "data conflict: one or more tickets have been modified by another user, please refresh":
 			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
@@ -219,12 +226,18 @@ func (h *TicketHandler) ExecuteAction(c *gin.Context) {
 		for _, metadata := range filesMetadata {
 			os.Remove(metadata.FilePath)
 		}
+		if strings.HasPrefix(err.Error(), "ticket is blocked by open ticket") {
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+			return
+		}
 		switch err.Error() {
 		case "ticket not found", "user not found", "original requestor not found":
 			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
 		case "user does not have the required role for this action":
 			util.ErrorResponse(c, http.StatusForbidden, err.Error(), nil)
-		case "action not allowed from the current status", "reason is required for this action", "file upload is required for this action":
+		case "action not allowed from the current status", "reason is required for this action", "file upload is required for this action", "transition prerequisite has not been met",
+			"user has already approved this action", "actor role of the user has already approved this action",
+			"reason code is required for this action", "invalid reason code for this action", "ticket still has unfinished jobs":
 			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
 		default:
 			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to execute action", err.Error())
@@ -235,6 +248,74 @@ func (h *TicketHandler) ExecuteAction(c *gin.Context) {
 	util.SuccessResponse(c, http.StatusOK, gin.H{"message": "Action '" + req.ActionName + "' executed successfully"})
 }
 
+// POST /tickets/:id/cancel
+func (h *TicketHandler) CancelTicket(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid ticket ID format", nil)
+		return
+	}
+	userNPK := c.GetString("user_npk")
+
+	var req dto.TicketReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	err = h.workflowService.CancelTicket(c.Request.Context(), id, userNPK, req)
+	if err != nil {
+		switch err.Error() {
+		case "ticket not found", "user not found", "user employee not found":
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		case "user does not have the required role or action is not allowed from the current status":
+			util.ErrorResponse(c, http.StatusForbidden, "ticket cannot be cancelled by this user in its current status", nil)
+		case "reason is required for this action", "transition prerequisite has not been met",
+			"reason code is required for this action", "invalid reason code for this action":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to cancel ticket", err.Error())
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, gin.H{"message": "Ticket cancelled successfully"})
+}
+
+// POST /tickets/:id/reopen
+func (h *TicketHandler) ReopenTicket(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid ticket ID format", nil)
+		return
+	}
+	userNPK := c.GetString("user_npk")
+
+	var req dto.TicketReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	err = h.workflowService.ReopenTicket(c.Request.Context(), id, userNPK, req)
+	if err != nil {
+		switch err.Error() {
+		case "ticket not found", "user not found", "user employee not found":
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		case "user does not have the required role or action is not allowed from the current status":
+			util.ErrorResponse(c, http.StatusForbidden, "ticket cannot be reopened by this user in its current status", nil)
+		case "reason is required for this action", "transition prerequisite has not been met",
+			"reason code is required for this action", "invalid reason code for this action":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to reopen ticket", err.Error())
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, gin.H{"message": "Ticket reopened successfully"})
+}
+
 // GET /tickets/:id/available-actions
 func (h *TicketHandler) GetAvailableActions(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
@@ -385,3 +466,24 @@ func (h *TicketHandler) GetLastRejectionDetail(c *gin.Context) {
 
 	util.SuccessResponse(c, http.StatusOK, rejectionDetail)
 }
+
+// GET /tickets/:id/timeline
+func (h *TicketHandler) GetTicketTimeline(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid ticket ID format", nil)
+		return
+	}
+
+	timeline, err := h.queryService.GetTicketTimeline(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == "ticket not found" {
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve ticket timeline", err.Error())
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, timeline)
+}
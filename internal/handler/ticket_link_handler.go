@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TicketLinkHandler struct {
+	service *service.TicketLinkService
+}
+
+func NewTicketLinkHandler(service *service.TicketLinkService) *TicketLinkHandler {
+	return &TicketLinkHandler{service: service}
+}
+
+// POST /tickets/:id/links
+func (h *TicketLinkHandler) CreateLink(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid ticket ID format", nil)
+		return
+	}
+
+	var req dto.CreateTicketLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	links, err := h.service.CreateLink(c.Request.Context(), id, c.GetString("user_npk"), req)
+	if err != nil {
+		switch err.Error() {
+		case "ticket not found", "linked ticket not found", "user not found", "user employee not found", "requestor employee not found":
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		case "user is not involved in this ticket", "user is not involved in the linked ticket":
+			util.ErrorResponse(c, http.StatusForbidden, err.Error(), nil)
+		case "user does not have the required role or action is not allowed from the current status":
+			util.ErrorResponse(c, http.StatusForbidden, "ticket cannot be marked as a duplicate by this user in its current status", nil)
+		case "ticket cannot be linked to itself":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		case "tickets are already linked with this type", "original ticket is itself marked as a duplicate",
+			"transition prerequisite has not been met", "reason code is required for this action":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to link tickets", err.Error())
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusCreated, links)
+}
+
+// GET /tickets/:id/links
+func (h *TicketLinkHandler) GetLinks(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid ticket ID format", nil)
+		return
+	}
+
+	links, err := h.service.GetLinks(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == "ticket not found" {
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve ticket links", err.Error())
+		return
+	}
+	if links == nil {
+		links = []dto.TicketLinkResponse{}
+	}
+
+	util.SuccessResponse(c, http.StatusOK, links)
+}
+
+// DELETE /tickets/:id/links/:linkId
+func (h *TicketLinkHandler) DeleteLink(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid ticket ID format", nil)
+		return
+	}
+	linkID, err := strconv.Atoi(c.Param("linkId"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid link ID format", nil)
+		return
+	}
+
+	err = h.service.DeleteLink(c.Request.Context(), id, linkID, c.GetString("user_npk"))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Ticket link not found", nil)
+			return
+		}
+		switch err.Error() {
+		case "ticket not found", "user not found", "requestor employee not found":
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		case "user is not involved in this ticket", "user is not involved in the linked ticket":
+			util.ErrorResponse(c, http.StatusForbidden, err.Error(), nil)
+		case "duplicate link cannot be removed":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete ticket link", err.Error())
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TicketWatcherHandler struct {
+	service *service.TicketWatcherService
+}
+
+func NewTicketWatcherHandler(service *service.TicketWatcherService) *TicketWatcherHandler {
+	return &TicketWatcherHandler{service: service}
+}
+
+// POST /tickets/:id/watchers
+func (h *TicketWatcherHandler) AddWatcher(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid ticket ID format", nil)
+		return
+	}
+
+	// The body is optional, without it the user follows the ticket
+	var req dto.AddTicketWatcherRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+	}
+
+	watchers, err := h.service.AddWatcher(c.Request.Context(), id, c.GetString("user_npk"), req)
+	if err != nil {
+		switch err.Error() {
+		case "ticket not found", "employee not found", "user not found", "requestor employee not found":
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		case "user is not involved in this ticket":
+			util.ErrorResponse(c, http.StatusForbidden, err.Error(), nil)
+		case "employee is not active":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to add ticket watcher", err.Error())
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusCreated, watchers)
+}
+
+// GET /tickets/:id/watchers
+func (h *TicketWatcherHandler) GetWatchers(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid ticket ID format", nil)
+		return
+	}
+
+	watchers, err := h.service.GetWatchers(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == "ticket not found" {
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve ticket watchers", err.Error())
+		return
+	}
+	if watchers == nil {
+		watchers = []dto.TicketWatcherResponse{}
+	}
+
+	util.SuccessResponse(c, http.StatusOK, watchers)
+}
+
+// DELETE /tickets/:id/watchers?employee_npk=
+func (h *TicketWatcherHandler) RemoveWatcher(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid ticket ID format", nil)
+		return
+	}
+
+	err = h.service.RemoveWatcher(c.Request.Context(), id, c.GetString("user_npk"), c.Query("employee_npk"))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Employee is not watching this ticket", nil)
+			return
+		}
+		switch err.Error() {
+		case "ticket not found", "user not found", "requestor employee not found":
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		case "user is not involved in this ticket":
+			util.ErrorResponse(c, http.StatusForbidden, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to remove ticket watcher", err.Error())
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
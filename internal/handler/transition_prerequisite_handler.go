@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TransitionPrerequisiteHandler struct {
+	service *service.TransitionPrerequisiteService
+}
+
+func NewTransitionPrerequisiteHandler(service *service.TransitionPrerequisiteService) *TransitionPrerequisiteHandler {
+	return &TransitionPrerequisiteHandler{service: service}
+}
+
+// POST /transition-prerequisite
+func (h *TransitionPrerequisiteHandler) CreateTransitionPrerequisite(c *gin.Context) {
+	var req dto.CreateTransitionPrerequisiteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	newPrerequisite, err := h.service.CreateTransitionPrerequisite(req)
+	if err != nil {
+		switch err.Error() {
+		case "prerequisite already exists for this transition":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		case "invalid transition_id or required_status_id":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to create transition prerequisite", nil)
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusCreated, newPrerequisite)
+}
+
+// GET /transition-prerequisite
+func (h *TransitionPrerequisiteHandler) GetAllTransitionPrerequisites(c *gin.Context) {
+	var filters dto.TransitionPrerequisiteFilter
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	prerequisites, err := h.service.GetAllTransitionPrerequisites(filters)
+	if err != nil {
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve transition prerequisites", err.Error())
+		return
+	}
+
+	if prerequisites == nil {
+		util.SuccessResponse(c, http.StatusOK, []dto.TransitionPrerequisiteDetailResponse{})
+		return
+	}
+	util.SuccessResponse(c, http.StatusOK, prerequisites)
+}
+
+// GET /transition-prerequisite/:transitionId
+func (h *TransitionPrerequisiteHandler) GetTransitionPrerequisiteByTransitionID(c *gin.Context) {
+	transitionID, err := strconv.Atoi(c.Param("transitionId"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid transition ID format", nil)
+		return
+	}
+
+	prerequisite, err := h.service.GetTransitionPrerequisiteByTransitionID(transitionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Transition prerequisite not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve transition prerequisite", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, prerequisite)
+}
+
+// PUT /transition-prerequisite/:transitionId
+func (h *TransitionPrerequisiteHandler) UpdateTransitionPrerequisite(c *gin.Context) {
+	transitionID, err := strconv.Atoi(c.Param("transitionId"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid transition ID format", nil)
+		return
+	}
+
+	var req dto.UpdateTransitionPrerequisiteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	updated, err := h.service.UpdateTransitionPrerequisite(transitionID, req)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Transition prerequisite not found", nil)
+			return
+		}
+		if err.Error() == "invalid transition_id or required_status_id" {
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update transition prerequisite", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, updated)
+}
+
+// PATCH /transition-prerequisite/:transitionId/status
+func (h *TransitionPrerequisiteHandler) UpdateTransitionPrerequisiteActiveStatus(c *gin.Context) {
+	transitionID, err := strconv.Atoi(c.Param("transitionId"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid transition ID format", nil)
+		return
+	}
+
+	var req dto.UpdateTransitionPrerequisiteStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	err = h.service.UpdateTransitionPrerequisiteActiveStatus(transitionID, req)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Transition prerequisite not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update transition prerequisite status", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, gin.H{"message": "Transition prerequisite status updated successfully"})
+}
+
+// DELETE /transition-prerequisite/:transitionId
+func (h *TransitionPrerequisiteHandler) DeleteTransitionPrerequisite(c *gin.Context) {
+	transitionID, err := strconv.Atoi(c.Param("transitionId"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid transition ID format", nil)
+		return
+	}
+
+	err = h.service.DeleteTransitionPrerequisite(transitionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Transition prerequisite not found or already deleted", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete transition prerequisite", nil)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	service *service.WebhookService
+}
+
+func NewWebhookHandler(service *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// POST /webhook
+func (h *WebhookHandler) CreateEndpoint(c *gin.Context) {
+	var req dto.CreateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	endpoint, err := h.service.CreateEndpoint(req)
+	if err != nil {
+		switch err.Error() {
+		case "events contain an unsupported event type":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to create webhook endpoint", nil)
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusCreated, endpoint)
+}
+
+// GET /webhook
+func (h *WebhookHandler) GetAllEndpoints(c *gin.Context) {
+	endpoints, err := h.service.GetAllEndpoints()
+	if err != nil {
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve webhook endpoints", err.Error())
+		return
+	}
+
+	if endpoints == nil {
+		util.SuccessResponse(c, http.StatusOK, []model.WebhookEndpoint{})
+		return
+	}
+	util.SuccessResponse(c, http.StatusOK, endpoints)
+}
+
+// GET /webhook/:id
+func (h *WebhookHandler) GetEndpointByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid webhook endpoint ID format", nil)
+		return
+	}
+
+	endpoint, err := h.service.GetEndpointByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Webhook endpoint not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve webhook endpoint", err.Error())
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, endpoint)
+}
+
+// PUT /webhook/:id
+func (h *WebhookHandler) UpdateEndpoint(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid webhook endpoint ID format", nil)
+		return
+	}
+
+	var req dto.UpdateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	endpoint, err := h.service.UpdateEndpoint(id, req)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Webhook endpoint not found", nil)
+			return
+		}
+		switch err.Error() {
+		case "events contain an unsupported event type":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update webhook endpoint", nil)
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, endpoint)
+}
+
+// PATCH /webhook/:id/status
+func (h *WebhookHandler) UpdateEndpointActiveStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid webhook endpoint ID format", nil)
+		return
+	}
+
+	var req dto.UpdateWebhookEndpointStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	if err := h.service.UpdateEndpointActiveStatus(id, req); err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Webhook endpoint not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update webhook endpoint status", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, gin.H{"message": "Webhook endpoint status updated successfully"})
+}
+
+// DELETE /webhook/:id
+func (h *WebhookHandler) DeleteEndpoint(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid webhook endpoint ID format", nil)
+		return
+	}
+
+	if err := h.service.DeleteEndpoint(id); err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Webhook endpoint not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete webhook endpoint", nil)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GET /webhook-delivery
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	var filters dto.WebhookDeliveryFilter
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	deliveries, err := h.service.GetDeliveries(c.Request.Context(), filters)
+	if err != nil {
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve webhook deliveries", err.Error())
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, deliveries)
+}
+
+// POST /webhook-delivery/:id/redeliver
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid webhook delivery ID format", nil)
+		return
+	}
+
+	delivery, err := h.service.Redeliver(c.Request.Context(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Webhook delivery not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to redeliver webhook", err.Error())
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusCreated, delivery)
+}
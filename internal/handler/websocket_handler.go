@@ -31,12 +31,13 @@ var upgrader = websocket.Upgrader{
 }
 
 type WebSocketHandler struct {
-	hub      *ws.Hub
-	authRepo *repository.AuthRepository
+	hub         *ws.Hub
+	authRepo    *repository.AuthRepository
+	appUserRepo *repository.AppUserRepository
 }
 
-func NewWebSocketHandler(hub *ws.Hub, authRepo *repository.AuthRepository) *WebSocketHandler {
-	return &WebSocketHandler{hub: hub, authRepo: authRepo}
+func NewWebSocketHandler(hub *ws.Hub, authRepo *repository.AuthRepository, appUserRepo *repository.AppUserRepository) *WebSocketHandler {
+	return &WebSocketHandler{hub: hub, authRepo: authRepo, appUserRepo: appUserRepo}
 }
 
 // HANDLE WEBSOCKET REQUEST FROM CLIENT
@@ -52,6 +53,19 @@ func (h *WebSocketHandler) ServeWs(c *gin.Context) {
 		return
 	}
 
+	// Employee NPK is needed to route "involved" ticket messages
+	var employeeNPK string
+	if userID > 0 {
+		user, err := h.appUserRepo.FindByID(userID)
+		if err != nil {
+			log.Printf("WebSocket connection rejected: user %d not found: %v", userID, err)
+			return
+		}
+		if user.EmployeeNPK.Valid {
+			employeeNPK = user.EmployeeNPK.String
+		}
+	}
+
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		log.Printf("Failed to upgrade WebSocket connection: %v", err)
@@ -62,11 +76,12 @@ func (h *WebSocketHandler) ServeWs(c *gin.Context) {
 	clientID := uuid.New().String()
 
 	client := &ws.Client{
-		ID:     clientID,
-		Hub:    h.hub,
-		Conn:   conn,
-		Send:   make(chan []byte, 256),
-		UserID: userID,
+		ID:          clientID,
+		Hub:         h.hub,
+		Conn:        conn,
+		Send:        make(chan []byte, 256),
+		UserID:      userID,
+		EmployeeNPK: employeeNPK,
 	}
 	client.Hub.Register <- client
 
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkCalendarHandler struct {
+	service *service.WorkCalendarService
+}
+
+func NewWorkCalendarHandler(service *service.WorkCalendarService) *WorkCalendarHandler {
+	return &WorkCalendarHandler{service: service}
+}
+
+// GET /work-calendar/work-days
+func (h *WorkCalendarHandler) GetWorkDays(c *gin.Context) {
+	workDays, err := h.service.GetWorkDays()
+	if err != nil {
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve work days", err.Error())
+		return
+	}
+
+	if workDays == nil {
+		util.SuccessResponse(c, http.StatusOK, []model.WorkDay{})
+		return
+	}
+	util.SuccessResponse(c, http.StatusOK, workDays)
+}
+
+// PUT /work-calendar/work-days
+func (h *WorkCalendarHandler) UpdateWorkDays(c *gin.Context) {
+	var req dto.UpdateWorkDaysRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	workDays, err := h.service.UpdateWorkDays(c.Request.Context(), req)
+	if err != nil {
+		if err.Error() == "at least one work day is required" {
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update work days", err.Error())
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, workDays)
+}
+
+// POST /work-calendar/holidays
+func (h *WorkCalendarHandler) CreateHoliday(c *gin.Context) {
+	var req dto.CreateHolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	newHoliday, err := h.service.CreateHoliday(req)
+	if err != nil {
+		switch err.Error() {
+		case "invalid holiday_date format, expected YYYY-MM-DD":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		case "holiday on this date already exists":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to create holiday", nil)
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusCreated, newHoliday)
+}
+
+// GET /work-calendar/holidays
+func (h *WorkCalendarHandler) GetAllHolidays(c *gin.Context) {
+	var filters dto.HolidayFilter
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	holidays, err := h.service.GetAllHolidays(filters)
+	if err != nil {
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve holidays", err.Error())
+		return
+	}
+
+	if holidays == nil {
+		util.SuccessResponse(c, http.StatusOK, []model.Holiday{})
+		return
+	}
+	util.SuccessResponse(c, http.StatusOK, holidays)
+}
+
+// GET /work-calendar/holidays/:id
+func (h *WorkCalendarHandler) GetHolidayByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid holiday ID format", nil)
+		return
+	}
+
+	holiday, err := h.service.GetHolidayByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Holiday not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve holiday", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, holiday)
+}
+
+// PUT /work-calendar/holidays/:id
+func (h *WorkCalendarHandler) UpdateHoliday(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid holiday ID format", nil)
+		return
+	}
+
+	var req dto.UpdateHolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	updated, err := h.service.UpdateHoliday(id, req)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Holiday not found", nil)
+			return
+		}
+		switch err.Error() {
+		case "invalid holiday_date format, expected YYYY-MM-DD":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		case "holiday on this date already exists":
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update holiday", nil)
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, updated)
+}
+
+// PATCH /work-calendar/holidays/:id/status
+func (h *WorkCalendarHandler) UpdateHolidayActiveStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid holiday ID format", nil)
+		return
+	}
+
+	var req dto.UpdateHolidayStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	err = h.service.UpdateHolidayActiveStatus(id, req)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Holiday not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to update holiday status", nil)
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, gin.H{"message": "Holiday status updated successfully"})
+}
+
+// DELETE /work-calendar/holidays/:id
+func (h *WorkCalendarHandler) DeleteHoliday(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid holiday ID format", nil)
+		return
+	}
+
+	err = h.service.DeleteHoliday(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Holiday not found or already deleted", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete holiday", nil)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
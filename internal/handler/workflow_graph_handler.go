@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkflowGraphHandler struct {
+	service *service.WorkflowGraphService
+}
+
+func NewWorkflowGraphHandler(service *service.WorkflowGraphService) *WorkflowGraphHandler {
+	return &WorkflowGraphHandler{service: service}
+}
+
+// GET /workflow/:id/graph?format=json|dot|mermaid
+func (h *WorkflowGraphHandler) ExportGraph(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid workflow ID format", nil)
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "dot" && format != "mermaid" {
+		util.ErrorResponse(c, http.StatusBadRequest, "format must be json, dot or mermaid", nil)
+		return
+	}
+
+	graph, err := h.service.ExportGraph(c.Request.Context(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Workflow not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to export workflow graph", nil)
+		return
+	}
+
+	switch format {
+	case "dot":
+		c.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(service.RenderWorkflowGraphDOT(graph)))
+	case "mermaid":
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(service.RenderWorkflowGraphMermaid(graph)))
+	default:
+		util.SuccessResponse(c, http.StatusOK, graph)
+	}
+}
+
+// PUT /workflow/:id/graph
+func (h *WorkflowGraphHandler) ImportGraph(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid workflow ID format", nil)
+		return
+	}
+
+	var req dto.WorkflowGraphExport
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	result, err := h.service.ImportGraph(c.Request.Context(), id, req)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Workflow not found", nil)
+			return
+		}
+		respondWorkflowGraphError(c, err, "Failed to import workflow graph")
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, result)
+}
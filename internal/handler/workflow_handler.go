@@ -176,6 +176,10 @@ func (h *WorkflowHandler) DeleteWorkflowStep(c *gin.Context) {
 			util.ErrorResponse(c, http.StatusNotFound, "Workflow step not found or sequence is not 0", nil)
 			return
 		}
+		if err.Error() == "workflow step still has open tickets, publish a version and migrate them first" {
+			util.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+			return
+		}
 		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete workflow step", nil)
 		return
 	}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkflowSimulationHandler struct {
+	service *service.WorkflowSimulationService
+}
+
+func NewWorkflowSimulationHandler(service *service.WorkflowSimulationService) *WorkflowSimulationHandler {
+	return &WorkflowSimulationHandler{service: service}
+}
+
+// POST /workflow/simulate
+func (h *WorkflowSimulationHandler) Simulate(c *gin.Context) {
+	var req dto.SimulateWorkflowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	result, err := h.service.Simulate(c.Request.Context(), req)
+	if err != nil {
+		switch err.Error() {
+		case "user employee not found", "employee position not found", "ticket not found", "requestor employee not found", "current status not found":
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to simulate workflow", nil)
+		}
+		return
+	}
+
+	if result.UserContexts == nil {
+		result.UserContexts = []string{}
+	}
+	if result.ActorRoleIDs == nil {
+		result.ActorRoleIDs = []int{}
+	}
+	if result.AvailableActions == nil {
+		result.AvailableActions = []dto.AvailableTicketActionResponse{}
+	}
+	util.SuccessResponse(c, http.StatusOK, result)
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkflowVersionHandler struct {
+	service *service.WorkflowVersionService
+}
+
+func NewWorkflowVersionHandler(service *service.WorkflowVersionService) *WorkflowVersionHandler {
+	return &WorkflowVersionHandler{service: service}
+}
+
+// POST /workflow/:id/publish
+func (h *WorkflowVersionHandler) Publish(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid workflow ID format", nil)
+		return
+	}
+
+	var req dto.PublishWorkflowVersionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		// The note is optional, an empty body is accepted
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	version, err := h.service.Publish(c.Request.Context(), id, req, c.GetString("user_npk"))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Workflow not found", nil)
+			return
+		}
+		if err.Error() == "workflow has no steps to publish" {
+			util.ErrorResponse(c, http.StatusUnprocessableEntity, err.Error(), nil)
+			return
+		}
+		respondWorkflowGraphError(c, err, "Failed to publish workflow version")
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusCreated, version)
+}
+
+// GET /workflow/:id/versions
+func (h *WorkflowVersionHandler) GetVersionsByWorkflowID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid workflow ID format", nil)
+		return
+	}
+
+	versions, err := h.service.GetVersionsByWorkflowID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Workflow not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve workflow versions", nil)
+		return
+	}
+	if versions == nil {
+		versions = []model.WorkflowVersion{}
+	}
+	util.SuccessResponse(c, http.StatusOK, versions)
+}
+
+// GET /workflow-version/:id
+func (h *WorkflowVersionHandler) GetVersionByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid workflow version ID format", nil)
+		return
+	}
+
+	version, err := h.service.GetVersionByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Workflow version not found", nil)
+			return
+		}
+		util.ErrorResponse(c, http.StatusInternalServerError, "Failed to retrieve workflow version", nil)
+		return
+	}
+	util.SuccessResponse(c, http.StatusOK, version)
+}
+
+// POST /workflow/:id/migrate-tickets
+func (h *WorkflowVersionHandler) MigrateTickets(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, "Invalid workflow ID format", nil)
+		return
+	}
+
+	var req dto.MigrateWorkflowTicketsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	result, err := h.service.MigrateTickets(c.Request.Context(), id, req, c.GetString("user_npk"))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			util.ErrorResponse(c, http.StatusNotFound, "Workflow not found", nil)
+			return
+		}
+		switch err.Error() {
+		case "workflow version not found":
+			util.ErrorResponse(c, http.StatusNotFound, err.Error(), nil)
+		case "source and target version are the same", "workflow version does not belong to this workflow",
+			"status mapping lists a status more than once", "status mapping points to a status outside the target version":
+			util.ErrorResponse(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			util.ErrorResponse(c, http.StatusInternalServerError, "Failed to migrate tickets", nil)
+		}
+		return
+	}
+
+	util.SuccessResponse(c, http.StatusOK, result)
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type ActionReasonCode struct {
+	ID        int       `json:"id"`
+	ActionID  int       `json:"action_id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
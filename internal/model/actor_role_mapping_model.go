@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type ActorRoleMapping struct {
+	ID                 int       `json:"id"`
+	EmployeePositionID int       `json:"employee_position_id"`
+	Context            string    `json:"context"`
+	ActorRoleID        int       `json:"actor_role_id"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+type ActorRole struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+type EditingLock struct {
+	LockKey     string    `json:"lock_key"`
+	Entity      string    `json:"entity"`
+	ContextID   int       `json:"context_id"`
+	UserID      int       `json:"user_id"`
+	HolderNPK   *string   `json:"holder_npk"`
+	ClientID    *string   `json:"client_id"`
+	AcquiredAt  time.Time `json:"acquired_at"`
+	HeartbeatAt time.Time `json:"heartbeat_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// EditingLockKey builds the "entity:context_id" key shared by the websocket sessions and the REST commands
+func EditingLockKey(entity string, contextID int) string {
+	return fmt.Sprintf("%s:%d", entity, contextID)
+}
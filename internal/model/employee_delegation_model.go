@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+type EmployeeDelegation struct {
+	ID           int       `json:"id"`
+	DelegatorNPK string    `json:"delegator_npk"`
+	DelegateNPK  string    `json:"delegate_npk"`
+	StartDate    time.Time `json:"start_date"`
+	EndDate      time.Time `json:"end_date"`
+	Reason       *string   `json:"reason"`
+	IsActive     bool      `json:"is_active"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+type EscalationRule struct {
+	ID                  int       `json:"id"`
+	Name                string    `json:"name"`
+	StatusTicketID      int       `json:"status_ticket_id"`
+	DepartmentID        *int      `json:"department_id"`
+	AfterHours          int       `json:"after_hours"`
+	EscalationType      string    `json:"escalation_type"`
+	NotifyActorRoleID   *int      `json:"notify_actor_role_id"`
+	ActionID            *int      `json:"action_id"`
+	ReminderHours       *int      `json:"reminder_hours"`
+	OnBehalfOfRequestor bool      `json:"on_behalf_of_requestor"`
+	IsActive            bool      `json:"is_active"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
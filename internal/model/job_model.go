@@ -6,13 +6,18 @@ import (
 )
 
 type Job struct {
-	ID          int            `json:"id"`
-	TicketID    int            `json:"ticket_id"`
-	PicJob      sql.NullString `json:"pic_job"`
-	JobPriority int            `json:"job_priority"`
-	ReportFiles []FileMetadata `json:"report_files"`
-	Version     int            `json:"version"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	Ticket      Ticket         `json:"ticket,omitempty"`
+	ID             int            `json:"id"`
+	TicketID       int            `json:"ticket_id"`
+	Title          sql.NullString `json:"title"`
+	PicJob         sql.NullString `json:"pic_job"`
+	JobPriority    int            `json:"job_priority"`
+	ReportFiles    []FileMetadata `json:"report_files"`
+	SpendingAmount sql.NullInt64  `json:"spending_amount"`
+	Status         string         `json:"status"`
+	CompletedAt    sql.NullTime   `json:"completed_at"`
+	CompletedByNpk sql.NullString `json:"completed_by_npk"`
+	Version        int            `json:"version"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	Ticket         Ticket         `json:"ticket,omitempty"`
 }
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type Notification struct {
+	ID           int64      `json:"id"`
+	RecipientNPK string     `json:"recipient_npk"`
+	TicketID     *int       `json:"ticket_id"`
+	Type         string     `json:"type"`
+	Title        string     `json:"title"`
+	Message      string     `json:"message"`
+	ActorNPK     *string    `json:"actor_npk"`
+	IsRead       bool       `json:"is_read"`
+	ReadAt       *time.Time `json:"read_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
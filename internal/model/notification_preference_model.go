@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+type NotificationPreference struct {
+	EmployeeNPK  string    `json:"employee_npk"`
+	Email        *string   `json:"email"`
+	Language     string    `json:"language"`
+	EmailEnabled bool      `json:"email_enabled"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type EmailQueue struct {
+	ID             int64      `json:"id"`
+	RecipientNPK   *string    `json:"recipient_npk"`
+	RecipientEmail string     `json:"recipient_email"`
+	Subject        string     `json:"subject"`
+	Body           string     `json:"body"`
+	Event          string     `json:"event"`
+	TicketID       *int       `json:"ticket_id"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastError      *string    `json:"last_error"`
+	SentAt         *time.Time `json:"sent_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+type SlaPolicy struct {
+	ID                int       `json:"id"`
+	StatusTicketID    int       `json:"status_ticket_id"`
+	DepartmentID      *int      `json:"department_id"`
+	MaxDays           int       `json:"max_days"`
+	WarningPercentage int       `json:"warning_percentage"`
+	IsActive          bool      `json:"is_active"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
@@ -17,4 +17,12 @@ type TicketActionLog struct {
 	FromStatusID   sql.NullInt32  `json:"from_status_id"`
 	ToStatusID     int            `json:"to_status_id"`
 	PerformedAt    time.Time      `json:"performed_at"`
+	// Set when the action was performed with a role delegated by that employee
+	OnBehalfOfNpk sql.NullString `json:"on_behalf_of_npk"`
+	// Set when the action needs a quorum, partial approvals leave the ticket in its status
+	ApprovalActorRoleID sql.NullInt32 `json:"approval_actor_role_id"`
+	IsPartialApproval   bool          `json:"is_partial_approval"`
+	// Set instead of the performer when the worker wrote the entry, escalation notices have no action
+	SystemActor  sql.NullString `json:"system_actor"`
+	ReasonCodeID sql.NullInt32  `json:"reason_code_id"`
 }
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type TicketComment struct {
+	ID            int64          `json:"id"`
+	TicketID      int            `json:"ticket_id"`
+	ParentID      sql.NullInt64  `json:"parent_id"`
+	AuthorNPK     string         `json:"author_npk"`
+	AuthorName    string         `json:"author_name"`
+	Body          string         `json:"body"`
+	Attachments   []FileMetadata `json:"attachments"`
+	MentionedNPKs pq.StringArray `json:"mentioned_npks"`
+	EditedAt      sql.NullTime   `json:"edited_at"`
+	DeletedAt     sql.NullTime   `json:"deleted_at"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
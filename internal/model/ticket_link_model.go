@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type TicketLink struct {
+	ID             int       `json:"id"`
+	TicketID       int       `json:"ticket_id"`
+	LinkedTicketID int       `json:"linked_ticket_id"`
+	LinkType       string    `json:"link_type"`
+	CreatedByNPK   string    `json:"created_by_npk"`
+	CreatedAt      time.Time `json:"created_at"`
+}
@@ -16,6 +16,7 @@ type Ticket struct {
 	SupportFiles        []FileMetadata `json:"support_files"`
 	Version             int            `json:"version"`
 	Deadline            sql.NullTime   `json:"deadline"`
+	WorkflowVersionID   sql.NullInt64  `json:"workflow_version_id"`
 	CreatedAt           time.Time      `json:"created_at"`
 	UpdatedAt           time.Time      `json:"updated_at"`
 }
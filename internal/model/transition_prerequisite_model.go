@@ -0,0 +1,7 @@
+package model
+
+type TransitionPrerequisite struct {
+	TransitionID     int  `json:"transition_id"`
+	RequiredStatusID int  `json:"required_status_id"`
+	IsActive         bool `json:"is_active"`
+}
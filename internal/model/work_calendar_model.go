@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+type WorkDay struct {
+	DayOfWeek int       `json:"day_of_week"`
+	IsWorkDay bool      `json:"is_work_day"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Holiday struct {
+	ID          int       `json:"id"`
+	HolidayDate time.Time `json:"holiday_date"`
+	Name        string    `json:"name"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// WorkCalendar is the in-memory form of work_day and holiday, mirrors the is_working_day SQL function
+type WorkCalendar struct {
+	WorkDays map[time.Weekday]bool
+	Holidays map[string]bool
+}
+
+func (c *WorkCalendar) IsWorkingDay(t time.Time) bool {
+	return c.WorkDays[t.Weekday()] && !c.Holidays[t.Format("2006-01-02")]
+}
+
+// WorkingDaysBetween counts working days in (from, to], negative when to is before from
+func (c *WorkCalendar) WorkingDaysBetween(from, to time.Time) int {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, from.Location())
+
+	sign := 1
+	if end.Before(start) {
+		start, end = end, start
+		sign = -1
+	}
+
+	count := 0
+	for d := start.AddDate(0, 0, 1); !d.After(end); d = d.AddDate(0, 0, 1) {
+		if c.IsWorkingDay(d) {
+			count++
+		}
+	}
+	return sign * count
+}
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type WorkflowVersion struct {
+	ID             int       `json:"id"`
+	WorkflowID     int       `json:"workflow_id"`
+	VersionNumber  int       `json:"version_number"`
+	Note           *string   `json:"note"`
+	PublishedByNPK *string   `json:"published_by_npk"`
+	PublishedAt    time.Time `json:"published_at"`
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+
+	"github.com/lib/pq"
+)
+
+type ActionReasonCodeRepository struct {
+	DB *sql.DB
+}
+
+func NewActionReasonCodeRepository(db *sql.DB) *ActionReasonCodeRepository {
+	return &ActionReasonCodeRepository{DB: db}
+}
+
+const actionReasonCodeColumns = "id, action_id, code, name, is_active, created_at, updated_at"
+
+// HELPER
+func scanActionReasonCode(scanner interface{ Scan(...interface{}) error }) (*model.ActionReasonCode, error) {
+	var rc model.ActionReasonCode
+	err := scanner.Scan(&rc.ID, &rc.ActionID, &rc.Code, &rc.Name, &rc.IsActive, &rc.CreatedAt, &rc.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &rc, nil
+}
+
+// CREATE
+func (r *ActionReasonCodeRepository) Create(req dto.CreateActionReasonCodeRequest) (*model.ActionReasonCode, error) {
+	query := `
+        INSERT INTO action_reason_code (action_id, code, name, is_active)
+        VALUES ($1, $2, $3, false)
+        RETURNING ` + actionReasonCodeColumns
+
+	return scanActionReasonCode(r.DB.QueryRow(query, req.ActionID, req.Code, req.Name))
+}
+
+// GET ALL
+func (r *ActionReasonCodeRepository) FindAll(filters dto.ActionReasonCodeFilter) ([]model.ActionReasonCode, error) {
+	query := "SELECT " + actionReasonCodeColumns + " FROM action_reason_code"
+	var conditions []string
+	var args []interface{}
+	argID := 1
+
+	if filters.ActionID > 0 {
+		conditions = append(conditions, "action_id = $"+strconv.Itoa(argID))
+		args = append(args, filters.ActionID)
+		argID++
+	}
+	if filters.IsActive != nil {
+		conditions = append(conditions, "is_active = $"+strconv.Itoa(argID))
+		args = append(args, *filters.IsActive)
+		argID++
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY action_id ASC, code ASC"
+
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reasonCodes []model.ActionReasonCode
+	for rows.Next() {
+		rc, err := scanActionReasonCode(rows)
+		if err != nil {
+			return nil, err
+		}
+		reasonCodes = append(reasonCodes, *rc)
+	}
+	return reasonCodes, nil
+}
+
+// GET BY ID
+func (r *ActionReasonCodeRepository) FindByID(id int) (*model.ActionReasonCode, error) {
+	query := "SELECT " + actionReasonCodeColumns + " FROM action_reason_code WHERE id = $1"
+	return scanActionReasonCode(r.DB.QueryRow(query, id))
+}
+
+// UPDATE
+func (r *ActionReasonCodeRepository) Update(id int, req dto.UpdateActionReasonCodeRequest) (*model.ActionReasonCode, error) {
+	query := `
+        UPDATE action_reason_code
+        SET action_id = $1, code = $2, name = $3, is_active = $4, updated_at = NOW()
+        WHERE id = $5
+        RETURNING ` + actionReasonCodeColumns
+
+	return scanActionReasonCode(r.DB.QueryRow(query, req.ActionID, req.Code, req.Name, req.IsActive, id))
+}
+
+// CHANGE ACTIVE STATUS
+func (r *ActionReasonCodeRepository) UpdateActiveStatus(id int, isActive bool) error {
+	query := "UPDATE action_reason_code SET is_active = $1, updated_at = NOW() WHERE id = $2"
+	result, err := r.DB.Exec(query, isActive, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DELETE
+func (r *ActionReasonCodeRepository) Delete(id int) error {
+	query := "DELETE FROM action_reason_code WHERE id = $1"
+	result, err := r.DB.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GET ACTIVE BY ACTION IDS
+// Active reason codes grouped by action, actions without codes are left out
+func (r *ActionReasonCodeRepository) FindActiveByActionIDs(ctx context.Context, actionIDs []int) (map[int][]dto.ReasonCodeOption, error) {
+	query := `
+        SELECT action_id, id, code, name
+        FROM action_reason_code
+        WHERE action_id = ANY($1) AND is_active = true
+        ORDER BY action_id ASC, code ASC`
+
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(actionIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reasonCodes := make(map[int][]dto.ReasonCodeOption)
+	for rows.Next() {
+		var actionID int
+		var option dto.ReasonCodeOption
+		if err := rows.Scan(&actionID, &option.ID, &option.Code, &option.Name); err != nil {
+			return nil, err
+		}
+		reasonCodes[actionID] = append(reasonCodes[actionID], option)
+	}
+	return reasonCodes, rows.Err()
+}
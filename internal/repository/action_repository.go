@@ -3,6 +3,7 @@ package repository
 import (
 	"database/sql"
 
+	"e-memo-job-reservation-api/internal/dto"
 	"e-memo-job-reservation-api/internal/model"
 )
 
@@ -14,6 +15,27 @@ func NewActionRepository(db *sql.DB) *ActionRepository {
 	return &ActionRepository{DB: db}
 }
 
+// HELPER
+func scanAction(scanner interface{ Scan(...interface{}) error }) (*model.Action, error) {
+	var a model.Action
+	var hexCode sql.NullString
+	err := scanner.Scan(&a.ID, &a.Name, &a.IsActive, &hexCode, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	a.HexCode = hexCode.String
+	return &a, nil
+}
+
+// CREATE
+func (r *ActionRepository) Create(req dto.CreateActionRequest) (*model.Action, error) {
+	query := `
+        INSERT INTO action (name, hex_code, is_active)
+        VALUES ($1, $2, false)
+        RETURNING id, name, is_active, hex_code, created_at, updated_at`
+	return scanAction(r.DB.QueryRow(query, req.Name, req.HexCode))
+}
+
 func (r *ActionRepository) FindAll() ([]model.Action, error) {
 	query := "SELECT id, name, is_active, hex_code, created_at, updated_at FROM action ORDER BY id ASC"
 	rows, err := r.DB.Query(query)
@@ -33,3 +55,47 @@ func (r *ActionRepository) FindAll() ([]model.Action, error) {
 	}
 	return actions, nil
 }
+
+// GET BY ID
+func (r *ActionRepository) FindByID(id int) (*model.Action, error) {
+	query := "SELECT id, name, is_active, hex_code, created_at, updated_at FROM action WHERE id = $1"
+	return scanAction(r.DB.QueryRow(query, id))
+}
+
+// UPDATE
+func (r *ActionRepository) Update(id int, req dto.UpdateActionRequest) (*model.Action, error) {
+	query := `
+        UPDATE action
+        SET name = $1, hex_code = $2, is_active = $3, updated_at = NOW()
+        WHERE id = $4
+        RETURNING id, name, is_active, hex_code, created_at, updated_at`
+	return scanAction(r.DB.QueryRow(query, req.Name, req.HexCode, req.IsActive, id))
+}
+
+// CHANGE ACTIVE STATUS
+func (r *ActionRepository) UpdateActiveStatus(id int, isActive bool) error {
+	query := "UPDATE action SET is_active = $1, updated_at = NOW() WHERE id = $2"
+	result, err := r.DB.Exec(query, isActive, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DELETE
+func (r *ActionRepository) Delete(id int) error {
+	query := "DELETE FROM action WHERE id = $1"
+	result, err := r.DB.Exec(query, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -4,6 +4,9 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
 )
 
 type ActorRoleMappingRepository struct {
@@ -84,6 +87,133 @@ func (r *ActorRoleMappingRepository) GetRoleIDsForUserContext(positionID int, co
 		}
 		roleIDs = append(roleIDs, roleID)
 	}
-	
+
 	return roleIDs, nil
-}
\ No newline at end of file
+}
+
+const baseActorRoleMappingQuery = `
+    SELECT
+        arm.id,
+        arm.employee_position_id,
+        ep.name as employee_position_name,
+        arm.context,
+        arm.actor_role_id,
+        ar.name as actor_role_name,
+        arm.created_at,
+        arm.updated_at
+    FROM actor_role_mapping arm
+    JOIN employee_position ep ON arm.employee_position_id = ep.id
+    JOIN actor_role ar ON arm.actor_role_id = ar.id`
+
+// HELPER
+func scanActorRoleMappingDetail(scanner interface{ Scan(...interface{}) error }) (*dto.ActorRoleMappingDetailResponse, error) {
+	var m dto.ActorRoleMappingDetailResponse
+	err := scanner.Scan(
+		&m.ID, &m.EmployeePositionID, &m.EmployeePositionName, &m.Context,
+		&m.ActorRoleID, &m.ActorRoleName, &m.CreatedAt, &m.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// CREATE
+func (r *ActorRoleMappingRepository) Create(req dto.ActorRoleMappingRequest) (*model.ActorRoleMapping, error) {
+	query := `
+        INSERT INTO actor_role_mapping (employee_position_id, context, actor_role_id)
+        VALUES ($1, $2, $3)
+        RETURNING id, employee_position_id, context, actor_role_id, created_at, updated_at`
+
+	var m model.ActorRoleMapping
+	err := r.DB.QueryRow(query, req.EmployeePositionID, req.Context, req.ActorRoleID).Scan(
+		&m.ID, &m.EmployeePositionID, &m.Context, &m.ActorRoleID, &m.CreatedAt, &m.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GET ALL
+func (r *ActorRoleMappingRepository) FindAll(filters dto.ActorRoleMappingFilter) ([]dto.ActorRoleMappingDetailResponse, error) {
+	query := baseActorRoleMappingQuery
+	var conditions []string
+	var args []interface{}
+	argID := 1
+
+	if filters.EmployeePositionID > 0 {
+		conditions = append(conditions, fmt.Sprintf("arm.employee_position_id = $%d", argID))
+		args = append(args, filters.EmployeePositionID)
+		argID++
+	}
+	if filters.ActorRoleID > 0 {
+		conditions = append(conditions, fmt.Sprintf("arm.actor_role_id = $%d", argID))
+		args = append(args, filters.ActorRoleID)
+		argID++
+	}
+	if filters.Context != "" {
+		conditions = append(conditions, fmt.Sprintf("arm.context = $%d", argID))
+		args = append(args, filters.Context)
+		argID++
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY arm.employee_position_id ASC, arm.context ASC"
+
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mappings []dto.ActorRoleMappingDetailResponse
+	for rows.Next() {
+		m, err := scanActorRoleMappingDetail(rows)
+		if err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, *m)
+	}
+	return mappings, nil
+}
+
+// GET BY ID
+func (r *ActorRoleMappingRepository) FindByID(id int) (*dto.ActorRoleMappingDetailResponse, error) {
+	query := baseActorRoleMappingQuery + " WHERE arm.id = $1"
+	return scanActorRoleMappingDetail(r.DB.QueryRow(query, id))
+}
+
+// UPDATE
+func (r *ActorRoleMappingRepository) Update(id int, req dto.ActorRoleMappingRequest) (*model.ActorRoleMapping, error) {
+	query := `
+        UPDATE actor_role_mapping
+        SET employee_position_id = $1, context = $2, actor_role_id = $3, updated_at = NOW()
+        WHERE id = $4
+        RETURNING id, employee_position_id, context, actor_role_id, created_at, updated_at`
+
+	var m model.ActorRoleMapping
+	err := r.DB.QueryRow(query, req.EmployeePositionID, req.Context, req.ActorRoleID, id).Scan(
+		&m.ID, &m.EmployeePositionID, &m.Context, &m.ActorRoleID, &m.CreatedAt, &m.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// DELETE
+func (r *ActorRoleMappingRepository) Delete(id int) error {
+	query := "DELETE FROM actor_role_mapping WHERE id = $1"
+	result, err := r.DB.Exec(query, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
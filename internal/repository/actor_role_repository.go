@@ -3,6 +3,9 @@ package repository
 import (
 	"database/sql"
 
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+
 	"github.com/lib/pq"
 )
 
@@ -45,3 +48,86 @@ func (r *ActorRoleRepository) GetRoleIDsByNames(names []string) ([]int, error) {
 
 	return ids, nil
 }
+
+// HELPER
+func scanActorRole(scanner interface{ Scan(...interface{}) error }) (*model.ActorRole, error) {
+	var ar model.ActorRole
+	err := scanner.Scan(&ar.ID, &ar.Name, &ar.IsActive, &ar.CreatedAt, &ar.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &ar, nil
+}
+
+// CREATE
+func (r *ActorRoleRepository) Create(req dto.CreateActorRoleRequest) (*model.ActorRole, error) {
+	query := `
+        INSERT INTO actor_role (name, is_active)
+        VALUES ($1, false)
+        RETURNING id, name, is_active, created_at, updated_at`
+	return scanActorRole(r.DB.QueryRow(query, req.Name))
+}
+
+// GET ALL
+func (r *ActorRoleRepository) FindAll() ([]model.ActorRole, error) {
+	query := "SELECT id, name, is_active, created_at, updated_at FROM actor_role ORDER BY id ASC"
+	rows, err := r.DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []model.ActorRole
+	for rows.Next() {
+		ar, err := scanActorRole(rows)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, *ar)
+	}
+	return roles, nil
+}
+
+// GET BY ID
+func (r *ActorRoleRepository) FindByID(id int) (*model.ActorRole, error) {
+	query := "SELECT id, name, is_active, created_at, updated_at FROM actor_role WHERE id = $1"
+	return scanActorRole(r.DB.QueryRow(query, id))
+}
+
+// UPDATE
+func (r *ActorRoleRepository) Update(id int, req dto.UpdateActorRoleRequest) (*model.ActorRole, error) {
+	query := `
+        UPDATE actor_role
+        SET name = $1, is_active = $2, updated_at = NOW()
+        WHERE id = $3
+        RETURNING id, name, is_active, created_at, updated_at`
+	return scanActorRole(r.DB.QueryRow(query, req.Name, req.IsActive, id))
+}
+
+// CHANGE ACTIVE STATUS
+func (r *ActorRoleRepository) UpdateActiveStatus(id int, isActive bool) error {
+	query := "UPDATE actor_role SET is_active = $1, updated_at = NOW() WHERE id = $2"
+	result, err := r.DB.Exec(query, isActive, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DELETE
+func (r *ActorRoleRepository) Delete(id int) error {
+	query := "DELETE FROM actor_role WHERE id = $1"
+	result, err := r.DB.Exec(query, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
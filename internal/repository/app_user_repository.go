@@ -91,3 +91,22 @@ func (r *AppUserRepository) FindByID(id int) (*model.AppUser, error) {
 	)
 	return &user, err
 }
+
+// GET IDS BY EMPLOYEE NPK
+func (r *AppUserRepository) FindIDsByEmployeeNPK(npk string) ([]int, error) {
+	rows, err := r.DB.Query("SELECT id FROM app_user WHERE employee_npk = $1", npk)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+)
+
+type EditingLockRepository struct {
+	DB *sql.DB
+}
+
+func NewEditingLockRepository(db *sql.DB) *EditingLockRepository {
+	return &EditingLockRepository{DB: db}
+}
+
+const editingLockColumns = "lock_key, entity, context_id, user_id, holder_npk, client_id, acquired_at, heartbeat_at, expires_at"
+
+// HELPER
+func scanEditingLock(scanner interface{ Scan(...interface{}) error }) (*model.EditingLock, error) {
+	var l model.EditingLock
+	var holderNPK, clientID sql.NullString
+	err := scanner.Scan(
+		&l.LockKey, &l.Entity, &l.ContextID, &l.UserID, &holderNPK, &clientID,
+		&l.AcquiredAt, &l.HeartbeatAt, &l.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if holderNPK.Valid {
+		l.HolderNPK = &holderNPK.String
+	}
+	if clientID.Valid {
+		l.ClientID = &clientID.String
+	}
+	return &l, nil
+}
+
+func scanEditingLocks(rows *sql.Rows) ([]model.EditingLock, error) {
+	defer rows.Close()
+	var locks []model.EditingLock
+	for rows.Next() {
+		l, err := scanEditingLock(rows)
+		if err != nil {
+			return nil, err
+		}
+		locks = append(locks, *l)
+	}
+	return locks, rows.Err()
+}
+
+func toNullString(val string) sql.NullString {
+	if val == "" {
+		return sql.NullString{Valid: false}
+	}
+	return sql.NullString{String: val, Valid: true}
+}
+
+// ACQUIRE
+// An expired lock or one already held by the same user is taken over, otherwise sql.ErrNoRows is returned
+func (r *EditingLockRepository) Acquire(ctx context.Context, entity string, contextID int, userID int, holderNPK string, clientID string, ttl time.Duration) (*model.EditingLock, error) {
+	query := `
+        INSERT INTO editing_lock (lock_key, entity, context_id, user_id, holder_npk, client_id, acquired_at, heartbeat_at, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW(), NOW() + make_interval(secs => $7))
+        ON CONFLICT (lock_key) DO UPDATE SET
+            user_id = EXCLUDED.user_id,
+            holder_npk = EXCLUDED.holder_npk,
+            client_id = EXCLUDED.client_id,
+            acquired_at = CASE
+                WHEN editing_lock.user_id = EXCLUDED.user_id AND editing_lock.expires_at > NOW() THEN editing_lock.acquired_at
+                ELSE EXCLUDED.acquired_at
+            END,
+            heartbeat_at = EXCLUDED.heartbeat_at,
+            expires_at = EXCLUDED.expires_at
+        WHERE editing_lock.expires_at <= NOW() OR editing_lock.user_id = EXCLUDED.user_id
+        RETURNING ` + editingLockColumns
+
+	row := r.DB.QueryRowContext(ctx, query,
+		model.EditingLockKey(entity, contextID), entity, contextID, userID,
+		toNullString(holderNPK), toNullString(clientID), ttl.Seconds(),
+	)
+	return scanEditingLock(row)
+}
+
+// HEARTBEAT
+func (r *EditingLockRepository) Heartbeat(ctx context.Context, lockKey string, userID int, ttl time.Duration) (*model.EditingLock, error) {
+	query := `
+        UPDATE editing_lock
+        SET heartbeat_at = NOW(), expires_at = NOW() + make_interval(secs => $3)
+        WHERE lock_key = $1 AND user_id = $2 AND expires_at > NOW()
+        RETURNING ` + editingLockColumns
+
+	return scanEditingLock(r.DB.QueryRowContext(ctx, query, lockKey, userID, ttl.Seconds()))
+}
+
+// RELEASE
+func (r *EditingLockRepository) Release(ctx context.Context, lockKey string, userID int) (*model.EditingLock, error) {
+	query := "DELETE FROM editing_lock WHERE lock_key = $1 AND user_id = $2 RETURNING " + editingLockColumns
+	return scanEditingLock(r.DB.QueryRowContext(ctx, query, lockKey, userID))
+}
+
+// RELEASE BY CLIENT
+// Used when a websocket connection goes away, locks already taken over by another device are kept
+func (r *EditingLockRepository) ReleaseByClient(ctx context.Context, clientID string) ([]model.EditingLock, error) {
+	query := "DELETE FROM editing_lock WHERE client_id = $1 RETURNING " + editingLockColumns
+	rows, err := r.DB.QueryContext(ctx, query, clientID)
+	if err != nil {
+		return nil, err
+	}
+	return scanEditingLocks(rows)
+}
+
+// FORCE RELEASE
+func (r *EditingLockRepository) ForceRelease(ctx context.Context, lockKey string) (*model.EditingLock, error) {
+	query := "DELETE FROM editing_lock WHERE lock_key = $1 RETURNING " + editingLockColumns
+	return scanEditingLock(r.DB.QueryRowContext(ctx, query, lockKey))
+}
+
+// DELETE EXPIRED
+func (r *EditingLockRepository) DeleteExpired(ctx context.Context) ([]model.EditingLock, error) {
+	query := "DELETE FROM editing_lock WHERE expires_at <= NOW() RETURNING " + editingLockColumns
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return scanEditingLocks(rows)
+}
+
+const baseEditingLockQuery = `
+    SELECT
+        l.lock_key, l.entity, l.context_id, l.user_id, l.holder_npk,
+        COALESCE(e.name, au.username, '') AS holder_name,
+        l.client_id, l.acquired_at, l.heartbeat_at, l.expires_at
+    FROM editing_lock l
+    LEFT JOIN app_user au ON l.user_id = au.id
+    LEFT JOIN employee e ON l.holder_npk = e.npk
+    WHERE l.expires_at > NOW()`
+
+func scanEditingLockDetail(scanner interface{ Scan(...interface{}) error }) (*dto.EditingLockResponse, error) {
+	var l dto.EditingLockResponse
+	var holderNPK, clientID sql.NullString
+	err := scanner.Scan(
+		&l.LockKey, &l.Entity, &l.ContextID, &l.UserID, &holderNPK, &l.HolderName,
+		&clientID, &l.AcquiredAt, &l.HeartbeatAt, &l.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if holderNPK.Valid {
+		l.HolderNPK = &holderNPK.String
+	}
+	if clientID.Valid {
+		l.ClientID = &clientID.String
+	}
+	return &l, nil
+}
+
+// GET ACTIVE BY KEY
+func (r *EditingLockRepository) FindActiveByKey(ctx context.Context, lockKey string) (*dto.EditingLockResponse, error) {
+	query := baseEditingLockQuery + " AND l.lock_key = $1"
+	return scanEditingLockDetail(r.DB.QueryRowContext(ctx, query, lockKey))
+}
+
+// GET ACTIVE BY KEY FOR SHARE
+// The lock row stays share-locked until the transaction ends, so it cannot be taken over while the command writes
+func (r *EditingLockRepository) FindActiveByKeyForShare(ctx context.Context, tx *sql.Tx, lockKey string) (*dto.EditingLockResponse, error) {
+	query := baseEditingLockQuery + " AND l.lock_key = $1 FOR SHARE OF l"
+	return scanEditingLockDetail(tx.QueryRowContext(ctx, query, lockKey))
+}
+
+// GET ALL ACTIVE
+func (r *EditingLockRepository) FindAllActive(ctx context.Context, filters dto.EditingLockFilter) ([]dto.EditingLockResponse, error) {
+	query := baseEditingLockQuery
+	var conditions []string
+	var args []interface{}
+	argID := 1
+
+	if filters.Entity != "" {
+		conditions = append(conditions, "l.entity = $"+strconv.Itoa(argID))
+		args = append(args, filters.Entity)
+		argID++
+	}
+	if filters.UserID > 0 {
+		conditions = append(conditions, "l.user_id = $"+strconv.Itoa(argID))
+		args = append(args, filters.UserID)
+		argID++
+	}
+
+	if len(conditions) > 0 {
+		query += " AND " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY l.acquired_at ASC"
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var locks []dto.EditingLockResponse
+	for rows.Next() {
+		l, err := scanEditingLockDetail(rows)
+		if err != nil {
+			return nil, err
+		}
+		locks = append(locks, *l)
+	}
+	return locks, rows.Err()
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"e-memo-job-reservation-api/internal/model"
+)
+
+type EmailQueueRepository struct {
+	DB *sql.DB
+}
+
+func NewEmailQueueRepository(db *sql.DB) *EmailQueueRepository {
+	return &EmailQueueRepository{DB: db}
+}
+
+const emailQueueColumns = "id, recipient_npk, recipient_email, subject, body, event, ticket_id, status, attempts, next_attempt_at, last_error, sent_at, created_at"
+
+// HELPER
+func scanEmailQueue(scanner interface{ Scan(...interface{}) error }) (*model.EmailQueue, error) {
+	var e model.EmailQueue
+	var recipientNPK, lastError sql.NullString
+	var ticketID sql.NullInt64
+	var sentAt sql.NullTime
+	err := scanner.Scan(
+		&e.ID, &recipientNPK, &e.RecipientEmail, &e.Subject, &e.Body, &e.Event, &ticketID,
+		&e.Status, &e.Attempts, &e.NextAttemptAt, &lastError, &sentAt, &e.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if recipientNPK.Valid {
+		e.RecipientNPK = &recipientNPK.String
+	}
+	if ticketID.Valid {
+		id := int(ticketID.Int64)
+		e.TicketID = &id
+	}
+	if lastError.Valid {
+		e.LastError = &lastError.String
+	}
+	if sentAt.Valid {
+		e.SentAt = &sentAt.Time
+	}
+	return &e, nil
+}
+
+// ENQUEUE
+// An outbox event queues at most one email per recipient, so a retried event does not send it twice
+func (r *EmailQueueRepository) Enqueue(ctx context.Context, outboxEventID int64, emails []model.EmailQueue) error {
+	if len(emails) == 0 {
+		return nil
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+        INSERT INTO email_queue (recipient_npk, recipient_email, subject, body, event, ticket_id, outbox_event_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT (outbox_event_id, recipient_email) DO NOTHING`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, email := range emails {
+		var recipientNPK sql.NullString
+		if email.RecipientNPK != nil {
+			recipientNPK = toNullString(*email.RecipientNPK)
+		}
+		_, err := stmt.ExecContext(ctx, recipientNPK, email.RecipientEmail, email.Subject, email.Body, email.Event, toNullInt64(email.TicketID), outboxEventID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// CLAIM DUE
+// Claimed rows are leased by moving next_attempt_at forward, so concurrent workers skip them
+func (r *EmailQueueRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.EmailQueue, error) {
+	query := `
+        UPDATE email_queue SET next_attempt_at = NOW() + make_interval(secs => $2)
+        WHERE id IN (
+            SELECT id FROM email_queue
+            WHERE status = 'PENDING' AND next_attempt_at <= NOW()
+            ORDER BY next_attempt_at ASC
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING ` + emailQueueColumns
+
+	rows, err := r.DB.QueryContext(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var emails []model.EmailQueue
+	for rows.Next() {
+		e, err := scanEmailQueue(rows)
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, *e)
+	}
+	return emails, rows.Err()
+}
+
+// MARK SENT
+func (r *EmailQueueRepository) MarkSent(ctx context.Context, id int64) error {
+	query := "UPDATE email_queue SET status = 'SENT', attempts = attempts + 1, sent_at = NOW(), last_error = NULL WHERE id = $1"
+	_, err := r.DB.ExecContext(ctx, query, id)
+	return err
+}
+
+// MARK RETRY
+func (r *EmailQueueRepository) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error {
+	query := "UPDATE email_queue SET attempts = attempts + 1, next_attempt_at = $2, last_error = $3 WHERE id = $1"
+	_, err := r.DB.ExecContext(ctx, query, id, nextAttemptAt, lastError)
+	return err
+}
+
+// MARK FAILED
+func (r *EmailQueueRepository) MarkFailed(ctx context.Context, id int64, lastError string) error {
+	query := "UPDATE email_queue SET status = 'FAILED', attempts = attempts + 1, last_error = $2 WHERE id = $1"
+	_, err := r.DB.ExecContext(ctx, query, id, lastError)
+	return err
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"e-memo-job-reservation-api/internal/dto"
+)
+
+type EmployeeDelegationRepository struct {
+	DB *sql.DB
+}
+
+func NewEmployeeDelegationRepository(db *sql.DB) *EmployeeDelegationRepository {
+	return &EmployeeDelegationRepository{DB: db}
+}
+
+const baseEmployeeDelegationQuery = `
+    SELECT
+        d.id,
+        d.delegator_npk,
+        dr.name as delegator_name,
+        d.delegate_npk,
+        de.name as delegate_name,
+        d.start_date,
+        d.end_date,
+        d.reason,
+        d.is_active,
+        d.is_active AND CURRENT_DATE BETWEEN d.start_date AND d.end_date as is_current,
+        d.created_at,
+        d.updated_at
+    FROM employee_delegation d
+    JOIN employee dr ON d.delegator_npk = dr.npk
+    JOIN employee de ON d.delegate_npk = de.npk`
+
+// HELPER
+func scanEmployeeDelegation(scanner interface{ Scan(...interface{}) error }) (*dto.EmployeeDelegationResponse, error) {
+	var d dto.EmployeeDelegationResponse
+	var reason sql.NullString
+	err := scanner.Scan(
+		&d.ID, &d.DelegatorNPK, &d.DelegatorName, &d.DelegateNPK, &d.DelegateName,
+		&d.StartDate, &d.EndDate, &reason, &d.IsActive, &d.IsCurrent, &d.CreatedAt, &d.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if reason.Valid {
+		d.Reason = &reason.String
+	}
+	return &d, nil
+}
+
+// CREATE
+func (r *EmployeeDelegationRepository) Create(ctx context.Context, delegatorNPK string, delegateNPK string, startDate time.Time, endDate time.Time, reason *string) (int, error) {
+	query := `
+        INSERT INTO employee_delegation (delegator_npk, delegate_npk, start_date, end_date, reason)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id`
+
+	var id int
+	err := r.DB.QueryRowContext(ctx, query, delegatorNPK, delegateNPK, startDate, endDate, reason).Scan(&id)
+	return id, err
+}
+
+// GET ALL BY NPK
+func (r *EmployeeDelegationRepository) FindByNPK(ctx context.Context, npk string, filters dto.EmployeeDelegationFilter) ([]dto.EmployeeDelegationResponse, error) {
+	query := baseEmployeeDelegationQuery
+	var conditions []string
+	args := []interface{}{npk}
+	argID := 2
+
+	switch filters.Direction {
+	case "given":
+		conditions = append(conditions, "d.delegator_npk = $1")
+	case "received":
+		conditions = append(conditions, "d.delegate_npk = $1")
+	default:
+		conditions = append(conditions, "(d.delegator_npk = $1 OR d.delegate_npk = $1)")
+	}
+	if filters.IsActive != nil {
+		conditions = append(conditions, fmt.Sprintf("d.is_active = $%d", argID))
+		args = append(args, *filters.IsActive)
+		argID++
+	}
+
+	query += " WHERE " + strings.Join(conditions, " AND ")
+	query += " ORDER BY d.start_date DESC, d.id DESC"
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var delegations []dto.EmployeeDelegationResponse
+	for rows.Next() {
+		d, err := scanEmployeeDelegation(rows)
+		if err != nil {
+			return nil, err
+		}
+		delegations = append(delegations, *d)
+	}
+	return delegations, rows.Err()
+}
+
+// GET BY ID
+func (r *EmployeeDelegationRepository) FindByID(ctx context.Context, id int) (*dto.EmployeeDelegationResponse, error) {
+	query := baseEmployeeDelegationQuery + " WHERE d.id = $1"
+	return scanEmployeeDelegation(r.DB.QueryRowContext(ctx, query, id))
+}
+
+// HAS OVERLAP
+// True when the delegator already hands their roles to the same delegate for part of the range
+func (r *EmployeeDelegationRepository) HasOverlap(ctx context.Context, delegatorNPK string, delegateNPK string, startDate time.Time, endDate time.Time) (bool, error) {
+	var exists bool
+	query := `
+        SELECT EXISTS (
+            SELECT 1 FROM employee_delegation
+            WHERE delegator_npk = $1
+              AND delegate_npk = $2
+              AND is_active = true
+              AND start_date <= $4
+              AND end_date >= $3
+        )`
+	err := r.DB.QueryRowContext(ctx, query, delegatorNPK, delegateNPK, startDate, endDate).Scan(&exists)
+	return exists, err
+}
+
+// REVOKE
+func (r *EmployeeDelegationRepository) Revoke(ctx context.Context, id int) error {
+	query := "UPDATE employee_delegation SET is_active = false, updated_at = NOW() WHERE id = $1 AND is_active = true"
+	result, err := r.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GET CURRENT DELEGATORS
+// NPKs of the employees whose roles the delegate holds today
+func (r *EmployeeDelegationRepository) FindCurrentDelegatorNPKs(ctx context.Context, delegateNPK string) ([]string, error) {
+	query := `
+        SELECT DISTINCT delegator_npk FROM employee_delegation
+        WHERE delegate_npk = $1
+          AND is_active = true
+          AND CURRENT_DATE BETWEEN start_date AND end_date
+        ORDER BY delegator_npk`
+
+	rows, err := r.DB.QueryContext(ctx, query, delegateNPK)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var npks []string
+	for rows.Next() {
+		var npk string
+		if err := rows.Scan(&npk); err != nil {
+			return nil, err
+		}
+		npks = append(npks, npk)
+	}
+	return npks, rows.Err()
+}
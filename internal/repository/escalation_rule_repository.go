@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+)
+
+type EscalationRuleRepository struct {
+	DB *sql.DB
+}
+
+func NewEscalationRuleRepository(db *sql.DB) *EscalationRuleRepository {
+	return &EscalationRuleRepository{DB: db}
+}
+
+const escalationRuleColumns = "id, name, status_ticket_id, department_id, after_hours, escalation_type, notify_actor_role_id, action_id, reminder_hours, on_behalf_of_requestor, is_active, created_at, updated_at"
+
+// HELPER
+func scanEscalationRule(scanner interface{ Scan(...interface{}) error }) (*model.EscalationRule, error) {
+	var rule model.EscalationRule
+	var departmentID, notifyActorRoleID, actionID, reminderHours sql.NullInt64
+	err := scanner.Scan(
+		&rule.ID, &rule.Name, &rule.StatusTicketID, &departmentID, &rule.AfterHours, &rule.EscalationType,
+		&notifyActorRoleID, &actionID, &reminderHours, &rule.OnBehalfOfRequestor, &rule.IsActive, &rule.CreatedAt, &rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if departmentID.Valid {
+		id := int(departmentID.Int64)
+		rule.DepartmentID = &id
+	}
+	if notifyActorRoleID.Valid {
+		id := int(notifyActorRoleID.Int64)
+		rule.NotifyActorRoleID = &id
+	}
+	if actionID.Valid {
+		id := int(actionID.Int64)
+		rule.ActionID = &id
+	}
+	if reminderHours.Valid {
+		hours := int(reminderHours.Int64)
+		rule.ReminderHours = &hours
+	}
+	return &rule, nil
+}
+
+// CREATE
+func (r *EscalationRuleRepository) Create(req dto.CreateEscalationRuleRequest) (*model.EscalationRule, error) {
+	query := `
+        INSERT INTO escalation_rule (
+            name, status_ticket_id, department_id, after_hours, escalation_type, notify_actor_role_id, action_id,
+            reminder_hours, on_behalf_of_requestor, is_active
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, false)
+        RETURNING ` + escalationRuleColumns
+
+	row := r.DB.QueryRow(query, req.Name, req.StatusTicketID, toNullInt64(req.DepartmentID), req.AfterHours,
+		req.EscalationType, toNullInt64(req.NotifyActorRoleID), toNullInt64(req.ActionID),
+		toNullInt64(req.ReminderHours), req.OnBehalfOfRequestor)
+	return scanEscalationRule(row)
+}
+
+// GET ALL
+func (r *EscalationRuleRepository) FindAll(filters dto.EscalationRuleFilter) ([]model.EscalationRule, error) {
+	query := "SELECT " + escalationRuleColumns + " FROM escalation_rule"
+	var conditions []string
+	var args []interface{}
+	argID := 1
+
+	if filters.StatusTicketID > 0 {
+		conditions = append(conditions, "status_ticket_id = $"+strconv.Itoa(argID))
+		args = append(args, filters.StatusTicketID)
+		argID++
+	}
+	if filters.DepartmentID > 0 {
+		conditions = append(conditions, "department_id = $"+strconv.Itoa(argID))
+		args = append(args, filters.DepartmentID)
+		argID++
+	}
+	if filters.EscalationType != "" {
+		conditions = append(conditions, "escalation_type = $"+strconv.Itoa(argID))
+		args = append(args, filters.EscalationType)
+		argID++
+	}
+	if filters.IsActive != nil {
+		conditions = append(conditions, "is_active = $"+strconv.Itoa(argID))
+		args = append(args, *filters.IsActive)
+		argID++
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY status_ticket_id ASC, after_hours ASC, id ASC"
+
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []model.EscalationRule
+	for rows.Next() {
+		rule, err := scanEscalationRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+	return rules, nil
+}
+
+// GET BY ID
+func (r *EscalationRuleRepository) FindByID(id int) (*model.EscalationRule, error) {
+	query := "SELECT " + escalationRuleColumns + " FROM escalation_rule WHERE id = $1"
+	return scanEscalationRule(r.DB.QueryRow(query, id))
+}
+
+// UPDATE
+func (r *EscalationRuleRepository) Update(id int, req dto.UpdateEscalationRuleRequest) (*model.EscalationRule, error) {
+	query := `
+        UPDATE escalation_rule
+        SET name = $1, status_ticket_id = $2, department_id = $3, after_hours = $4, escalation_type = $5,
+            notify_actor_role_id = $6, action_id = $7, reminder_hours = $8, on_behalf_of_requestor = $9,
+            is_active = $10, updated_at = NOW()
+        WHERE id = $11
+        RETURNING ` + escalationRuleColumns
+
+	row := r.DB.QueryRow(query, req.Name, req.StatusTicketID, toNullInt64(req.DepartmentID), req.AfterHours,
+		req.EscalationType, toNullInt64(req.NotifyActorRoleID), toNullInt64(req.ActionID),
+		toNullInt64(req.ReminderHours), req.OnBehalfOfRequestor, req.IsActive, id)
+	return scanEscalationRule(row)
+}
+
+// CHANGE ACTIVE STATUS
+func (r *EscalationRuleRepository) UpdateActiveStatus(id int, isActive bool) error {
+	query := "UPDATE escalation_rule SET is_active = $1, updated_at = NOW() WHERE id = $2"
+	result, err := r.DB.Exec(query, isActive, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DELETE
+func (r *EscalationRuleRepository) Delete(id int) error {
+	query := "DELETE FROM escalation_rule WHERE id = $1"
+	result, err := r.DB.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DUE ESCALATIONS
+type DueEscalation struct {
+	RuleID              int
+	RuleName            string
+	EscalationType      string
+	AfterHours          int
+	NotifyActorRoleID   sql.NullInt64
+	ActionID            sql.NullInt64
+	ActionName          sql.NullString
+	OnBehalfOfRequestor bool
+	TrackID             int64
+	TicketID            int
+	RequestorNPK        string
+	StatusID            int
+	StatusName          string
+	WorkflowVersionID   sql.NullInt64
+	DueAt               time.Time
+}
+
+// Open status periods joined with the active rules of their status, rules of the target department and global rules all apply
+const dueEscalationQuery = `
+        SELECT
+            er.id, er.name, er.escalation_type, er.after_hours, er.notify_actor_role_id, er.action_id, a.name,
+            er.on_behalf_of_requestor, tst.id, tst.ticket_id, t.requestor, st.id, st.name, t.workflow_version_id,
+            tst.start_date + make_interval(hours => er.after_hours) as due_at
+        FROM track_status_ticket tst
+        JOIN ticket t ON tst.ticket_id = t.id
+        JOIN status_ticket st ON tst.status_ticket_id = st.id
+        JOIN escalation_rule er ON er.status_ticket_id = tst.status_ticket_id
+            AND er.is_active = true
+            AND (er.department_id = t.department_target_id OR er.department_id IS NULL)
+        LEFT JOIN action a ON er.action_id = a.id
+        LEFT JOIN ticket_escalation te ON te.escalation_rule_id = er.id AND te.track_status_ticket_id = tst.id
+        WHERE tst.finish_date IS NULL`
+
+func (r *EscalationRuleRepository) findDueEscalations(ctx context.Context, conditions string) ([]DueEscalation, error) {
+	query := dueEscalationQuery + conditions + " ORDER BY tst.ticket_id ASC, er.after_hours ASC, er.id ASC"
+
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var escalations []DueEscalation
+	for rows.Next() {
+		var e DueEscalation
+		err := rows.Scan(
+			&e.RuleID, &e.RuleName, &e.EscalationType, &e.AfterHours, &e.NotifyActorRoleID, &e.ActionID, &e.ActionName,
+			&e.OnBehalfOfRequestor, &e.TrackID, &e.TicketID, &e.RequestorNPK, &e.StatusID, &e.StatusName, &e.WorkflowVersionID,
+			&e.DueAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		escalations = append(escalations, e)
+	}
+	return escalations, rows.Err()
+}
+
+// FindDue lists the open status periods that stayed longer than an active rule of their status allows
+// and were not escalated by that rule yet
+func (r *EscalationRuleRepository) FindDue(ctx context.Context) ([]DueEscalation, error) {
+	return r.findDueEscalations(ctx, `
+          AND NOW() >= tst.start_date + make_interval(hours => er.after_hours)
+          AND te.escalated_at IS NULL`)
+}
+
+// FindDueReminders lists the open status periods whose automatic action is less than the rule's reminder hours away
+// and were not reminded of yet
+func (r *EscalationRuleRepository) FindDueReminders(ctx context.Context) ([]DueEscalation, error) {
+	return r.findDueEscalations(ctx, `
+          AND er.reminder_hours IS NOT NULL
+          AND NOW() >= tst.start_date + make_interval(hours => er.after_hours - er.reminder_hours)
+          AND NOW() < tst.start_date + make_interval(hours => er.after_hours)
+          AND te.id IS NULL`)
+}
+
+// RECORD ESCALATION, RETURNS FALSE IF THE PERIOD HAS ENDED OR WAS ALREADY ESCALATED BY THE RULE
+// The status period is locked so an action performed at the same time either finishes first or waits
+func (r *EscalationRuleRepository) RecordEscalation(ctx context.Context, tx *sql.Tx, ruleID int, trackID int64) (bool, error) {
+	query := `
+        INSERT INTO ticket_escalation (escalation_rule_id, track_status_ticket_id, ticket_id, escalated_at)
+        SELECT $1, tst.id, tst.ticket_id, NOW()
+        FROM (
+            SELECT id, ticket_id FROM track_status_ticket
+            WHERE id = $2 AND finish_date IS NULL
+            FOR UPDATE
+        ) tst
+        ON CONFLICT (escalation_rule_id, track_status_ticket_id)
+        DO UPDATE SET escalated_at = NOW() WHERE ticket_escalation.escalated_at IS NULL`
+
+	result, err := tx.ExecContext(ctx, query, ruleID, trackID)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	return rowsAffected > 0, err
+}
+
+// RECORD REMINDER, RETURNS FALSE IF THE PERIOD HAS ENDED OR THE RULE ALREADY REMINDED OF IT
+func (r *EscalationRuleRepository) RecordReminder(ctx context.Context, tx *sql.Tx, ruleID int, trackID int64) (bool, error) {
+	query := `
+        INSERT INTO ticket_escalation (escalation_rule_id, track_status_ticket_id, ticket_id, reminder_sent_at)
+        SELECT $1, tst.id, tst.ticket_id, NOW()
+        FROM (
+            SELECT id, ticket_id FROM track_status_ticket
+            WHERE id = $2 AND finish_date IS NULL
+            FOR UPDATE
+        ) tst
+        ON CONFLICT (escalation_rule_id, track_status_ticket_id) DO NOTHING`
+
+	result, err := tx.ExecContext(ctx, query, ruleID, trackID)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	return rowsAffected > 0, err
+}
@@ -17,6 +17,9 @@ const baseJobQuery = `
         j.id as job_id,
         t.id as ticket_id,
         t.description,
+        j.title,
+        j.status as job_status,
+        j.completed_at,
         j.job_priority,
         t.ticket_priority,
 		j.spending_amount,
@@ -29,9 +32,9 @@ const baseJobQuery = `
         pic_emp.name as pic_name,
         req_emp.name as requestor_name,
         req_dept.name as requestor_department,
-        (NOW()::date - t.created_at::date) as ticket_age_days,
+        working_days_between(t.created_at::date, NOW()::date) as ticket_age_days,
         t.deadline,
-        (t.deadline::date - NOW()::date) as days_remaining
+        working_days_between(NOW()::date, t.deadline::date) as days_remaining
     FROM job j
     JOIN ticket t ON j.ticket_id = t.id
     JOIN department dept ON t.department_target_id = dept.id
@@ -93,6 +96,16 @@ func (r *JobQueryRepository) FindAll(filters dto.JobFilter) ([]dto.JobDetailResp
 		args = append(args, filters.RequestorNPK)
 		argID++
 	}
+	if filters.TicketID != 0 {
+		conditions = append(conditions, fmt.Sprintf("j.ticket_id = $%d", argID))
+		args = append(args, filters.TicketID)
+		argID++
+	}
+	if filters.JobStatus != "" {
+		conditions = append(conditions, fmt.Sprintf("j.status = $%d", argID))
+		args = append(args, filters.JobStatus)
+		argID++
+	}
 	if filters.SearchQuery != "" {
 		searchQuery := strings.ReplaceAll(strings.TrimSpace(filters.SearchQuery), " ", " & ")
 		conditions = append(conditions, fmt.Sprintf("t.description_tsv @@ to_tsquery('simple', $%d)", argID))
@@ -174,7 +187,7 @@ func scanJobDetails(rows *sql.Rows) ([]dto.JobDetailResponse, error) {
 	for rows.Next() {
 		var j dto.JobDetailResponse
 		err := rows.Scan(
-			&j.JobID, &j.TicketID, &j.Description, &j.JobPriority, &j.TicketPriority, &j.SpendingAmount,
+			&j.JobID, &j.TicketID, &j.Description, &j.Title, &j.JobStatus, &j.CompletedAt, &j.JobPriority, &j.TicketPriority, &j.SpendingAmount,
 			&j.Version, &j.AssignedDepartmentID, &j.AssignedDepartmentName,
 			&j.CurrentStatus, &j.CurrentStatusHexCode, &j.CurrentSectionName,
 			&j.PicName, &j.RequestorName, &j.RequestorDepartment,
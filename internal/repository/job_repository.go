@@ -4,7 +4,6 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"log"
 
@@ -28,45 +27,10 @@ func (r *JobRepository) Create(ctx context.Context, tx *sql.Tx, ticketID int, in
 	return err
 }
 
-// CHECK THE JOB ALREADY GET ASSIGN OR NOT
-func (r *JobRepository) IsJobAssigned(ctx context.Context, ticketID int) (bool, error) {
-	var isAssigned bool
-	query := "SELECT (pic_job IS NOT NULL) FROM job WHERE ticket_id = $1"
-
-	err := r.DB.QueryRowContext(ctx, query, ticketID).Scan(&isAssigned)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return false, nil
-		}
-		return false, err
-	}
-	return isAssigned, nil
-}
-
-// GET PIC
-func (r *JobRepository) GetPicByTicketID(ctx context.Context, ticketID int) (string, error) {
-	var picNpk sql.NullString
-	query := "SELECT pic_job FROM job WHERE ticket_id = $1"
-
-	err := r.DB.QueryRowContext(ctx, query, ticketID).Scan(&picNpk)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return "", errors.New("job not found for this ticket")
-		}
-		return "", err
-	}
-
-	if !picNpk.Valid {
-		return "", nil
-	}
-
-	return picNpk.String, nil
-}
-
 // AssignPIC
-func (r *JobRepository) AssignPIC(id int, picNpk string) error {
+func (r *JobRepository) AssignPIC(ctx context.Context, tx *sql.Tx, id int, picNpk string) error {
 	query := "UPDATE job SET pic_job = $1, updated_at = NOW() WHERE id = $2"
-	result, err := r.DB.Exec(query, picNpk, id)
+	result, err := tx.ExecContext(ctx, query, picNpk, id)
 	if err != nil {
 		return err
 	}
@@ -135,24 +99,26 @@ func (r *JobRepository) AddReportFile(id int, filePath string) error {
 	return nil
 }
 
-// GET JOB BY TICKET ID
-func (r *JobRepository) FindByTicketID(ctx context.Context, ticketID int) (*model.Job, error) {
-	query := `
-        SELECT id, ticket_id, pic_job, job_priority, report_file, version, created_at, updated_at 
-        FROM job
-        WHERE ticket_id = $1`
-
-	row := r.DB.QueryRowContext(ctx, query, ticketID)
+const baseJobColumns = `
+        SELECT id, ticket_id, title, pic_job, job_priority, report_file, spending_amount, status,
+               completed_at, completed_by_npk, version, created_at, updated_at
+        FROM job`
 
+func scanJob(scanner interface{ Scan(...interface{}) error }) (*model.Job, error) {
 	var j model.Job
 	var reportFilesJSON []byte
 
-	err := row.Scan(
+	err := scanner.Scan(
 		&j.ID,
 		&j.TicketID,
+		&j.Title,
 		&j.PicJob,
 		&j.JobPriority,
 		&reportFilesJSON,
+		&j.SpendingAmount,
+		&j.Status,
+		&j.CompletedAt,
+		&j.CompletedByNpk,
 		&j.Version,
 		&j.CreatedAt,
 		&j.UpdatedAt,
@@ -163,73 +129,151 @@ func (r *JobRepository) FindByTicketID(ctx context.Context, ticketID int) (*mode
 
 	if len(reportFilesJSON) > 0 {
 		if err := json.Unmarshal(reportFilesJSON, &j.ReportFiles); err != nil {
-			log.Printf("WARNING: Failed to unmarshal report_file for job with ticket_id %d: %v", ticketID, err)
+			log.Printf("WARNING: Failed to unmarshal report_file for job %d: %v", j.ID, err)
 		}
 	}
 
 	return &j, nil
 }
 
-func (r *JobRepository) FindByID(id int) (*model.Job, error) {
-	query := `
-        SELECT id, ticket_id, pic_job, job_priority, report_file, version, created_at, updated_at 
-        FROM job WHERE id = $1`
-
-	row := r.DB.QueryRow(query, id)
+func scanJobs(rows *sql.Rows) ([]model.Job, error) {
+	defer rows.Close()
 
-	var j model.Job
-	err := row.Scan(
-		&j.ID, &j.TicketID, &j.PicJob, &j.JobPriority, &j.ReportFiles, &j.Version, &j.CreatedAt, &j.UpdatedAt,
-	)
-	return &j, err
+	var jobs []model.Job
+	for rows.Next() {
+		j, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *j)
+	}
+	return jobs, rows.Err()
 }
 
-// [GANTI FUNGSI INI]
-func (r *JobRepository) UpdateJobCompletionDetails(ctx context.Context, tx *sql.Tx, ticketID int, filesMetadata []model.FileMetadata, spendingAmount *int64) error {
-	if len(filesMetadata) > 0 {
-		// Convert all file metadata to JSONB array
-		var fileMetadataJSON []interface{}
-		for _, fm := range filesMetadata {
-			fileMetadataJSON = append(fileMetadataJSON, fm)
-		}
+// GET ALL JOBS BY TICKET ID
+func (r *JobRepository) FindAllByTicketID(ctx context.Context, ticketID int) ([]model.Job, error) {
+	query := baseJobColumns + " WHERE ticket_id = $1 ORDER BY id ASC"
 
-		jsonBytes, err := json.Marshal(fileMetadataJSON)
-		if err != nil {
-			return fmt.Errorf("failed to marshal file metadata: %w", err)
-		}
+	rows, err := r.DB.QueryContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	return scanJobs(rows)
+}
 
-		// Replace the entire report_file array instead of appending
-		query := `
-            UPDATE job 
-            SET report_file = $1::jsonb, 
-                updated_at = NOW()
-            WHERE ticket_id = $2`
+// GET OPEN JOBS BY TICKET ID
+// Locks the open jobs so the ticket cannot be finished while one of them is completed concurrently
+func (r *JobRepository) FindOpenByTicketIDForUpdate(ctx context.Context, tx *sql.Tx, ticketID int) ([]model.Job, error) {
+	query := baseJobColumns + " WHERE ticket_id = $1 AND status = 'OPEN' ORDER BY id ASC FOR UPDATE"
 
-		result, err := tx.ExecContext(ctx, query, string(jsonBytes), ticketID)
-		if err != nil {
-			return err
-		}
-		rowsAffected, _ := result.RowsAffected()
-		if rowsAffected == 0 {
-			return sql.ErrNoRows
-		}
+	rows, err := tx.QueryContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, err
 	}
+	return scanJobs(rows)
+}
+
+// GET TICKET STATE
+// The target department of the ticket and whether it already sits in a terminal status
+func (r *JobRepository) GetTicketState(ctx context.Context, ticketID int) (departmentTargetID int, isFinished bool, err error) {
+	query := `
+        SELECT t.department_target_id, COALESCE(st.is_terminal, false)
+        FROM ticket t
+        LEFT JOIN track_status_ticket tst ON tst.ticket_id = t.id AND tst.finish_date IS NULL
+        LEFT JOIN status_ticket st ON tst.status_ticket_id = st.id
+        WHERE t.id = $1
+        LIMIT 1`
+
+	err = r.DB.QueryRowContext(ctx, query, ticketID).Scan(&departmentTargetID, &isFinished)
+	return departmentTargetID, isFinished, err
+}
 
-	if spendingAmount != nil {
-		query := `
-            UPDATE job 
-            SET spending_amount = $1, updated_at = NOW()
-            WHERE ticket_id = $2`
+// ADD JOB
+// Additional jobs of a ticket join the end of their department's job priority list
+func (r *JobRepository) AddJob(ctx context.Context, tx *sql.Tx, ticketID int, title string, picNpk sql.NullString) (int, error) {
+	query := `
+        INSERT INTO job (ticket_id, title, pic_job, job_priority)
+        SELECT t.id, $2, $3, COALESCE((
+            SELECT MAX(oj.job_priority)
+            FROM job oj
+            JOIN ticket ot ON oj.ticket_id = ot.id
+            WHERE ot.department_target_id = t.department_target_id
+        ), 0) + 1
+        FROM ticket t
+        WHERE t.id = $1
+        RETURNING id`
+
+	var id int
+	err := tx.QueryRowContext(ctx, query, ticketID, title, picNpk).Scan(&id)
+	return id, err
+}
 
-		result, err := tx.ExecContext(ctx, query, *spendingAmount, ticketID)
+// COMPLETE JOB
+// Report files replace the previous ones, the spending amount is only written when given
+func (r *JobRepository) Complete(ctx context.Context, tx *sql.Tx, jobID int, filesMetadata []model.FileMetadata, spendingAmount *int64, completedByNpk string) error {
+	var reportFiles interface{}
+	if len(filesMetadata) > 0 {
+		jsonBytes, err := json.Marshal(filesMetadata)
 		if err != nil {
-			return err
-		}
-		rowsAffected, _ := result.RowsAffected()
-		if rowsAffected == 0 {
-			return sql.ErrNoRows
+			return fmt.Errorf("failed to marshal file metadata: %w", err)
 		}
+		reportFiles = string(jsonBytes)
+	}
+
+	query := `
+        UPDATE job
+        SET status = 'DONE',
+            report_file = COALESCE($2::jsonb, report_file),
+            spending_amount = COALESCE($3, spending_amount),
+            completed_at = NOW(),
+            completed_by_npk = $4,
+            updated_at = NOW()
+        WHERE id = $1 AND status = 'OPEN'`
+
+	result, err := tx.ExecContext(ctx, query, jobID, reportFiles, spendingAmount, completedByNpk)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
 	}
+	return nil
+}
+
+// REOPEN JOBS
+func (r *JobRepository) ReopenByTicketID(ctx context.Context, tx *sql.Tx, ticketID int) error {
+	query := `
+        UPDATE job
+        SET status = 'OPEN', completed_at = NULL, completed_by_npk = NULL, updated_at = NOW()
+        WHERE ticket_id = $1 AND status = 'DONE'`
+
+	_, err := tx.ExecContext(ctx, query, ticketID)
+	return err
+}
 
+// DELETE
+// Only an open job can be removed and a ticket always keeps at least one job
+func (r *JobRepository) Delete(ctx context.Context, tx *sql.Tx, id int) error {
+	query := `
+        DELETE FROM job j
+        WHERE j.id = $1
+          AND j.status = 'OPEN'
+          AND EXISTS (SELECT 1 FROM job oj WHERE oj.ticket_id = j.ticket_id AND oj.id <> j.id)`
+
+	result, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
 	return nil
 }
+
+// GET BY ID
+func (r *JobRepository) FindByID(id int) (*model.Job, error) {
+	query := baseJobColumns + " WHERE id = $1"
+	return scanJob(r.DB.QueryRow(query, id))
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+)
+
+type NotificationPreferenceRepository struct {
+	DB *sql.DB
+}
+
+func NewNotificationPreferenceRepository(db *sql.DB) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{DB: db}
+}
+
+const notificationPreferenceColumns = "employee_npk, email, language, email_enabled, created_at, updated_at"
+
+// HELPER
+func scanNotificationPreference(scanner interface{ Scan(...interface{}) error }) (*model.NotificationPreference, error) {
+	var p model.NotificationPreference
+	var email sql.NullString
+	err := scanner.Scan(&p.EmployeeNPK, &email, &p.Language, &p.EmailEnabled, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if email.Valid {
+		p.Email = &email.String
+	}
+	return &p, nil
+}
+
+// GET BY NPK
+func (r *NotificationPreferenceRepository) FindByNPK(ctx context.Context, npk string) (*model.NotificationPreference, error) {
+	query := "SELECT " + notificationPreferenceColumns + " FROM notification_preference WHERE employee_npk = $1"
+	return scanNotificationPreference(r.DB.QueryRowContext(ctx, query, npk))
+}
+
+// UPSERT
+func (r *NotificationPreferenceRepository) Upsert(ctx context.Context, npk string, req dto.UpdateNotificationPreferenceRequest) (*model.NotificationPreference, error) {
+	query := `
+        INSERT INTO notification_preference (employee_npk, email, language, email_enabled)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (employee_npk) DO UPDATE SET
+            email = EXCLUDED.email,
+            language = EXCLUDED.language,
+            email_enabled = EXCLUDED.email_enabled,
+            updated_at = NOW()
+        RETURNING ` + notificationPreferenceColumns
+
+	var email sql.NullString
+	if req.Email != nil {
+		email = toNullString(*req.Email)
+	}
+	return scanNotificationPreference(r.DB.QueryRowContext(ctx, query, npk, email, req.Language, req.EmailEnabled))
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"e-memo-job-reservation-api/internal/dto"
+
+	"github.com/lib/pq"
+)
+
+type NotificationRecipientRepository struct {
+	DB *sql.DB
+}
+
+func NewNotificationRecipientRepository(db *sql.DB) *NotificationRecipientRepository {
+	return &NotificationRecipientRepository{DB: db}
+}
+
+// FIND TICKET RECIPIENTS
+// Returns the requestor, the assigned PIC, the watchers and every employee whose actor-role mapping allows an action
+// from the ticket's current status (requestor dept approvers, target dept, ...), with the reasons in Roles
+func (r *NotificationRecipientRepository) FindTicketRecipients(ctx context.Context, ticketID int) ([]dto.NotificationRecipient, error) {
+	query := `
+        WITH t AS (
+            SELECT
+                t.id, t.requestor, t.department_target_id,
+                req.department_id AS requestor_department_id,
+                j.pic_job,
+                t.workflow_version_id,
+                (SELECT tst.status_ticket_id FROM track_status_ticket tst
+                 WHERE tst.ticket_id = t.id AND tst.finish_date IS NULL LIMIT 1) AS current_status_id
+            FROM ticket t
+            JOIN employee req ON t.requestor = req.npk
+            LEFT JOIN job j ON j.ticket_id = t.id
+            WHERE t.id = $1
+        ),
+        next_actors AS (
+            SELECT arm.employee_position_id, arm.context
+            FROM t
+            JOIN status_transition st ON st.from_status_id = t.current_status_id AND st.is_active = true
+            JOIN actor_role_mapping arm ON arm.actor_role_id = st.actor_role_id
+            WHERE t.workflow_version_id IS NULL
+            UNION
+            SELECT arm.employee_position_id, arm.context
+            FROM t
+            JOIN workflow_version_transition vt
+              ON vt.workflow_version_id = t.workflow_version_id AND vt.from_status_id = t.current_status_id
+            JOIN actor_role_mapping arm ON arm.actor_role_id = vt.actor_role_id
+        ),
+        candidates AS (
+            SELECT t.requestor AS npk, 'REQUESTOR' AS role FROM t
+            UNION ALL
+            SELECT t.pic_job, 'PIC' FROM t WHERE t.pic_job IS NOT NULL
+            UNION ALL
+            SELECT tw.employee_npk, 'WATCHER' FROM ticket_watcher tw WHERE tw.ticket_id = $1
+            UNION ALL
+            SELECT e.npk, 'NEXT_ACTOR'
+            FROM t
+            JOIN next_actors na ON true
+            JOIN employee e ON e.employee_position_id = na.employee_position_id AND e.is_active = true
+            WHERE (na.context = 'SELF' AND e.npk = t.requestor)
+               OR (na.context = 'REQUESTOR_DEPT' AND e.department_id = t.requestor_department_id)
+               OR (na.context = 'TARGET_DEPT' AND e.department_id = t.department_target_id)
+               OR (na.context = 'ASSIGNED' AND e.npk = t.pic_job)
+        )
+        SELECT
+            e.npk, e.name, np.email,
+            COALESCE(np.language, 'id'),
+            COALESCE(np.email_enabled, true),
+            array_agg(DISTINCT c.role ORDER BY c.role)
+        FROM candidates c
+        JOIN employee e ON c.npk = e.npk
+        LEFT JOIN notification_preference np ON np.employee_npk = e.npk
+        GROUP BY e.npk, e.name, np.email, np.language, np.email_enabled
+        ORDER BY e.npk`
+
+	rows, err := r.DB.QueryContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recipients []dto.NotificationRecipient
+	for rows.Next() {
+		var recipient dto.NotificationRecipient
+		var email sql.NullString
+		var roles pq.StringArray
+		if err := rows.Scan(&recipient.NPK, &recipient.Name, &email, &recipient.Language, &recipient.EmailEnabled, &roles); err != nil {
+			return nil, err
+		}
+		if email.Valid {
+			recipient.Email = &email.String
+		}
+		recipient.Roles = roles
+		recipients = append(recipients, recipient)
+	}
+	return recipients, rows.Err()
+}
+
+// FIND ACTOR ROLE HOLDERS
+// Returns the employees who hold one of the actor roles for the ticket, resolved through the mapping contexts
+// the same way the next actors are
+func (r *NotificationRecipientRepository) FindActorRoleHolders(ctx context.Context, ticketID int, actorRoleIDs []int) ([]dto.NotificationRecipient, error) {
+	query := `
+        WITH t AS (
+            SELECT
+                t.id, t.requestor, t.department_target_id,
+                req.department_id AS requestor_department_id,
+                j.pic_job
+            FROM ticket t
+            JOIN employee req ON t.requestor = req.npk
+            LEFT JOIN job j ON j.ticket_id = t.id
+            WHERE t.id = $1
+        )
+        SELECT DISTINCT
+            e.npk, e.name, np.email,
+            COALESCE(np.language, 'id'),
+            COALESCE(np.email_enabled, true)
+        FROM t
+        JOIN actor_role_mapping arm ON arm.actor_role_id = ANY($2)
+        JOIN employee e ON e.employee_position_id = arm.employee_position_id AND e.is_active = true
+        LEFT JOIN notification_preference np ON np.employee_npk = e.npk
+        WHERE (arm.context = 'SELF' AND e.npk = t.requestor)
+           OR (arm.context = 'REQUESTOR_DEPT' AND e.department_id = t.requestor_department_id)
+           OR (arm.context = 'TARGET_DEPT' AND e.department_id = t.department_target_id)
+           OR (arm.context = 'ASSIGNED' AND e.npk = t.pic_job)
+        ORDER BY e.npk`
+
+	rows, err := r.DB.QueryContext(ctx, query, ticketID, pq.Array(actorRoleIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recipients []dto.NotificationRecipient
+	for rows.Next() {
+		var recipient dto.NotificationRecipient
+		var email sql.NullString
+		if err := rows.Scan(&recipient.NPK, &recipient.Name, &email, &recipient.Language, &recipient.EmailEnabled); err != nil {
+			return nil, err
+		}
+		if email.Valid {
+			recipient.Email = &email.String
+		}
+		recipient.Roles = []string{"NEXT_ACTOR"}
+		recipients = append(recipients, recipient)
+	}
+	return recipients, rows.Err()
+}
+
+// FIND EMPLOYEES
+// Returns the given active employees with their preferences, the reason in Roles is the one passed in
+func (r *NotificationRecipientRepository) FindEmployees(ctx context.Context, npks []string, role string) ([]dto.NotificationRecipient, error) {
+	query := `
+        SELECT
+            e.npk, e.name, np.email,
+            COALESCE(np.language, 'id'),
+            COALESCE(np.email_enabled, true)
+        FROM employee e
+        LEFT JOIN notification_preference np ON np.employee_npk = e.npk
+        WHERE e.npk = ANY($1) AND e.is_active = true
+        ORDER BY e.npk`
+
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(npks))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recipients []dto.NotificationRecipient
+	for rows.Next() {
+		var recipient dto.NotificationRecipient
+		var email sql.NullString
+		if err := rows.Scan(&recipient.NPK, &recipient.Name, &email, &recipient.Language, &recipient.EmailEnabled); err != nil {
+			return nil, err
+		}
+		if email.Valid {
+			recipient.Email = &email.String
+		}
+		recipient.Roles = []string{role}
+		recipients = append(recipients, recipient)
+	}
+	return recipients, rows.Err()
+}
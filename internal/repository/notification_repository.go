@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+)
+
+type NotificationRepository struct {
+	DB *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) *NotificationRepository {
+	return &NotificationRepository{DB: db}
+}
+
+// CREATE BATCH
+// An outbox event creates at most one notification per recipient, so a retried event does not repeat it
+func (r *NotificationRepository) CreateBatch(ctx context.Context, outboxEventID int64, notifications []model.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+        INSERT INTO notification (recipient_npk, ticket_id, type, title, message, actor_npk, outbox_event_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT (outbox_event_id, recipient_npk) DO NOTHING`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, n := range notifications {
+		var actorNPK sql.NullString
+		if n.ActorNPK != nil {
+			actorNPK = toNullString(*n.ActorNPK)
+		}
+		_, err := stmt.ExecContext(ctx, n.RecipientNPK, toNullInt64(n.TicketID), n.Type, n.Title, n.Message, actorNPK, outboxEventID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GET ALL BY RECIPIENT
+func (r *NotificationRepository) FindByRecipient(ctx context.Context, npk string, filters dto.NotificationFilter) ([]dto.NotificationResponse, int64, error) {
+	baseQuery := `
+        FROM notification n
+        LEFT JOIN employee a ON n.actor_npk = a.npk
+        WHERE n.recipient_npk = $1`
+	args := []interface{}{npk}
+	argID := 2
+
+	var conditions []string
+	if filters.IsRead != nil {
+		conditions = append(conditions, fmt.Sprintf("n.is_read = $%d", argID))
+		args = append(args, *filters.IsRead)
+		argID++
+	}
+	if len(conditions) > 0 {
+		baseQuery += " AND " + strings.Join(conditions, " AND ")
+	}
+
+	var totalItems int64
+	if err := r.DB.QueryRowContext(ctx, "SELECT COUNT(n.id)"+baseQuery, args...).Scan(&totalItems); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+        SELECT n.id, n.ticket_id, n.type, n.title, n.message, n.actor_npk, a.name,
+               n.is_read, n.read_at, n.created_at` + baseQuery +
+		fmt.Sprintf(" ORDER BY n.created_at DESC, n.id DESC LIMIT $%d OFFSET $%d", argID, argID+1)
+	args = append(args, filters.Limit, (filters.Page-1)*filters.Limit)
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var notifications []dto.NotificationResponse
+	for rows.Next() {
+		var n dto.NotificationResponse
+		var ticketID sql.NullInt64
+		var actorNPK, actorName sql.NullString
+		var readAt sql.NullTime
+		err := rows.Scan(
+			&n.ID, &ticketID, &n.Type, &n.Title, &n.Message, &actorNPK, &actorName,
+			&n.IsRead, &readAt, &n.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		if ticketID.Valid {
+			id := int(ticketID.Int64)
+			n.TicketID = &id
+		}
+		if actorNPK.Valid {
+			n.ActorNPK = &actorNPK.String
+		}
+		if actorName.Valid {
+			n.ActorName = &actorName.String
+		}
+		if readAt.Valid {
+			n.ReadAt = &readAt.Time
+		}
+		notifications = append(notifications, n)
+	}
+
+	return notifications, totalItems, rows.Err()
+}
+
+// COUNT UNREAD
+func (r *NotificationRepository) CountUnread(ctx context.Context, npk string) (int, error) {
+	var count int
+	query := "SELECT COUNT(id) FROM notification WHERE recipient_npk = $1 AND is_read = false"
+	err := r.DB.QueryRowContext(ctx, query, npk).Scan(&count)
+	return count, err
+}
+
+// MARK AS READ
+func (r *NotificationRepository) MarkAsRead(ctx context.Context, id int64, npk string) error {
+	query := `
+        UPDATE notification SET is_read = true, read_at = COALESCE(read_at, NOW())
+        WHERE id = $1 AND recipient_npk = $2`
+	result, err := r.DB.ExecContext(ctx, query, id, npk)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// MARK ALL AS READ
+func (r *NotificationRepository) MarkAllAsRead(ctx context.Context, npk string) (int64, error) {
+	query := "UPDATE notification SET is_read = true, read_at = NOW() WHERE recipient_npk = $1 AND is_read = false"
+	result, err := r.DB.ExecContext(ctx, query, npk)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
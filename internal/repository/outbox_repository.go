@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"e-memo-job-reservation-api/internal/model"
+)
+
+type OutboxRepository struct {
+	DB *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{DB: db}
+}
+
+const outboxEventColumns = "id, aggregate_type, aggregate_id, event, payload, status, attempts, next_attempt_at, last_error, processed_at, created_at"
+
+// HELPER
+func scanOutboxEvent(scanner interface{ Scan(...interface{}) error }) (*model.OutboxEvent, error) {
+	var e model.OutboxEvent
+	var lastError sql.NullString
+	var processedAt sql.NullTime
+	err := scanner.Scan(
+		&e.ID, &e.AggregateType, &e.AggregateID, &e.Event, &e.Payload,
+		&e.Status, &e.Attempts, &e.NextAttemptAt, &lastError, &processedAt, &e.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if lastError.Valid {
+		e.LastError = &lastError.String
+	}
+	if processedAt.Valid {
+		e.ProcessedAt = &processedAt.Time
+	}
+	return &e, nil
+}
+
+// CREATE
+// Must run inside the transaction of the domain change so the event only exists if the change does
+func (r *OutboxRepository) Create(ctx context.Context, tx *sql.Tx, aggregateType string, aggregateID int, event string, payload []byte) error {
+	query := "INSERT INTO outbox_event (aggregate_type, aggregate_id, event, payload) VALUES ($1, $2, $3, $4)"
+	_, err := tx.ExecContext(ctx, query, aggregateType, aggregateID, event, payload)
+	return err
+}
+
+// CLAIM DUE
+// Only the oldest pending event of each aggregate can be claimed, later events wait until it is
+// processed or failed, which keeps the per-ticket order. Claimed rows are leased like the email queue.
+func (r *OutboxRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.OutboxEvent, error) {
+	query := `
+        UPDATE outbox_event SET next_attempt_at = NOW() + make_interval(secs => $2)
+        WHERE id IN (
+            SELECT o.id FROM outbox_event o
+            WHERE o.status = 'PENDING' AND o.next_attempt_at <= NOW()
+            AND NOT EXISTS (
+                SELECT 1 FROM outbox_event prev
+                WHERE prev.aggregate_type = o.aggregate_type
+                AND prev.aggregate_id = o.aggregate_id
+                AND prev.status = 'PENDING'
+                AND prev.id < o.id
+            )
+            ORDER BY o.id ASC
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING ` + outboxEventColumns
+
+	rows, err := r.DB.QueryContext(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []model.OutboxEvent
+	for rows.Next() {
+		e, err := scanOutboxEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *e)
+	}
+	return events, rows.Err()
+}
+
+// MARK PROCESSED
+func (r *OutboxRepository) MarkProcessed(ctx context.Context, id int64) error {
+	query := "UPDATE outbox_event SET status = 'PROCESSED', attempts = attempts + 1, processed_at = NOW(), last_error = NULL WHERE id = $1"
+	_, err := r.DB.ExecContext(ctx, query, id)
+	return err
+}
+
+// MARK RETRY
+func (r *OutboxRepository) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error {
+	query := "UPDATE outbox_event SET attempts = attempts + 1, next_attempt_at = $2, last_error = $3 WHERE id = $1"
+	_, err := r.DB.ExecContext(ctx, query, id, nextAttemptAt, lastError)
+	return err
+}
+
+// MARK FAILED
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int64, lastError string) error {
+	query := "UPDATE outbox_event SET status = 'FAILED', attempts = attempts + 1, last_error = $2 WHERE id = $1"
+	_, err := r.DB.ExecContext(ctx, query, id, lastError)
+	return err
+}
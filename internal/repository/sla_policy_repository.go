@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+)
+
+type SlaPolicyRepository struct {
+	DB *sql.DB
+}
+
+func NewSlaPolicyRepository(db *sql.DB) *SlaPolicyRepository {
+	return &SlaPolicyRepository{DB: db}
+}
+
+const slaPolicyColumns = "id, status_ticket_id, department_id, max_days, warning_percentage, is_active, created_at, updated_at"
+
+// HELPER
+func scanSlaPolicy(scanner interface{ Scan(...interface{}) error }) (*model.SlaPolicy, error) {
+	var p model.SlaPolicy
+	var departmentID sql.NullInt64
+	err := scanner.Scan(
+		&p.ID, &p.StatusTicketID, &departmentID, &p.MaxDays,
+		&p.WarningPercentage, &p.IsActive, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if departmentID.Valid {
+		id := int(departmentID.Int64)
+		p.DepartmentID = &id
+	}
+	return &p, nil
+}
+
+// CREATE
+func (r *SlaPolicyRepository) Create(req dto.CreateSlaPolicyRequest) (*model.SlaPolicy, error) {
+	query := `
+        INSERT INTO sla_policy (status_ticket_id, department_id, max_days, warning_percentage, is_active)
+        VALUES ($1, $2, $3, $4, false)
+        RETURNING ` + slaPolicyColumns
+
+	return scanSlaPolicy(r.DB.QueryRow(query, req.StatusTicketID, toNullInt64(req.DepartmentID), req.MaxDays, req.WarningPercentage))
+}
+
+// GET ALL
+func (r *SlaPolicyRepository) FindAll(filters dto.SlaPolicyFilter) ([]model.SlaPolicy, error) {
+	query := "SELECT " + slaPolicyColumns + " FROM sla_policy"
+	var conditions []string
+	var args []interface{}
+	argID := 1
+
+	if filters.StatusTicketID > 0 {
+		conditions = append(conditions, "status_ticket_id = $"+strconv.Itoa(argID))
+		args = append(args, filters.StatusTicketID)
+		argID++
+	}
+	if filters.DepartmentID > 0 {
+		conditions = append(conditions, "department_id = $"+strconv.Itoa(argID))
+		args = append(args, filters.DepartmentID)
+		argID++
+	}
+	if filters.IsActive != nil {
+		conditions = append(conditions, "is_active = $"+strconv.Itoa(argID))
+		args = append(args, *filters.IsActive)
+		argID++
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY status_ticket_id ASC, department_id ASC NULLS FIRST"
+
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var policies []model.SlaPolicy
+	for rows.Next() {
+		p, err := scanSlaPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, *p)
+	}
+	return policies, nil
+}
+
+// GET BY ID
+func (r *SlaPolicyRepository) FindByID(id int) (*model.SlaPolicy, error) {
+	query := "SELECT " + slaPolicyColumns + " FROM sla_policy WHERE id = $1"
+	return scanSlaPolicy(r.DB.QueryRow(query, id))
+}
+
+// UPDATE
+func (r *SlaPolicyRepository) Update(id int, req dto.UpdateSlaPolicyRequest) (*model.SlaPolicy, error) {
+	query := `
+        UPDATE sla_policy
+        SET status_ticket_id = $1, department_id = $2, max_days = $3, warning_percentage = $4, is_active = $5, updated_at = NOW()
+        WHERE id = $6
+        RETURNING ` + slaPolicyColumns
+
+	return scanSlaPolicy(r.DB.QueryRow(query, req.StatusTicketID, toNullInt64(req.DepartmentID), req.MaxDays, req.WarningPercentage, req.IsActive, id))
+}
+
+// CHANGE ACTIVE STATUS
+func (r *SlaPolicyRepository) UpdateActiveStatus(id int, isActive bool) error {
+	query := "UPDATE sla_policy SET is_active = $1, updated_at = NOW() WHERE id = $2"
+	result, err := r.DB.Exec(query, isActive, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DELETE
+func (r *SlaPolicyRepository) Delete(id int) error {
+	query := "DELETE FROM sla_policy WHERE id = $1"
+	result, err := r.DB.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -42,7 +42,7 @@ func (r *StatusTicketRepository) Create(req dto.CreateStatusTicketRequest) (*mod
 
 // GET ALL
 func (r *StatusTicketRepository) FindAll(filters dto.StatusTicketFilter) ([]model.StatusTicket, error) {
-	baseQuery := "SELECT id, name, sequence, is_active, section_id, hex_color, created_at, updated_at FROM status_ticket"
+	baseQuery := "SELECT id, name, sequence, is_active, section_id, hex_color, is_terminal, created_at, updated_at FROM status_ticket"
 	var conditions []string
 	var args []interface{}
 	argID := 1
@@ -73,7 +73,7 @@ func (r *StatusTicketRepository) FindAll(filters dto.StatusTicketFilter) ([]mode
 	var statuses []model.StatusTicket
 	for rows.Next() {
 		var s model.StatusTicket
-		err := rows.Scan(&s.ID, &s.Name, &s.Sequence, &s.IsActive, &s.SectionID, &s.HexColor, &s.CreatedAt, &s.UpdatedAt)
+		err := rows.Scan(&s.ID, &s.Name, &s.Sequence, &s.IsActive, &s.SectionID, &s.HexColor, &s.IsTerminal, &s.CreatedAt, &s.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -84,11 +84,11 @@ func (r *StatusTicketRepository) FindAll(filters dto.StatusTicketFilter) ([]mode
 
 // GET BY ID
 func (r *StatusTicketRepository) FindByID(id int) (*model.StatusTicket, error) {
-	query := "SELECT id, name, sequence, is_active, created_at, updated_at FROM status_ticket WHERE id = $1"
+	query := "SELECT id, name, sequence, is_active, is_terminal, created_at, updated_at FROM status_ticket WHERE id = $1"
 	row := r.DB.QueryRow(query, id)
 
 	var s model.StatusTicket
-	err := row.Scan(&s.ID, &s.Name, &s.Sequence, &s.IsActive, &s.CreatedAt, &s.UpdatedAt)
+	err := row.Scan(&s.ID, &s.Name, &s.Sequence, &s.IsActive, &s.IsTerminal, &s.CreatedAt, &s.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -123,6 +123,20 @@ func (r *StatusTicketRepository) UpdateActiveStatus(id int, isActive bool) error
 	return nil
 }
 
+// CHANGE TERMINAL FLAG
+func (r *StatusTicketRepository) UpdateTerminalStatus(id int, isTerminal bool) error {
+	query := "UPDATE status_ticket SET is_terminal = $1, updated_at = NOW() WHERE id = $2"
+	result, err := r.DB.Exec(query, isTerminal, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
 // REORDER
 func (r *StatusTicketRepository) Reorder(ctx context.Context, tx *sql.Tx, id int, newSequence int) error {
 	query := "UPDATE status_ticket SET sequence = $1, updated_at = NOW() WHERE id = $2"
@@ -1,8 +1,10 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"e-memo-job-reservation-api/internal/dto"
 	"e-memo-job-reservation-api/internal/model"
@@ -105,6 +107,16 @@ func (r *StatusTransitionRepository) FindPossibleTransitionsWithDetails(fromStat
 }
 
 func (r *StatusTransitionRepository) FindAvailableTransitionsForRoles(fromStatusID int, roleIDs []int) ([]dto.AvailableTicketActionResponse, error) {
+	return r.findTransitionsForRoles(fromStatusID, roleIDs, false)
+}
+
+// Same as FindAvailableTransitionsForRoles but inactive transitions and actions are listed too,
+// used to try out a change before it is activated
+func (r *StatusTransitionRepository) FindTransitionsForRolesIncludingInactive(fromStatusID int, roleIDs []int) ([]dto.AvailableTicketActionResponse, error) {
+	return r.findTransitionsForRoles(fromStatusID, roleIDs, true)
+}
+
+func (r *StatusTransitionRepository) findTransitionsForRoles(fromStatusID int, roleIDs []int, includeInactive bool) ([]dto.AvailableTicketActionResponse, error) {
 	if len(roleIDs) == 0 {
 		return []dto.AvailableTicketActionResponse{}, nil
 	}
@@ -117,15 +129,22 @@ func (r *StatusTransitionRepository) FindAvailableTransitionsForRoles(fromStatus
             a.hex_code,
             st.require_reason,
             st.reason_label,
-            st.require_file
+            st.require_file,
+            tp.required_status_id,
+            rs.name as required_status_name,
+            st.actor_role_id,
+            (SELECT MAX(q.required_approvals) FROM status_transition q
+             WHERE q.from_status_id = st.from_status_id AND q.action_id = st.action_id
+               AND ($3 OR q.is_active = true)) as required_approvals
         FROM status_transition st
         JOIN action a ON st.action_id = a.id
+        LEFT JOIN transition_prerequisite tp ON tp.transition_id = st.id AND tp.is_active = true
+        LEFT JOIN status_ticket rs ON tp.required_status_id = rs.id
         WHERE st.from_status_id = $1
           AND st.actor_role_id = ANY($2)
-          AND st.is_active = true
-          AND a.is_active = true`
+          AND ($3 OR (st.is_active = true AND a.is_active = true))`
 
-	rows, err := r.DB.Query(query, fromStatusID, pq.Array(roleIDs))
+	rows, err := r.DB.Query(query, fromStatusID, pq.Array(roleIDs), includeInactive)
 	if err != nil {
 		return nil, err
 	}
@@ -142,6 +161,10 @@ func (r *StatusTransitionRepository) FindAvailableTransitionsForRoles(fromStatus
 			&a.RequireReason,
 			&a.ReasonLabel,
 			&a.RequireFile,
+			&a.RequiredStatusID,
+			&a.RequiredStatusName,
+			&a.ActorRoleID,
+			&a.RequiredApprovals,
 		); err != nil {
 			return nil, err
 		}
@@ -190,3 +213,258 @@ func (r *StatusTransitionRepository) HasAvailableActionsForRoles(fromStatusID in
 	err := r.DB.QueryRow(query, fromStatusID, pq.Array(actorRoleIDs)).Scan(&exists)
 	return exists, err
 }
+
+// GET ACTION FILE REQUIREMENT
+// Whether the action can be performed from the status by any actor role, and whether one of them needs a file
+func (r *StatusTransitionRepository) FindActionFileRequirement(ctx context.Context, fromStatusID int, actionName string) (bool, bool, error) {
+	query := `
+        SELECT COUNT(*) > 0, COALESCE(bool_or(st.require_file), false)
+        FROM status_transition st
+        JOIN action a ON st.action_id = a.id
+        WHERE st.from_status_id = $1 AND a.name = $2 AND st.is_active = true`
+
+	var exists, requireFile bool
+	err := r.DB.QueryRowContext(ctx, query, fromStatusID, actionName).Scan(&exists, &requireFile)
+	return exists, requireFile, err
+}
+
+// IS ACTION TARGET
+// Whether the action leads into the status
+func (r *StatusTransitionRepository) IsActionTarget(ctx context.Context, toStatusID int, actionName string) (bool, error) {
+	var exists bool
+	query := `
+        SELECT EXISTS (
+            SELECT 1 FROM status_transition st
+            JOIN action a ON st.action_id = a.id
+            WHERE st.to_status_id = $1 AND a.name = $2 AND st.is_active = true
+        )`
+	err := r.DB.QueryRowContext(ctx, query, toStatusID, actionName).Scan(&exists)
+	return exists, err
+}
+
+const baseStatusTransitionQuery = `
+    SELECT
+        st.id,
+        st.from_status_id,
+        fs.name as from_status_name,
+        st.to_status_id,
+        ts.name as to_status_name,
+        st.action_id,
+        a.name as action_name,
+        st.actor_role_id,
+        ar.name as actor_role_name,
+        st.require_reason,
+        st.reason_label,
+        st.require_file,
+        st.required_approvals,
+        st.is_active,
+        st.created_at,
+        st.updated_at
+    FROM status_transition st
+    LEFT JOIN status_ticket fs ON st.from_status_id = fs.id
+    JOIN status_ticket ts ON st.to_status_id = ts.id
+    JOIN action a ON st.action_id = a.id
+    JOIN actor_role ar ON st.actor_role_id = ar.id`
+
+// HELPER
+func scanStatusTransitionDetail(scanner interface{ Scan(...interface{}) error }) (*dto.StatusTransitionDetailResponse, error) {
+	var t dto.StatusTransitionDetailResponse
+	var fromStatusID sql.NullInt32
+	var fromStatusName, reasonLabel sql.NullString
+
+	err := scanner.Scan(
+		&t.ID, &fromStatusID, &fromStatusName, &t.ToStatusID, &t.ToStatusName,
+		&t.ActionID, &t.ActionName, &t.ActorRoleID, &t.ActorRoleName,
+		&t.RequireReason, &reasonLabel, &t.RequireFile, &t.RequiredApprovals, &t.IsActive, &t.CreatedAt, &t.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromStatusID.Valid {
+		id := int(fromStatusID.Int32)
+		t.FromStatusID = &id
+	}
+	if fromStatusName.Valid {
+		t.FromStatusName = &fromStatusName.String
+	}
+	if reasonLabel.Valid {
+		t.ReasonLabel = &reasonLabel.String
+	}
+	return &t, nil
+}
+
+// a quorum left empty means a single approval moves the ticket
+func approvalQuorum(requiredApprovals int) int {
+	if requiredApprovals < 1 {
+		return 1
+	}
+	return requiredApprovals
+}
+
+// CREATE
+func (r *StatusTransitionRepository) Create(req dto.CreateStatusTransitionRequest) (int, error) {
+	query := `
+        INSERT INTO status_transition (from_status_id, to_status_id, action_id, actor_role_id, require_reason, reason_label, require_file, required_approvals, is_active)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, false)
+        RETURNING id`
+
+	var id int
+	err := r.DB.QueryRow(query, toNullInt64(req.FromStatusID), req.ToStatusID, req.ActionID, req.ActorRoleID, req.RequireReason, req.ReasonLabel, req.RequireFile, approvalQuorum(req.RequiredApprovals)).Scan(&id)
+	return id, err
+}
+
+// GET ALL
+func (r *StatusTransitionRepository) FindAll(filters dto.StatusTransitionFilter) ([]dto.StatusTransitionDetailResponse, error) {
+	query := baseStatusTransitionQuery
+	var conditions []string
+	var args []interface{}
+	argID := 1
+
+	if filters.FromStatusID > 0 {
+		conditions = append(conditions, fmt.Sprintf("st.from_status_id = $%d", argID))
+		args = append(args, filters.FromStatusID)
+		argID++
+	}
+	if filters.ToStatusID > 0 {
+		conditions = append(conditions, fmt.Sprintf("st.to_status_id = $%d", argID))
+		args = append(args, filters.ToStatusID)
+		argID++
+	}
+	if filters.ActionID > 0 {
+		conditions = append(conditions, fmt.Sprintf("st.action_id = $%d", argID))
+		args = append(args, filters.ActionID)
+		argID++
+	}
+	if filters.ActorRoleID > 0 {
+		conditions = append(conditions, fmt.Sprintf("st.actor_role_id = $%d", argID))
+		args = append(args, filters.ActorRoleID)
+		argID++
+	}
+	if filters.IsActive != nil {
+		conditions = append(conditions, fmt.Sprintf("st.is_active = $%d", argID))
+		args = append(args, *filters.IsActive)
+		argID++
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY st.from_status_id ASC NULLS FIRST, st.action_id ASC, st.actor_role_id ASC"
+
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transitions []dto.StatusTransitionDetailResponse
+	for rows.Next() {
+		t, err := scanStatusTransitionDetail(rows)
+		if err != nil {
+			return nil, err
+		}
+		transitions = append(transitions, *t)
+	}
+	return transitions, rows.Err()
+}
+
+// GET BY ID
+func (r *StatusTransitionRepository) FindByID(id int) (*dto.StatusTransitionDetailResponse, error) {
+	query := baseStatusTransitionQuery + " WHERE st.id = $1"
+	return scanStatusTransitionDetail(r.DB.QueryRow(query, id))
+}
+
+// UPDATE
+func (r *StatusTransitionRepository) Update(id int, req dto.UpdateStatusTransitionRequest) error {
+	query := `
+        UPDATE status_transition
+        SET from_status_id = $1, to_status_id = $2, action_id = $3, actor_role_id = $4,
+            require_reason = $5, reason_label = $6, require_file = $7, is_active = $8, required_approvals = $9, updated_at = NOW()
+        WHERE id = $10`
+
+	result, err := r.DB.Exec(query, toNullInt64(req.FromStatusID), req.ToStatusID, req.ActionID, req.ActorRoleID, req.RequireReason, req.ReasonLabel, req.RequireFile, req.IsActive, approvalQuorum(req.RequiredApprovals), id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// CHANGE ACTIVE STATUS
+func (r *StatusTransitionRepository) UpdateActiveStatus(id int, isActive bool) error {
+	query := "UPDATE status_transition SET is_active = $1, updated_at = NOW() WHERE id = $2"
+	result, err := r.DB.Exec(query, isActive, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DELETE
+func (r *StatusTransitionRepository) Delete(id int) error {
+	query := "DELETE FROM status_transition WHERE id = $1"
+	result, err := r.DB.Exec(query, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// HAS CONFLICTING TRANSITION
+// True when the action already leads somewhere else from the same status, FindValidTransition refuses that at runtime
+func (r *StatusTransitionRepository) HasConflictingTransition(fromStatusID *int, actionID int, toStatusID int, excludeID int) (bool, error) {
+	var exists bool
+	query := `
+        SELECT EXISTS (
+            SELECT 1 FROM status_transition
+            WHERE from_status_id IS NOT DISTINCT FROM $1
+              AND action_id = $2
+              AND to_status_id <> $3
+              AND id <> $4
+              AND is_active = true
+        )`
+	err := r.DB.QueryRow(query, toNullInt64(fromStatusID), actionID, toStatusID, excludeID).Scan(&exists)
+	return exists, err
+}
+
+// GRAPH
+func (r *StatusTransitionRepository) InsertGraphTransition(ctx context.Context, tx *sql.Tx, t dto.WorkflowGraphTransition) error {
+	query := `
+        INSERT INTO status_transition (from_status_id, to_status_id, action_id, actor_role_id, require_reason, reason_label, require_file, is_active, required_approvals)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := tx.ExecContext(ctx, query, toNullInt64(t.FromStatusID), t.ToStatusID, t.ActionID, t.ActorRoleID, t.RequireReason, t.ReasonLabel, t.RequireFile, t.IsActive, approvalQuorum(t.RequiredApprovals))
+	return err
+}
+
+func (r *StatusTransitionRepository) UpdateGraphTransition(ctx context.Context, tx *sql.Tx, id int, t dto.WorkflowGraphTransition) error {
+	query := `
+        UPDATE status_transition
+        SET to_status_id = $1, require_reason = $2, reason_label = $3, require_file = $4, is_active = $5, required_approvals = $6, updated_at = NOW()
+        WHERE id = $7`
+	_, err := tx.ExecContext(ctx, query, t.ToStatusID, t.RequireReason, t.ReasonLabel, t.RequireFile, t.IsActive, approvalQuorum(t.RequiredApprovals), id)
+	return err
+}
+
+// Prerequisites belong to their transition, so they are removed together with it
+func (r *StatusTransitionRepository) DeleteGraphTransitions(ctx context.Context, tx *sql.Tx, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM transition_prerequisite WHERE transition_id = ANY($1)", pq.Array(ids)); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, "DELETE FROM status_transition WHERE id = ANY($1)", pq.Array(ids))
+	return err
+}
@@ -3,9 +3,12 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"e-memo-job-reservation-api/internal/dto"
 	"e-memo-job-reservation-api/internal/model"
+
+	"github.com/lib/pq"
 )
 
 type TicketActionLogRepository struct {
@@ -20,21 +23,128 @@ func (r *TicketActionLogRepository) Create(ctx context.Context, tx *sql.Tx, logE
 	query := `
         INSERT INTO ticket_action_log (
             ticket_id, action_id, performed_by_npk, details_text, 
-            file_path, from_status_id, to_status_id
-        ) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+            file_path, from_status_id, to_status_id, approval_actor_role_id, is_partial_approval, on_behalf_of_npk,
+            system_actor, reason_code_id
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
 
+	actionID := sql.NullInt32{Int32: int32(logEntry.ActionID), Valid: logEntry.ActionID > 0}
 	_, err := tx.ExecContext(ctx, query,
 		logEntry.TicketID,
-		logEntry.ActionID,
-		logEntry.PerformedByNpk,
+		actionID,
+		toNullString(logEntry.PerformedByNpk),
 		logEntry.DetailsText,
 		logEntry.FilePath,
 		logEntry.FromStatusID,
 		logEntry.ToStatusID,
+		logEntry.ApprovalActorRoleID,
+		logEntry.IsPartialApproval,
+		logEntry.OnBehalfOfNpk,
+		logEntry.SystemActor,
+		logEntry.ReasonCodeID,
 	)
 	return err
 }
 
+// APPROVALS
+type TicketApproval struct {
+	ActorRoleID    int
+	PerformedByNPK string
+	OnBehalfOfNPK  sql.NullString
+}
+
+// FindPendingApprovals returns the partial approvals of the action given since the ticket entered its current status,
+// approvals from an earlier visit of the same status do not count
+func (r *TicketActionLogRepository) FindPendingApprovals(ctx context.Context, tx *sql.Tx, ticketID int, fromStatusID int, actionID int) ([]TicketApproval, error) {
+	query := `
+        SELECT tal.approval_actor_role_id, tal.performed_by_npk, tal.on_behalf_of_npk
+        FROM ticket_action_log tal
+        JOIN track_status_ticket tst ON tst.ticket_id = tal.ticket_id AND tst.finish_date IS NULL
+        WHERE tal.ticket_id = $1
+          AND tal.from_status_id = $2
+          AND tal.action_id = $3
+          AND tal.is_partial_approval = true
+          AND tal.performed_at >= tst.start_date
+        ORDER BY tal.performed_at ASC`
+
+	rows, err := tx.QueryContext(ctx, query, ticketID, fromStatusID, actionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var approvals []TicketApproval
+	for rows.Next() {
+		var a TicketApproval
+		if err := rows.Scan(&a.ActorRoleID, &a.PerformedByNPK, &a.OnBehalfOfNPK); err != nil {
+			return nil, err
+		}
+		approvals = append(approvals, a)
+	}
+	return approvals, rows.Err()
+}
+
+// FindApprovalProgressByTicketID lists the partial approvals collected in the current status together with
+// the quorum of their action, read from the workflow version the ticket is pinned to or the live transitions
+func (r *TicketActionLogRepository) FindApprovalProgressByTicketID(ctx context.Context, ticketID int) ([]dto.TicketApprovalProgressResponse, error) {
+	query := `
+        SELECT
+            a.name as action_name,
+            COALESCE(
+                (SELECT MAX(vt.required_approvals) FROM workflow_version_transition vt
+                 WHERE vt.workflow_version_id = t.workflow_version_id
+                   AND vt.from_status_id = tal.from_status_id AND vt.action_id = tal.action_id),
+                (SELECT MAX(st.required_approvals) FROM status_transition st
+                 WHERE t.workflow_version_id IS NULL AND st.is_active = true
+                   AND st.from_status_id = tal.from_status_id AND st.action_id = tal.action_id),
+                1
+            ) as required_approvals,
+            ar.name as actor_role_name,
+            tal.performed_by_npk,
+            e.name as performed_by_name,
+            tal.on_behalf_of_npk,
+            ob.name as on_behalf_of_name,
+            tal.performed_at
+        FROM ticket_action_log tal
+        JOIN ticket t ON tal.ticket_id = t.id
+        JOIN track_status_ticket tst ON tst.ticket_id = tal.ticket_id AND tst.finish_date IS NULL
+        JOIN action a ON tal.action_id = a.id
+        JOIN actor_role ar ON tal.approval_actor_role_id = ar.id
+        LEFT JOIN employee e ON tal.performed_by_npk = e.npk
+        LEFT JOIN employee ob ON tal.on_behalf_of_npk = ob.npk
+        WHERE tal.ticket_id = $1
+          AND tal.from_status_id = tst.status_ticket_id
+          AND tal.is_partial_approval = true
+          AND tal.performed_at >= tst.start_date
+        ORDER BY a.name ASC, tal.performed_at ASC`
+
+	rows, err := r.DB.QueryContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var progress []dto.TicketApprovalProgressResponse
+	for rows.Next() {
+		var actionName string
+		var requiredApprovals int
+		var approval dto.TicketApprovalResponse
+		if err := rows.Scan(&actionName, &requiredApprovals, &approval.ActorRoleName, &approval.ApprovedByNPK, &approval.ApprovedByName, &approval.OnBehalfOfNPK, &approval.OnBehalfOfName, &approval.ApprovedAt); err != nil {
+			return nil, err
+		}
+
+		if len(progress) == 0 || progress[len(progress)-1].ActionName != actionName {
+			progress = append(progress, dto.TicketApprovalProgressResponse{
+				ActionName:        actionName,
+				RequiredApprovals: requiredApprovals,
+			})
+		}
+		current := &progress[len(progress)-1]
+		current.Approvals = append(current.Approvals, approval)
+		current.CollectedApprovals = len(current.Approvals)
+	}
+	return progress, rows.Err()
+}
+
 func (r *TicketActionLogRepository) FindLastRejectionByTicketID(ctx context.Context, ticketID int) (*dto.RejectionDetailResponse, error) {
 	query := `
         SELECT 
@@ -70,3 +180,163 @@ func (r *TicketActionLogRepository) FindLastRejectionByTicketID(ctx context.Cont
 	}
 	return &rejectionDetail, nil
 }
+
+// GET TIMELINE
+// every track_status_ticket row is paired with the action log written in the same transaction,
+// both share the transaction NOW() so the start date and performed_at are identical.
+// the initial status has no action log, so its actor falls back to the requestor.
+// action logs that leave the ticket in its status (escalation notices, partial approvals) have no track row,
+// they show up as entries that start and finish at once
+func (r *TicketActionLogRepository) FindTimelineByTicketID(ctx context.Context, ticketID int) ([]dto.TicketTimelineEntryResponse, error) {
+	query := `
+        SELECT
+            action_name, action_hex_code, performed_by_npk, performed_by_name, performed_by_position,
+            on_behalf_of_npk, on_behalf_of_name, system_actor, reason_code, reason_code_name,
+            from_status_id, from_status_name, from_status_hex_color,
+            to_status_id, to_status_name, to_status_hex_color,
+            details_text, file_path, start_date, finish_date, is_partial_approval
+        FROM (
+            SELECT
+                a.name as action_name,
+                a.hex_code as action_hex_code,
+                COALESCE(tal.performed_by_npk, CASE WHEN tst.start_date = t.created_at THEN t.requestor END) as performed_by_npk,
+                e.name as performed_by_name,
+                ep.name as performed_by_position,
+                tal.on_behalf_of_npk,
+                ob.name as on_behalf_of_name,
+                tal.system_actor,
+                rc.code as reason_code,
+                rc.name as reason_code_name,
+                tal.from_status_id,
+                fs.name as from_status_name,
+                fs.hex_color as from_status_hex_color,
+                tst.status_ticket_id as to_status_id,
+                ts.name as to_status_name,
+                ts.hex_color as to_status_hex_color,
+                tal.details_text,
+                tal.file_path,
+                tst.start_date,
+                tst.finish_date,
+                false as is_partial_approval,
+                tst.id as sort_id
+            FROM track_status_ticket tst
+            JOIN ticket t ON tst.ticket_id = t.id
+            JOIN status_ticket ts ON tst.status_ticket_id = ts.id
+            LEFT JOIN ticket_action_log tal ON tal.ticket_id = tst.ticket_id
+                AND tal.to_status_id = tst.status_ticket_id
+                AND tal.performed_at = tst.start_date
+            LEFT JOIN action a ON tal.action_id = a.id
+            LEFT JOIN employee e ON e.npk = COALESCE(tal.performed_by_npk, CASE WHEN tst.start_date = t.created_at THEN t.requestor END)
+            LEFT JOIN employee_position ep ON e.employee_position_id = ep.id
+            LEFT JOIN employee ob ON tal.on_behalf_of_npk = ob.npk
+            LEFT JOIN action_reason_code rc ON tal.reason_code_id = rc.id
+            LEFT JOIN status_ticket fs ON tal.from_status_id = fs.id
+            WHERE tst.ticket_id = $1
+
+            UNION ALL
+
+            SELECT
+                a.name,
+                a.hex_code,
+                tal.performed_by_npk,
+                e.name,
+                ep.name,
+                tal.on_behalf_of_npk,
+                ob.name,
+                tal.system_actor,
+                rc.code,
+                rc.name,
+                tal.from_status_id,
+                fs.name,
+                fs.hex_color,
+                tal.to_status_id,
+                ts.name,
+                ts.hex_color,
+                tal.details_text,
+                tal.file_path,
+                tal.performed_at,
+                tal.performed_at,
+                tal.is_partial_approval,
+                NULL
+            FROM ticket_action_log tal
+            JOIN status_ticket ts ON tal.to_status_id = ts.id
+            LEFT JOIN action a ON tal.action_id = a.id
+            LEFT JOIN employee e ON tal.performed_by_npk = e.npk
+            LEFT JOIN employee_position ep ON e.employee_position_id = ep.id
+            LEFT JOIN employee ob ON tal.on_behalf_of_npk = ob.npk
+            LEFT JOIN action_reason_code rc ON tal.reason_code_id = rc.id
+            LEFT JOIN status_ticket fs ON tal.from_status_id = fs.id
+            WHERE tal.ticket_id = $1
+              AND NOT EXISTS (
+                  SELECT 1 FROM track_status_ticket tst
+                  WHERE tst.ticket_id = tal.ticket_id
+                    AND tst.status_ticket_id = tal.to_status_id
+                    AND tst.start_date = tal.performed_at
+              )
+        ) timeline
+        ORDER BY start_date ASC, sort_id ASC NULLS LAST`
+
+	rows, err := r.DB.QueryContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	var timeline []dto.TicketTimelineEntryResponse
+	for rows.Next() {
+		var entry dto.TicketTimelineEntryResponse
+		var fromStatusID sql.NullInt32
+		var filePaths pq.StringArray
+		var finishDate sql.NullTime
+
+		err := rows.Scan(
+			&entry.ActionName,
+			&entry.ActionHexCode,
+			&entry.PerformedByNPK,
+			&entry.PerformedByName,
+			&entry.PerformedByPosition,
+			&entry.OnBehalfOfNPK,
+			&entry.OnBehalfOfName,
+			&entry.SystemActor,
+			&entry.ReasonCode,
+			&entry.ReasonCodeName,
+			&fromStatusID,
+			&entry.FromStatusName,
+			&entry.FromStatusHexColor,
+			&entry.ToStatusID,
+			&entry.ToStatusName,
+			&entry.ToStatusHexColor,
+			&entry.Reason,
+			&filePaths,
+			&entry.StartedAt,
+			&finishDate,
+			&entry.IsPartialApproval,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if fromStatusID.Valid {
+			id := int(fromStatusID.Int32)
+			entry.FromStatusID = &id
+		}
+
+		entry.FilePaths = []string(filePaths)
+		if entry.FilePaths == nil {
+			entry.FilePaths = []string{}
+		}
+
+		endTime := now
+		if finishDate.Valid {
+			entry.FinishedAt = &finishDate.Time
+			endTime = finishDate.Time
+		} else {
+			entry.IsCurrent = true
+		}
+		entry.TimeSpentSeconds = int64(endTime.Sub(entry.StartedAt).Seconds())
+
+		timeline = append(timeline, entry)
+	}
+	return timeline, rows.Err()
+}
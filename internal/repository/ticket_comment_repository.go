@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+
+	"github.com/lib/pq"
+)
+
+type TicketCommentRepository struct {
+	DB *sql.DB
+}
+
+func NewTicketCommentRepository(db *sql.DB) *TicketCommentRepository {
+	return &TicketCommentRepository{DB: db}
+}
+
+const baseTicketCommentQuery = `
+    SELECT
+        c.id,
+        c.ticket_id,
+        c.parent_id,
+        c.author_npk,
+        e.name as author_name,
+        c.body,
+        c.attachments,
+        c.mentioned_npks,
+        c.edited_at,
+        c.deleted_at,
+        c.created_at,
+        c.updated_at
+    FROM ticket_comment c
+    JOIN employee e ON c.author_npk = e.npk`
+
+// HELPER
+func scanTicketComment(scanner interface{ Scan(...interface{}) error }) (*model.TicketComment, error) {
+	var c model.TicketComment
+	var attachmentsJSON []byte
+	err := scanner.Scan(
+		&c.ID, &c.TicketID, &c.ParentID, &c.AuthorNPK, &c.AuthorName, &c.Body, &attachmentsJSON,
+		&c.MentionedNPKs, &c.EditedAt, &c.DeletedAt, &c.CreatedAt, &c.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(attachmentsJSON) > 0 {
+		if err := json.Unmarshal(attachmentsJSON, &c.Attachments); err != nil {
+			log.Printf("WARNING: Failed to unmarshal attachments for ticket comment %d: %v", c.ID, err)
+		}
+	}
+	return &c, nil
+}
+
+// CREATE
+func (r *TicketCommentRepository) Create(ctx context.Context, tx *sql.Tx, comment model.TicketComment) (*model.TicketComment, error) {
+	attachmentsJSON, err := json.Marshal(comment.Attachments)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Attachments == nil {
+		attachmentsJSON = []byte("[]")
+	}
+	if comment.MentionedNPKs == nil {
+		comment.MentionedNPKs = pq.StringArray{}
+	}
+
+	query := `
+        INSERT INTO ticket_comment (ticket_id, parent_id, author_npk, body, attachments, mentioned_npks)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id, created_at, updated_at`
+
+	err = tx.QueryRowContext(ctx, query,
+		comment.TicketID, comment.ParentID, comment.AuthorNPK, comment.Body, attachmentsJSON, comment.MentionedNPKs,
+	).Scan(&comment.ID, &comment.CreatedAt, &comment.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// GET ALL BY TICKET ID
+func (r *TicketCommentRepository) FindByTicketID(ctx context.Context, ticketID int) ([]model.TicketComment, error) {
+	query := baseTicketCommentQuery + " WHERE c.ticket_id = $1 ORDER BY c.created_at ASC, c.id ASC"
+
+	rows, err := r.DB.QueryContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []model.TicketComment
+	for rows.Next() {
+		c, err := scanTicketComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, *c)
+	}
+	return comments, rows.Err()
+}
+
+// GET BY ID
+func (r *TicketCommentRepository) FindByID(ctx context.Context, id int64) (*model.TicketComment, error) {
+	query := baseTicketCommentQuery + " WHERE c.id = $1"
+	return scanTicketComment(r.DB.QueryRowContext(ctx, query, id))
+}
+
+// UPDATE
+func (r *TicketCommentRepository) Update(ctx context.Context, tx *sql.Tx, id int64, body string, mentionedNPKs []string) (time.Time, error) {
+	query := `
+        UPDATE ticket_comment
+        SET body = $2, mentioned_npks = $3, edited_at = NOW(), updated_at = NOW()
+        WHERE id = $1 AND deleted_at IS NULL
+        RETURNING edited_at`
+
+	if mentionedNPKs == nil {
+		mentionedNPKs = []string{}
+	}
+	var editedAt time.Time
+	err := tx.QueryRowContext(ctx, query, id, body, pq.Array(mentionedNPKs)).Scan(&editedAt)
+	return editedAt, err
+}
+
+// SOFT DELETE
+// The row stays so the replies keep their place in the thread
+func (r *TicketCommentRepository) SoftDelete(ctx context.Context, tx *sql.Tx, id int64) (time.Time, error) {
+	query := "UPDATE ticket_comment SET deleted_at = NOW(), updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL RETURNING deleted_at"
+
+	var deletedAt time.Time
+	err := tx.QueryRowContext(ctx, query, id).Scan(&deletedAt)
+	return deletedAt, err
+}
+
+// CREATE HISTORY
+func (r *TicketCommentRepository) CreateHistory(ctx context.Context, tx *sql.Tx, commentID int64, body string, changeType string, changedByNPK string) error {
+	query := `
+        INSERT INTO ticket_comment_history (comment_id, body, change_type, changed_by_npk)
+        VALUES ($1, $2, $3, $4)`
+	_, err := tx.ExecContext(ctx, query, commentID, body, changeType, changedByNPK)
+	return err
+}
+
+// GET HISTORY
+func (r *TicketCommentRepository) FindHistory(ctx context.Context, commentID int64) ([]dto.TicketCommentHistoryResponse, error) {
+	query := `
+        SELECT h.id, h.body, h.change_type, h.changed_by_npk, e.name, h.changed_at
+        FROM ticket_comment_history h
+        JOIN employee e ON h.changed_by_npk = e.npk
+        WHERE h.comment_id = $1
+        ORDER BY h.changed_at DESC, h.id DESC`
+
+	rows, err := r.DB.QueryContext(ctx, query, commentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []dto.TicketCommentHistoryResponse
+	for rows.Next() {
+		var h dto.TicketCommentHistoryResponse
+		if err := rows.Scan(&h.ID, &h.Body, &h.ChangeType, &h.ChangedByNPK, &h.ChangedByName, &h.ChangedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, h)
+	}
+	return history, rows.Err()
+}
+
+// FIND ACTIVE NPKS
+// Keeps the NPKs of active employees, used to resolve the mentions of a comment
+func (r *TicketCommentRepository) FindActiveNPKs(ctx context.Context, npks []string) ([]string, error) {
+	query := "SELECT npk FROM employee WHERE npk = ANY($1) AND is_active = true ORDER BY npk"
+
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(npks))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var found []string
+	for rows.Next() {
+		var npk string
+		if err := rows.Scan(&npk); err != nil {
+			return nil, err
+		}
+		found = append(found, npk)
+	}
+	return found, rows.Err()
+}
+
+// IS MENTIONED
+func (r *TicketCommentRepository) IsMentioned(ctx context.Context, ticketID int, npk string) (bool, error) {
+	var exists bool
+	query := "SELECT EXISTS (SELECT 1 FROM ticket_comment WHERE ticket_id = $1 AND $2 = ANY(mentioned_npks) AND deleted_at IS NULL)"
+	err := r.DB.QueryRowContext(ctx, query, ticketID, npk).Scan(&exists)
+	return exists, err
+}
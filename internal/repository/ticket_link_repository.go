@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+)
+
+type TicketLinkRepository struct {
+	DB *sql.DB
+}
+
+func NewTicketLinkRepository(db *sql.DB) *TicketLinkRepository {
+	return &TicketLinkRepository{DB: db}
+}
+
+// CREATE
+func (r *TicketLinkRepository) Create(ctx context.Context, tx *sql.Tx, ticketID int, linkedTicketID int, linkType string, createdByNPK string) (int, error) {
+	query := `
+        INSERT INTO ticket_link (ticket_id, linked_ticket_id, link_type, created_by_npk)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id`
+
+	var id int
+	err := tx.QueryRowContext(ctx, query, ticketID, linkedTicketID, linkType, createdByNPK).Scan(&id)
+	return id, err
+}
+
+// GET BY ID
+func (r *TicketLinkRepository) FindByID(ctx context.Context, id int) (*model.TicketLink, error) {
+	query := "SELECT id, ticket_id, linked_ticket_id, link_type, created_by_npk, created_at FROM ticket_link WHERE id = $1"
+
+	var l model.TicketLink
+	err := r.DB.QueryRowContext(ctx, query, id).Scan(&l.ID, &l.TicketID, &l.LinkedTicketID, &l.LinkType, &l.CreatedByNPK, &l.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// GET ALL BY TICKET ID
+// Links stored from the other ticket are returned with their inverse type
+func (r *TicketLinkRepository) FindByTicketID(ctx context.Context, ticketID int) ([]dto.TicketLinkResponse, error) {
+	query := `
+        SELECT
+            l.id,
+            CASE
+                WHEN l.ticket_id = $1 THEN l.link_type
+                WHEN l.link_type = 'DUPLICATE_OF' THEN 'DUPLICATED_BY'
+                WHEN l.link_type = 'BLOCKS' THEN 'BLOCKED_BY'
+                WHEN l.link_type = 'PARENT_OF' THEN 'CHILD_OF'
+                ELSE l.link_type
+            END as link_type,
+            other.id,
+            other.description,
+            st.name,
+            COALESCE(st.is_terminal, false),
+            l.created_by_npk,
+            l.created_at
+        FROM ticket_link l
+        JOIN ticket other ON other.id = CASE WHEN l.ticket_id = $1 THEN l.linked_ticket_id ELSE l.ticket_id END
+        LEFT JOIN track_status_ticket tst ON tst.ticket_id = other.id AND tst.finish_date IS NULL
+        LEFT JOIN status_ticket st ON tst.status_ticket_id = st.id
+        WHERE l.ticket_id = $1 OR l.linked_ticket_id = $1
+        ORDER BY l.created_at ASC, l.id ASC`
+
+	rows, err := r.DB.QueryContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []dto.TicketLinkResponse
+	for rows.Next() {
+		var l dto.TicketLinkResponse
+		err := rows.Scan(
+			&l.ID, &l.LinkType, &l.LinkedTicketID, &l.LinkedTicketDesc,
+			&l.LinkedTicketStatus, &l.IsLinkedTicketFinished, &l.CreatedByNPK, &l.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, l)
+	}
+	return links, rows.Err()
+}
+
+// GET OPEN BLOCKERS
+// IDs of the tickets blocking the ticket that have not reached a terminal status yet
+func (r *TicketLinkRepository) FindOpenBlockerIDs(ctx context.Context, ticketID int) ([]int, error) {
+	query := `
+        SELECT l.ticket_id
+        FROM ticket_link l
+        LEFT JOIN track_status_ticket tst ON tst.ticket_id = l.ticket_id AND tst.finish_date IS NULL
+        LEFT JOIN status_ticket st ON tst.status_ticket_id = st.id
+        WHERE l.linked_ticket_id = $1
+          AND l.link_type = 'BLOCKS'
+          AND COALESCE(st.is_terminal, false) = false
+        ORDER BY l.ticket_id`
+
+	rows, err := r.DB.QueryContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// IS DUPLICATE
+func (r *TicketLinkRepository) IsDuplicate(ctx context.Context, ticketID int) (bool, error) {
+	var exists bool
+	query := "SELECT EXISTS (SELECT 1 FROM ticket_link WHERE ticket_id = $1 AND link_type = 'DUPLICATE_OF')"
+	err := r.DB.QueryRowContext(ctx, query, ticketID).Scan(&exists)
+	return exists, err
+}
+
+// DELETE
+func (r *TicketLinkRepository) Delete(ctx context.Context, tx *sql.Tx, id int) error {
+	result, err := tx.ExecContext(ctx, "DELETE FROM ticket_link WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -33,23 +33,43 @@ const baseTicketQuery = `
         t.version,
         j.id as job_id,
         j.job_priority,
+        COALESCE(job_stats.job_count, 0) as job_count,
+        COALESCE(job_stats.done_job_count, 0) as done_job_count,
+        job_stats.pic_npks as job_pic_npks,
         pl.name as location_name,
         sl.name as specified_location_name,
         t.created_at,
-        (NOW()::date - t.created_at::date) as ticket_age_days,
+        working_days_between(t.created_at::date, NOW()::date) as ticket_age_days,
         t.deadline,
-        (t.deadline::date - NOW()::date) as days_remaining,
+        working_days_between(NOW()::date, t.deadline::date) as days_remaining,
         req_emp.name as requestor_name,
 		req_emp.npk as requestor_npk,
         req_dept.name as requestor_department,
         pic_emp.name as pic_name,
 		pic_emp.npk as pic_npk,
         pic_area.name as pic_area_name,
+        ARRAY(SELECT tw.employee_npk FROM ticket_watcher tw WHERE tw.ticket_id = t.id ORDER BY tw.employee_npk) as watcher_npks,
         current_st.name as current_status,
         current_st.hex_color as current_status_hex_code,
-        current_sst.name as current_section_name
+        current_sst.name as current_section_name,
+        current_sla.due_at as sla_due_at,
+        COALESCE(NOW() > current_sla.due_at, false) as is_sla_breached
     FROM ticket t
-    LEFT JOIN job j ON t.id = j.ticket_id
+    LEFT JOIN LATERAL (
+        SELECT id, job_priority, pic_job
+        FROM job
+        WHERE ticket_id = t.id
+        ORDER BY id ASC
+        LIMIT 1
+    ) j ON true
+    LEFT JOIN LATERAL (
+        SELECT
+            COUNT(*) as job_count,
+            COUNT(*) FILTER (WHERE status = 'DONE') as done_job_count,
+            array_agg(DISTINCT pic_job) FILTER (WHERE pic_job IS NOT NULL) as pic_npks
+        FROM job
+        WHERE ticket_id = t.id
+    ) job_stats ON true
     LEFT JOIN department dt ON t.department_target_id = dt.id
     LEFT JOIN physical_location pl ON t.physical_location_id = pl.id
     LEFT JOIN specified_location sl ON t.specified_location_id = sl.id
@@ -58,12 +78,21 @@ const baseTicketQuery = `
     LEFT JOIN employee pic_emp ON j.pic_job = pic_emp.npk
     LEFT JOIN area pic_area ON pic_emp.area_id = pic_area.id
     LEFT JOIN (
-        SELECT DISTINCT ON (ticket_id) ticket_id, status_ticket_id
+        SELECT DISTINCT ON (ticket_id) ticket_id, status_ticket_id, start_date
         FROM track_status_ticket
         ORDER BY ticket_id, start_date DESC
     ) current_tst ON t.id = current_tst.ticket_id
     LEFT JOIN status_ticket current_st ON current_tst.status_ticket_id = current_st.id
     LEFT JOIN section_status_ticket current_sst ON current_st.section_id = current_sst.id
+    LEFT JOIN LATERAL (
+        SELECT add_working_days(current_tst.start_date, sp.max_days) as due_at
+        FROM sla_policy sp
+        WHERE sp.status_ticket_id = current_tst.status_ticket_id
+          AND sp.is_active = true
+          AND (sp.department_id = t.department_target_id OR sp.department_id IS NULL)
+        ORDER BY sp.department_id NULLS LAST
+        LIMIT 1
+    ) current_sla ON true
 `
 
 // MAIN
@@ -75,9 +104,9 @@ func (r *TicketRepository) Create(ctx context.Context, tx *sql.Tx, ticket model.
 	query := `
         INSERT INTO ticket (
             requestor, department_target_id, physical_location_id, 
-            specified_location_id, description, ticket_priority, deadline, support_file
+            specified_location_id, description, ticket_priority, deadline, support_file, workflow_version_id
         )
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
         RETURNING id, created_at, updated_at`
 
 	row := tx.QueryRowContext(ctx, query,
@@ -89,6 +118,7 @@ func (r *TicketRepository) Create(ctx context.Context, tx *sql.Tx, ticket model.
 		ticket.TicketPriority,
 		ticket.Deadline,
 		supportFilesJSON,
+		ticket.WorkflowVersionID,
 	)
 
 	var newTicket model.Ticket = ticket
@@ -144,11 +174,17 @@ func (r *TicketRepository) FindAll(filters dto.TicketFilter) ([]dto.TicketDetail
 	}
 
 	if len(filters.PicNPK) > 0 {
-		conditions = append(conditions, fmt.Sprintf("j.pic_job = ANY($%d)", argID))
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM job pj WHERE pj.ticket_id = t.id AND pj.pic_job = ANY($%d))", argID))
 		args = append(args, pq.Array(filters.PicNPK))
 		argID++
 	}
 
+	if len(filters.WatcherNPK) > 0 {
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM ticket_watcher tw WHERE tw.ticket_id = t.id AND tw.employee_npk = ANY($%d))", argID))
+		args = append(args, pq.Array(filters.WatcherNPK))
+		argID++
+	}
+
 	if filters.Year != 0 {
 		conditions = append(conditions, fmt.Sprintf("EXTRACT(YEAR FROM t.created_at) = $%d", argID))
 		args = append(args, filters.Year)
@@ -235,13 +271,20 @@ func (r *TicketRepository) FindByID(id int) (*dto.TicketDetailResponse, error) {
 	return &tickets[0], nil
 }
 
+// LOCK
+// Serializes the writers of one ticket until the transaction ends
+func (r *TicketRepository) LockByID(ctx context.Context, tx *sql.Tx, id int) error {
+	var lockedID int
+	return tx.QueryRowContext(ctx, "SELECT id FROM ticket WHERE id = $1 FOR UPDATE", id).Scan(&lockedID)
+}
+
 // GET BY ID AS STRUCT
 func (r *TicketRepository) FindByIDAsStruct(ctx context.Context, id int) (*model.Ticket, error) {
-	query := "SELECT id, requestor, department_target_id, physical_location_id, specified_location_id, description, ticket_priority FROM ticket WHERE id = $1"
+	query := "SELECT id, requestor, department_target_id, physical_location_id, specified_location_id, description, ticket_priority, workflow_version_id FROM ticket WHERE id = $1"
 	row := r.DB.QueryRowContext(ctx, query, id)
 
 	var t model.Ticket
-	err := row.Scan(&t.ID, &t.Requestor, &t.DepartmentTargetID, &t.PhysicalLocationID, &t.SpecifiedLocationID, &t.Description, &t.TicketPriority)
+	err := row.Scan(&t.ID, &t.Requestor, &t.DepartmentTargetID, &t.PhysicalLocationID, &t.SpecifiedLocationID, &t.Description, &t.TicketPriority, &t.WorkflowVersionID)
 	if err != nil {
 		return nil, err
 	}
@@ -421,6 +464,7 @@ func scanTicketDetails(rows *sql.Rows) ([]dto.TicketDetailResponse, error) {
 	var tickets []dto.TicketDetailResponse
 	for rows.Next() {
 		var t dto.TicketDetailResponse
+		var jobPicNPKs, watcherNPKs pq.StringArray
 		err := rows.Scan(
 			&t.TicketID,
 			&t.Description,
@@ -430,6 +474,9 @@ func scanTicketDetails(rows *sql.Rows) ([]dto.TicketDetailResponse, error) {
 			&t.Version,
 			&t.JobID,
 			&t.JobPriority,
+			&t.JobCount,
+			&t.CompletedJobCount,
+			&jobPicNPKs,
 			&t.LocationName,
 			&t.SpecifiedLocationName,
 			&t.CreatedAt,
@@ -442,28 +489,28 @@ func scanTicketDetails(rows *sql.Rows) ([]dto.TicketDetailResponse, error) {
 			&t.PicName,
 			&t.PicNPK,
 			&t.PicAreaName,
+			&watcherNPKs,
 			&t.CurrentStatus,
 			&t.CurrentStatusHexCode,
 			&t.CurrentSectionName,
+			&t.SlaDueAt,
+			&t.IsSlaBreached,
 		)
 		if err != nil {
 			return nil, err
 		}
+		t.JobPicNPKs = jobPicNPKs
+		t.WatcherNPKs = watcherNPKs
 		tickets = append(tickets, t)
 	}
 	return tickets, nil
 }
 
 // ADD SUPPORT FILE FOR TICKET
-func (r *TicketRepository) AddSupportFiles(ctx context.Context, ticketID int, filesMetadata []model.FileMetadata) error {
+func (r *TicketRepository) AddSupportFiles(ctx context.Context, tx *sql.Tx, ticketID int, filesMetadata []model.FileMetadata) error {
 	if len(filesMetadata) == 0 {
 		return nil
 	}
-	tx, err := r.DB.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
 
 	for _, fm := range filesMetadata {
 		jsonBytes, err := json.Marshal(fm)
@@ -487,10 +534,10 @@ func (r *TicketRepository) AddSupportFiles(ctx context.Context, ticketID int, fi
 		}
 	}
 
-	return tx.Commit()
+	return nil
 }
 
-func (r *TicketRepository) RemoveSupportFiles(ctx context.Context, ticketID int, filePathsToRemove []string) error {
+func (r *TicketRepository) RemoveSupportFiles(ctx context.Context, tx *sql.Tx, ticketID int, filePathsToRemove []string) error {
 	if len(filePathsToRemove) == 0 {
 		return nil
 	}
@@ -511,7 +558,7 @@ func (r *TicketRepository) RemoveSupportFiles(ctx context.Context, ticketID int,
             updated_at = NOW()
         WHERE id = $2`
 
-	result, err := r.DB.ExecContext(ctx, query, pq.Array(filePathsToRemove), ticketID)
+	result, err := tx.ExecContext(ctx, query, pq.Array(filePathsToRemove), ticketID)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"e-memo-job-reservation-api/internal/dto"
+)
+
+type TicketWatcherRepository struct {
+	DB *sql.DB
+}
+
+func NewTicketWatcherRepository(db *sql.DB) *TicketWatcherRepository {
+	return &TicketWatcherRepository{DB: db}
+}
+
+// CREATE
+// Following a ticket twice keeps the first entry
+func (r *TicketWatcherRepository) Create(ctx context.Context, tx *sql.Tx, ticketID int, employeeNPK string, addedByNPK string) error {
+	query := `
+        INSERT INTO ticket_watcher (ticket_id, employee_npk, added_by_npk)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (ticket_id, employee_npk) DO NOTHING`
+	_, err := tx.ExecContext(ctx, query, ticketID, employeeNPK, addedByNPK)
+	return err
+}
+
+// GET ALL BY TICKET ID
+func (r *TicketWatcherRepository) FindByTicketID(ctx context.Context, ticketID int) ([]dto.TicketWatcherResponse, error) {
+	query := `
+        SELECT tw.employee_npk, e.name, tw.added_by_npk, tw.created_at
+        FROM ticket_watcher tw
+        JOIN employee e ON tw.employee_npk = e.npk
+        WHERE tw.ticket_id = $1
+        ORDER BY tw.created_at ASC, tw.employee_npk ASC`
+
+	rows, err := r.DB.QueryContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var watchers []dto.TicketWatcherResponse
+	for rows.Next() {
+		var w dto.TicketWatcherResponse
+		if err := rows.Scan(&w.EmployeeNPK, &w.EmployeeName, &w.AddedByNPK, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		watchers = append(watchers, w)
+	}
+	return watchers, rows.Err()
+}
+
+// DELETE
+func (r *TicketWatcherRepository) Delete(ctx context.Context, tx *sql.Tx, ticketID int, employeeNPK string) error {
+	result, err := tx.ExecContext(ctx, "DELETE FROM ticket_watcher WHERE ticket_id = $1 AND employee_npk = $2", ticketID, employeeNPK)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -45,3 +45,32 @@ func (r *TrackStatusTicketRepository) GetCurrentStatusByTicketID(ctx context.Con
 	err = r.DB.QueryRowContext(ctx, query, ticketID).Scan(&statusID, &statusName)
 	return
 }
+
+// GET CURRENT STATUS ID IN TRANSACTION
+func (r *TrackStatusTicketRepository) FindCurrentStatusID(ctx context.Context, tx *sql.Tx, ticketID int) (int, error) {
+	var statusID int
+	query := "SELECT status_ticket_id FROM track_status_ticket WHERE ticket_id = $1 AND finish_date IS NULL LIMIT 1"
+	err := tx.QueryRowContext(ctx, query, ticketID).Scan(&statusID)
+	return statusID, err
+}
+
+// GET VISITED STATUS IDS
+func (r *TrackStatusTicketRepository) GetVisitedStatusIDs(ctx context.Context, ticketID int) ([]int, error) {
+	query := "SELECT DISTINCT status_ticket_id FROM track_status_ticket WHERE ticket_id = $1"
+
+	rows, err := r.DB.QueryContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statusIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		statusIDs = append(statusIDs, id)
+	}
+	return statusIDs, rows.Err()
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+)
+
+type TransitionPrerequisiteRepository struct {
+	DB *sql.DB
+}
+
+func NewTransitionPrerequisiteRepository(db *sql.DB) *TransitionPrerequisiteRepository {
+	return &TransitionPrerequisiteRepository{DB: db}
+}
+
+const baseTransitionPrerequisiteQuery = `
+    SELECT
+        tp.transition_id,
+        st.from_status_id,
+        fs.name as from_status_name,
+        st.to_status_id,
+        ts.name as to_status_name,
+        a.name as action_name,
+        ar.name as actor_role_name,
+        tp.required_status_id,
+        rs.name as required_status_name,
+        tp.is_active
+    FROM transition_prerequisite tp
+    JOIN status_transition st ON tp.transition_id = st.id
+    LEFT JOIN status_ticket fs ON st.from_status_id = fs.id
+    JOIN status_ticket ts ON st.to_status_id = ts.id
+    JOIN action a ON st.action_id = a.id
+    JOIN actor_role ar ON st.actor_role_id = ar.id
+    JOIN status_ticket rs ON tp.required_status_id = rs.id`
+
+// HELPER
+func scanTransitionPrerequisiteDetail(scanner interface{ Scan(...interface{}) error }) (*dto.TransitionPrerequisiteDetailResponse, error) {
+	var d dto.TransitionPrerequisiteDetailResponse
+	var fromStatusID sql.NullInt32
+	var fromStatusName sql.NullString
+
+	err := scanner.Scan(
+		&d.TransitionID, &fromStatusID, &fromStatusName, &d.ToStatusID, &d.ToStatusName,
+		&d.ActionName, &d.ActorRoleName, &d.RequiredStatusID, &d.RequiredStatusName, &d.IsActive,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromStatusID.Valid {
+		id := int(fromStatusID.Int32)
+		d.FromStatusID = &id
+	}
+	if fromStatusName.Valid {
+		d.FromStatusName = &fromStatusName.String
+	}
+	return &d, nil
+}
+
+// CREATE
+func (r *TransitionPrerequisiteRepository) Create(req dto.CreateTransitionPrerequisiteRequest) (*model.TransitionPrerequisite, error) {
+	query := `
+        INSERT INTO transition_prerequisite (transition_id, required_status_id, is_active)
+        VALUES ($1, $2, false)
+        RETURNING transition_id, required_status_id, is_active`
+
+	var p model.TransitionPrerequisite
+	err := r.DB.QueryRow(query, req.TransitionID, req.RequiredStatusID).Scan(&p.TransitionID, &p.RequiredStatusID, &p.IsActive)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// GET ALL
+func (r *TransitionPrerequisiteRepository) FindAll(filters dto.TransitionPrerequisiteFilter) ([]dto.TransitionPrerequisiteDetailResponse, error) {
+	query := baseTransitionPrerequisiteQuery
+	var conditions []string
+	var args []interface{}
+	argID := 1
+
+	if filters.FromStatusID > 0 {
+		conditions = append(conditions, "st.from_status_id = $"+strconv.Itoa(argID))
+		args = append(args, filters.FromStatusID)
+		argID++
+	}
+	if filters.RequiredStatusID > 0 {
+		conditions = append(conditions, "tp.required_status_id = $"+strconv.Itoa(argID))
+		args = append(args, filters.RequiredStatusID)
+		argID++
+	}
+	if filters.IsActive != nil {
+		conditions = append(conditions, "tp.is_active = $"+strconv.Itoa(argID))
+		args = append(args, *filters.IsActive)
+		argID++
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY tp.transition_id ASC"
+
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prerequisites []dto.TransitionPrerequisiteDetailResponse
+	for rows.Next() {
+		p, err := scanTransitionPrerequisiteDetail(rows)
+		if err != nil {
+			return nil, err
+		}
+		prerequisites = append(prerequisites, *p)
+	}
+	return prerequisites, nil
+}
+
+// GET BY TRANSITION ID
+func (r *TransitionPrerequisiteRepository) FindByTransitionID(transitionID int) (*dto.TransitionPrerequisiteDetailResponse, error) {
+	query := baseTransitionPrerequisiteQuery + " WHERE tp.transition_id = $1"
+	return scanTransitionPrerequisiteDetail(r.DB.QueryRow(query, transitionID))
+}
+
+// UPDATE
+func (r *TransitionPrerequisiteRepository) Update(transitionID int, req dto.UpdateTransitionPrerequisiteRequest) (*model.TransitionPrerequisite, error) {
+	query := `
+        UPDATE transition_prerequisite
+        SET required_status_id = $1, is_active = $2
+        WHERE transition_id = $3
+        RETURNING transition_id, required_status_id, is_active`
+
+	var p model.TransitionPrerequisite
+	err := r.DB.QueryRow(query, req.RequiredStatusID, req.IsActive, transitionID).Scan(&p.TransitionID, &p.RequiredStatusID, &p.IsActive)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// CHANGE ACTIVE STATUS
+func (r *TransitionPrerequisiteRepository) UpdateActiveStatus(transitionID int, isActive bool) error {
+	query := "UPDATE transition_prerequisite SET is_active = $1 WHERE transition_id = $2"
+	result, err := r.DB.Exec(query, isActive, transitionID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DELETE
+func (r *TransitionPrerequisiteRepository) Delete(transitionID int) error {
+	query := "DELETE FROM transition_prerequisite WHERE transition_id = $1"
+	result, err := r.DB.Exec(query, transitionID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
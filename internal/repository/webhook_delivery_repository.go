@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+)
+
+type WebhookDeliveryRepository struct {
+	DB *sql.DB
+}
+
+func NewWebhookDeliveryRepository(db *sql.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{DB: db}
+}
+
+const webhookDeliveryColumns = "id, endpoint_id, event, payload, status, attempts, next_attempt_at, last_status_code, last_error, redelivery_of, delivered_at, created_at"
+
+// HELPER
+func scanWebhookDelivery(scanner interface{ Scan(...interface{}) error }) (*model.WebhookDelivery, error) {
+	var d model.WebhookDelivery
+	var payload []byte
+	var lastStatusCode, redeliveryOf sql.NullInt64
+	var lastError sql.NullString
+	var deliveredAt sql.NullTime
+	err := scanner.Scan(
+		&d.ID, &d.EndpointID, &d.Event, &payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+		&lastStatusCode, &lastError, &redeliveryOf, &deliveredAt, &d.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	d.Payload = payload
+	if lastStatusCode.Valid {
+		code := int(lastStatusCode.Int64)
+		d.LastStatusCode = &code
+	}
+	if lastError.Valid {
+		d.LastError = &lastError.String
+	}
+	if redeliveryOf.Valid {
+		d.RedeliveryOf = &redeliveryOf.Int64
+	}
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+	return &d, nil
+}
+
+// ENQUEUE FOR EVENT
+// Creates a delivery for every active endpoint subscribed to the event.
+// With an outbox event id an endpoint gets at most one delivery of that event, so a retried event does not repeat it.
+func (r *WebhookDeliveryRepository) EnqueueForEvent(ctx context.Context, event string, payload []byte, outboxEventID *int64) (int64, error) {
+	var eventID sql.NullInt64
+	if outboxEventID != nil {
+		eventID = sql.NullInt64{Int64: *outboxEventID, Valid: true}
+	}
+
+	query := `
+        INSERT INTO webhook_delivery (endpoint_id, event, payload, outbox_event_id)
+        SELECT id, $1, $2, $3 FROM webhook_endpoint
+        WHERE is_active = true AND $1 = ANY(events)
+        ON CONFLICT (outbox_event_id, endpoint_id, event) DO NOTHING`
+	result, err := r.DB.ExecContext(ctx, query, event, string(payload), eventID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// GET ALL
+func (r *WebhookDeliveryRepository) FindAll(ctx context.Context, filters dto.WebhookDeliveryFilter) ([]dto.WebhookDeliveryResponse, int64, error) {
+	baseQuery := `
+        FROM webhook_delivery d
+        JOIN webhook_endpoint e ON d.endpoint_id = e.id`
+
+	var conditions []string
+	var args []interface{}
+	argID := 1
+
+	if filters.EndpointID > 0 {
+		conditions = append(conditions, fmt.Sprintf("d.endpoint_id = $%d", argID))
+		args = append(args, filters.EndpointID)
+		argID++
+	}
+	if filters.Event != "" {
+		conditions = append(conditions, fmt.Sprintf("d.event = $%d", argID))
+		args = append(args, filters.Event)
+		argID++
+	}
+	if filters.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("d.status = $%d", argID))
+		args = append(args, filters.Status)
+		argID++
+	}
+	if len(conditions) > 0 {
+		baseQuery += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var totalItems int64
+	if err := r.DB.QueryRowContext(ctx, "SELECT COUNT(d.id)"+baseQuery, args...).Scan(&totalItems); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+        SELECT d.id, d.endpoint_id, e.name, d.event, d.payload, d.status, d.attempts, d.next_attempt_at,
+               d.last_status_code, d.last_error, d.redelivery_of, d.delivered_at, d.created_at` + baseQuery +
+		fmt.Sprintf(" ORDER BY d.created_at DESC, d.id DESC LIMIT $%d OFFSET $%d", argID, argID+1)
+	args = append(args, filters.Limit, (filters.Page-1)*filters.Limit)
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var deliveries []dto.WebhookDeliveryResponse
+	for rows.Next() {
+		var d dto.WebhookDeliveryResponse
+		var payload []byte
+		var lastStatusCode, redeliveryOf sql.NullInt64
+		var lastError sql.NullString
+		var deliveredAt sql.NullTime
+		err := rows.Scan(
+			&d.ID, &d.EndpointID, &d.EndpointName, &d.Event, &payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+			&lastStatusCode, &lastError, &redeliveryOf, &deliveredAt, &d.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		d.Payload = payload
+		if lastStatusCode.Valid {
+			code := int(lastStatusCode.Int64)
+			d.LastStatusCode = &code
+		}
+		if lastError.Valid {
+			d.LastError = &lastError.String
+		}
+		if redeliveryOf.Valid {
+			d.RedeliveryOf = &redeliveryOf.Int64
+		}
+		if deliveredAt.Valid {
+			d.DeliveredAt = &deliveredAt.Time
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, totalItems, rows.Err()
+}
+
+// REDELIVER
+// Copies the delivery into a new pending one so the history of the original is kept
+func (r *WebhookDeliveryRepository) CreateRedelivery(ctx context.Context, id int64) (*model.WebhookDelivery, error) {
+	query := `
+        INSERT INTO webhook_delivery (endpoint_id, event, payload, redelivery_of)
+        SELECT endpoint_id, event, payload, id FROM webhook_delivery WHERE id = $1
+        RETURNING ` + webhookDeliveryColumns
+
+	return scanWebhookDelivery(r.DB.QueryRowContext(ctx, query, id))
+}
+
+// CLAIM DUE
+// Claimed rows are leased by moving next_attempt_at forward, so concurrent workers skip them
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookDelivery, error) {
+	query := `
+        UPDATE webhook_delivery SET next_attempt_at = NOW() + make_interval(secs => $2)
+        WHERE id IN (
+            SELECT id FROM webhook_delivery
+            WHERE status = 'PENDING' AND next_attempt_at <= NOW()
+            ORDER BY next_attempt_at ASC
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING ` + webhookDeliveryColumns
+
+	rows, err := r.DB.QueryContext(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []model.WebhookDelivery
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *d)
+	}
+	return deliveries, rows.Err()
+}
+
+// MARK SUCCESS
+func (r *WebhookDeliveryRepository) MarkSuccess(ctx context.Context, id int64, statusCode int) error {
+	query := `
+        UPDATE webhook_delivery
+        SET status = 'SUCCESS', attempts = attempts + 1, last_status_code = $2, last_error = NULL, delivered_at = NOW()
+        WHERE id = $1`
+	_, err := r.DB.ExecContext(ctx, query, id, statusCode)
+	return err
+}
+
+// MARK RETRY
+func (r *WebhookDeliveryRepository) MarkRetry(ctx context.Context, id int64, statusCode *int, nextAttemptAt time.Time, lastError string) error {
+	query := "UPDATE webhook_delivery SET attempts = attempts + 1, last_status_code = $2, next_attempt_at = $3, last_error = $4 WHERE id = $1"
+	_, err := r.DB.ExecContext(ctx, query, id, toNullInt64(statusCode), nextAttemptAt, lastError)
+	return err
+}
+
+// MARK FAILED
+func (r *WebhookDeliveryRepository) MarkFailed(ctx context.Context, id int64, statusCode *int, lastError string) error {
+	query := "UPDATE webhook_delivery SET status = 'FAILED', attempts = attempts + 1, last_status_code = $2, last_error = $3 WHERE id = $1"
+	_, err := r.DB.ExecContext(ctx, query, id, toNullInt64(statusCode), lastError)
+	return err
+}
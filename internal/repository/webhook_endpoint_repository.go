@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"database/sql"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+
+	"github.com/lib/pq"
+)
+
+type WebhookEndpointRepository struct {
+	DB *sql.DB
+}
+
+func NewWebhookEndpointRepository(db *sql.DB) *WebhookEndpointRepository {
+	return &WebhookEndpointRepository{DB: db}
+}
+
+const webhookEndpointColumns = "id, name, url, secret, events, is_active, created_at, updated_at"
+
+// HELPER
+func scanWebhookEndpoint(scanner interface{ Scan(...interface{}) error }) (*model.WebhookEndpoint, error) {
+	var e model.WebhookEndpoint
+	var events pq.StringArray
+	err := scanner.Scan(&e.ID, &e.Name, &e.URL, &e.Secret, &events, &e.IsActive, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	e.Events = events
+	return &e, nil
+}
+
+// CREATE
+func (r *WebhookEndpointRepository) Create(req dto.CreateWebhookEndpointRequest) (*model.WebhookEndpoint, error) {
+	query := `
+        INSERT INTO webhook_endpoint (name, url, secret, events, is_active)
+        VALUES ($1, $2, $3, $4, false)
+        RETURNING ` + webhookEndpointColumns
+
+	return scanWebhookEndpoint(r.DB.QueryRow(query, req.Name, req.URL, req.Secret, pq.Array(req.Events)))
+}
+
+// GET ALL
+func (r *WebhookEndpointRepository) FindAll() ([]model.WebhookEndpoint, error) {
+	query := "SELECT " + webhookEndpointColumns + " FROM webhook_endpoint ORDER BY id ASC"
+	rows, err := r.DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var endpoints []model.WebhookEndpoint
+	for rows.Next() {
+		e, err := scanWebhookEndpoint(rows)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, *e)
+	}
+	return endpoints, nil
+}
+
+// GET BY ID
+func (r *WebhookEndpointRepository) FindByID(id int) (*model.WebhookEndpoint, error) {
+	query := "SELECT " + webhookEndpointColumns + " FROM webhook_endpoint WHERE id = $1"
+	return scanWebhookEndpoint(r.DB.QueryRow(query, id))
+}
+
+// UPDATE
+// An empty secret keeps the stored one
+func (r *WebhookEndpointRepository) Update(id int, req dto.UpdateWebhookEndpointRequest) (*model.WebhookEndpoint, error) {
+	query := `
+        UPDATE webhook_endpoint
+        SET name = $1, url = $2, secret = COALESCE(NULLIF($3, ''), secret), events = $4, is_active = $5, updated_at = NOW()
+        WHERE id = $6
+        RETURNING ` + webhookEndpointColumns
+
+	return scanWebhookEndpoint(r.DB.QueryRow(query, req.Name, req.URL, req.Secret, pq.Array(req.Events), req.IsActive, id))
+}
+
+// CHANGE ACTIVE STATUS
+func (r *WebhookEndpointRepository) UpdateActiveStatus(id int, isActive bool) error {
+	query := "UPDATE webhook_endpoint SET is_active = $1, updated_at = NOW() WHERE id = $2"
+	result, err := r.DB.Exec(query, isActive, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DELETE
+func (r *WebhookEndpointRepository) Delete(id int) error {
+	query := "DELETE FROM webhook_endpoint WHERE id = $1"
+	result, err := r.DB.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
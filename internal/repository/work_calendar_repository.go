@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+)
+
+type WorkCalendarRepository struct {
+	DB *sql.DB
+}
+
+func NewWorkCalendarRepository(db *sql.DB) *WorkCalendarRepository {
+	return &WorkCalendarRepository{DB: db}
+}
+
+// WORK DAY
+
+// GET ALL WORK DAYS
+func (r *WorkCalendarRepository) FindAllWorkDays() ([]model.WorkDay, error) {
+	rows, err := r.DB.Query("SELECT day_of_week, is_work_day, updated_at FROM work_day ORDER BY day_of_week ASC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var workDays []model.WorkDay
+	for rows.Next() {
+		var wd model.WorkDay
+		if err := rows.Scan(&wd.DayOfWeek, &wd.IsWorkDay, &wd.UpdatedAt); err != nil {
+			return nil, err
+		}
+		workDays = append(workDays, wd)
+	}
+	return workDays, nil
+}
+
+// UPSERT WORK DAY
+func (r *WorkCalendarRepository) UpsertWorkDay(ctx context.Context, tx *sql.Tx, dayOfWeek int, isWorkDay bool) error {
+	query := `
+        INSERT INTO work_day (day_of_week, is_work_day, updated_at)
+        VALUES ($1, $2, NOW())
+        ON CONFLICT (day_of_week) DO UPDATE SET is_work_day = EXCLUDED.is_work_day, updated_at = NOW()`
+	_, err := tx.ExecContext(ctx, query, dayOfWeek, isWorkDay)
+	return err
+}
+
+// HOLIDAY
+
+const holidayColumns = "id, holiday_date, name, is_active, created_at, updated_at"
+
+func scanHoliday(scanner interface{ Scan(...interface{}) error }) (*model.Holiday, error) {
+	var h model.Holiday
+	err := scanner.Scan(&h.ID, &h.HolidayDate, &h.Name, &h.IsActive, &h.CreatedAt, &h.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+// CREATE
+func (r *WorkCalendarRepository) CreateHoliday(holidayDate time.Time, name string) (*model.Holiday, error) {
+	query := `
+        INSERT INTO holiday (holiday_date, name, is_active)
+        VALUES ($1, $2, true)
+        RETURNING ` + holidayColumns
+	return scanHoliday(r.DB.QueryRow(query, holidayDate, name))
+}
+
+// GET ALL
+func (r *WorkCalendarRepository) FindAllHolidays(filters dto.HolidayFilter) ([]model.Holiday, error) {
+	query := "SELECT " + holidayColumns + " FROM holiday"
+	var conditions []string
+	var args []interface{}
+	argID := 1
+
+	if filters.Year > 0 {
+		conditions = append(conditions, "EXTRACT(YEAR FROM holiday_date) = $"+strconv.Itoa(argID))
+		args = append(args, filters.Year)
+		argID++
+	}
+	if filters.IsActive != nil {
+		conditions = append(conditions, "is_active = $"+strconv.Itoa(argID))
+		args = append(args, *filters.IsActive)
+		argID++
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY holiday_date ASC"
+
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holidays []model.Holiday
+	for rows.Next() {
+		h, err := scanHoliday(rows)
+		if err != nil {
+			return nil, err
+		}
+		holidays = append(holidays, *h)
+	}
+	return holidays, nil
+}
+
+// GET BY ID
+func (r *WorkCalendarRepository) FindHolidayByID(id int) (*model.Holiday, error) {
+	query := "SELECT " + holidayColumns + " FROM holiday WHERE id = $1"
+	return scanHoliday(r.DB.QueryRow(query, id))
+}
+
+// UPDATE
+func (r *WorkCalendarRepository) UpdateHoliday(id int, holidayDate time.Time, name string, isActive bool) (*model.Holiday, error) {
+	query := `
+        UPDATE holiday
+        SET holiday_date = $1, name = $2, is_active = $3, updated_at = NOW()
+        WHERE id = $4
+        RETURNING ` + holidayColumns
+	return scanHoliday(r.DB.QueryRow(query, holidayDate, name, isActive, id))
+}
+
+// CHANGE ACTIVE STATUS
+func (r *WorkCalendarRepository) UpdateHolidayActiveStatus(id int, isActive bool) error {
+	query := "UPDATE holiday SET is_active = $1, updated_at = NOW() WHERE id = $2"
+	result, err := r.DB.Exec(query, isActive, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DELETE
+func (r *WorkCalendarRepository) DeleteHoliday(id int) error {
+	result, err := r.DB.Exec("DELETE FROM holiday WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// CALENDAR
+
+// LOAD CALENDAR FOR IN-MEMORY CALCULATION
+func (r *WorkCalendarRepository) LoadCalendar(ctx context.Context) (*model.WorkCalendar, error) {
+	calendar := &model.WorkCalendar{
+		WorkDays: make(map[time.Weekday]bool),
+		Holidays: make(map[string]bool),
+	}
+
+	rows, err := r.DB.QueryContext(ctx, "SELECT day_of_week FROM work_day WHERE is_work_day = true")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var day int
+		if err := rows.Scan(&day); err != nil {
+			return nil, err
+		}
+		calendar.WorkDays[time.Weekday(day)] = true
+	}
+
+	holidayRows, err := r.DB.QueryContext(ctx, "SELECT to_char(holiday_date, 'YYYY-MM-DD') FROM holiday WHERE is_active = true")
+	if err != nil {
+		return nil, err
+	}
+	defer holidayRows.Close()
+	for holidayRows.Next() {
+		var date string
+		if err := holidayRows.Scan(&date); err != nil {
+			return nil, err
+		}
+		calendar.Holidays[date] = true
+	}
+
+	return calendar, nil
+}
@@ -150,3 +150,29 @@ func (r *WorkflowRepository) IsNameTaken(name string, currentID int) (bool, erro
 	err := r.DB.QueryRow(query, name, currentID).Scan(&exists)
 	return exists, err
 }
+
+// GET INITIAL STATUSES
+// The first step of every workflow except excludeWorkflowID, tickets can only enter the transition graph there
+func (r *WorkflowRepository) FindInitialStatusIDs(ctx context.Context, excludeWorkflowID int) ([]int, error) {
+	query := `
+        SELECT DISTINCT ON (workflow_id) status_ticket_id
+        FROM workflow_step
+        WHERE workflow_id <> $1
+        ORDER BY workflow_id, step_sequence ASC`
+
+	rows, err := r.DB.QueryContext(ctx, query, excludeWorkflowID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statusIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		statusIDs = append(statusIDs, id)
+	}
+	return statusIDs, rows.Err()
+}
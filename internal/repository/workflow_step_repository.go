@@ -107,6 +107,21 @@ func (r *WorkflowStepRepository) Delete(id int) error {
 	return nil
 }
 
+// COUNT UNPINNED TICKETS IN STEP
+// Open tickets sitting in the step's status that follow the live workflow, removing the step strands them
+func (r *WorkflowStepRepository) CountUnpinnedOpenTickets(id int) (int, error) {
+	var count int
+	query := `
+        SELECT COUNT(*)
+        FROM workflow_step ws
+        JOIN track_status_ticket tst ON tst.status_ticket_id = ws.status_ticket_id AND tst.finish_date IS NULL
+        JOIN status_ticket st ON ws.status_ticket_id = st.id
+        JOIN ticket t ON tst.ticket_id = t.id
+        WHERE ws.id = $1 AND st.is_terminal = false AND t.workflow_version_id IS NULL`
+	err := r.DB.QueryRow(query, id).Scan(&count)
+	return count, err
+}
+
 // CHANGE STATUS
 func (r *WorkflowStepRepository) UpdateActiveStatus(id int, isActive bool) error {
 	query := "UPDATE workflow_step SET is_active = $1, updated_at = NOW() WHERE id = $2"
@@ -127,3 +142,10 @@ func (r *WorkflowStepRepository) UpdateActiveStatusByWorkflowID(ctx context.Cont
 	_, err := tx.ExecContext(ctx, query, isActive, workflowID)
 	return err
 }
+
+// DELETE ALL BY WORKFLOW ID
+func (r *WorkflowStepRepository) DeleteByWorkflowID(ctx context.Context, tx *sql.Tx, workflowID int) error {
+	query := "DELETE FROM workflow_step WHERE workflow_id = $1"
+	_, err := tx.ExecContext(ctx, query, workflowID)
+	return err
+}
@@ -0,0 +1,449 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+
+	"github.com/lib/pq"
+)
+
+type WorkflowVersionRepository struct {
+	DB *sql.DB
+}
+
+func NewWorkflowVersionRepository(db *sql.DB) *WorkflowVersionRepository {
+	return &WorkflowVersionRepository{DB: db}
+}
+
+const workflowVersionColumns = "id, workflow_id, version_number, note, published_by_npk, published_at"
+
+// HELPER
+func scanWorkflowVersion(scanner interface{ Scan(...interface{}) error }) (*model.WorkflowVersion, error) {
+	var v model.WorkflowVersion
+	var note, publishedBy sql.NullString
+	if err := scanner.Scan(&v.ID, &v.WorkflowID, &v.VersionNumber, &note, &publishedBy, &v.PublishedAt); err != nil {
+		return nil, err
+	}
+	if note.Valid {
+		v.Note = &note.String
+	}
+	if publishedBy.Valid {
+		v.PublishedByNPK = &publishedBy.String
+	}
+	return &v, nil
+}
+
+// CREATE
+// The workflow row is locked first so two publications cannot take the same version number
+func (r *WorkflowVersionRepository) Create(ctx context.Context, tx *sql.Tx, workflowID int, note *string, publishedByNPK string) (*model.WorkflowVersion, error) {
+	var lockedID int
+	if err := tx.QueryRowContext(ctx, "SELECT id FROM workflow WHERE id = $1 FOR UPDATE", workflowID).Scan(&lockedID); err != nil {
+		return nil, err
+	}
+
+	query := `
+        INSERT INTO workflow_version (workflow_id, version_number, note, published_by_npk)
+        SELECT $1, COALESCE(MAX(version_number), 0) + 1, $2, $3
+        FROM workflow_version WHERE workflow_id = $1
+        RETURNING ` + workflowVersionColumns
+	return scanWorkflowVersion(tx.QueryRowContext(ctx, query, workflowID, note, toNullString(publishedByNPK)))
+}
+
+// SNAPSHOT
+// Copies the steps of the workflow and the active transitions leaving them, or starting a ticket in them
+func (r *WorkflowVersionRepository) Snapshot(ctx context.Context, tx *sql.Tx, versionID int, workflowID int) (steps int64, transitions int64, err error) {
+	stepQuery := `
+        INSERT INTO workflow_version_step (workflow_version_id, status_ticket_id, step_sequence)
+        SELECT $1, status_ticket_id, step_sequence FROM workflow_step WHERE workflow_id = $2`
+	result, err := tx.ExecContext(ctx, stepQuery, versionID, workflowID)
+	if err != nil {
+		return 0, 0, err
+	}
+	steps, _ = result.RowsAffected()
+
+	transitionQuery := `
+        INSERT INTO workflow_version_transition (
+            workflow_version_id, from_status_id, to_status_id, action_id, actor_role_id,
+            require_reason, reason_label, require_file, required_approvals, required_status_ids
+        )
+        SELECT
+            $1, st.from_status_id, st.to_status_id, st.action_id, st.actor_role_id,
+            st.require_reason, st.reason_label, st.require_file, st.required_approvals,
+            ARRAY(SELECT tp.required_status_id FROM transition_prerequisite tp
+                  WHERE tp.transition_id = st.id AND tp.is_active = true)
+        FROM status_transition st
+        WHERE st.is_active = true
+          AND (
+            st.from_status_id IN (SELECT status_ticket_id FROM workflow_step WHERE workflow_id = $2)
+            OR (st.from_status_id IS NULL AND st.to_status_id IN (SELECT status_ticket_id FROM workflow_step WHERE workflow_id = $2))
+          )`
+	result, err = tx.ExecContext(ctx, transitionQuery, versionID, workflowID)
+	if err != nil {
+		return 0, 0, err
+	}
+	transitions, _ = result.RowsAffected()
+	return steps, transitions, nil
+}
+
+// GET ALL BY WORKFLOW ID
+func (r *WorkflowVersionRepository) FindByWorkflowID(workflowID int) ([]model.WorkflowVersion, error) {
+	query := "SELECT " + workflowVersionColumns + " FROM workflow_version WHERE workflow_id = $1 ORDER BY version_number DESC"
+	rows, err := r.DB.Query(query, workflowID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []model.WorkflowVersion
+	for rows.Next() {
+		v, err := scanWorkflowVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, *v)
+	}
+	return versions, rows.Err()
+}
+
+// GET BY ID
+func (r *WorkflowVersionRepository) FindByID(id int) (*model.WorkflowVersion, error) {
+	query := "SELECT " + workflowVersionColumns + " FROM workflow_version WHERE id = $1"
+	return scanWorkflowVersion(r.DB.QueryRow(query, id))
+}
+
+func (r *WorkflowVersionRepository) FindSteps(versionID int) ([]dto.WorkflowVersionStepResponse, error) {
+	query := `
+        SELECT vs.status_ticket_id, st.name, vs.step_sequence
+        FROM workflow_version_step vs
+        JOIN status_ticket st ON vs.status_ticket_id = st.id
+        WHERE vs.workflow_version_id = $1
+        ORDER BY vs.step_sequence ASC`
+	rows, err := r.DB.Query(query, versionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var steps []dto.WorkflowVersionStepResponse
+	for rows.Next() {
+		var s dto.WorkflowVersionStepResponse
+		if err := rows.Scan(&s.StatusTicketID, &s.StatusName, &s.StepSequence); err != nil {
+			return nil, err
+		}
+		steps = append(steps, s)
+	}
+	return steps, rows.Err()
+}
+
+func (r *WorkflowVersionRepository) FindTransitions(versionID int) ([]dto.WorkflowVersionTransitionResponse, error) {
+	query := `
+        SELECT
+            vt.from_status_id, fs.name, vt.to_status_id, ts.name,
+            vt.action_id, a.name, vt.actor_role_id, ar.name,
+            vt.require_reason, vt.reason_label, vt.require_file, vt.required_approvals, vt.required_status_ids
+        FROM workflow_version_transition vt
+        LEFT JOIN status_ticket fs ON vt.from_status_id = fs.id
+        JOIN status_ticket ts ON vt.to_status_id = ts.id
+        JOIN action a ON vt.action_id = a.id
+        JOIN actor_role ar ON vt.actor_role_id = ar.id
+        WHERE vt.workflow_version_id = $1
+        ORDER BY vt.from_status_id ASC NULLS FIRST, vt.action_id ASC, vt.actor_role_id ASC`
+	rows, err := r.DB.Query(query, versionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transitions []dto.WorkflowVersionTransitionResponse
+	for rows.Next() {
+		var t dto.WorkflowVersionTransitionResponse
+		var fromStatusID sql.NullInt64
+		var fromStatusName, reasonLabel sql.NullString
+		var requiredStatusIDs pq.Int64Array
+		if err := rows.Scan(
+			&fromStatusID, &fromStatusName, &t.ToStatusID, &t.ToStatusName,
+			&t.ActionID, &t.ActionName, &t.ActorRoleID, &t.ActorRoleName,
+			&t.RequireReason, &reasonLabel, &t.RequireFile, &t.RequiredApprovals, &requiredStatusIDs,
+		); err != nil {
+			return nil, err
+		}
+		if fromStatusID.Valid {
+			id := int(fromStatusID.Int64)
+			t.FromStatusID = &id
+		}
+		if fromStatusName.Valid {
+			t.FromStatusName = &fromStatusName.String
+		}
+		if reasonLabel.Valid {
+			t.ReasonLabel = &reasonLabel.String
+		}
+		t.RequiredStatusIDs = make([]int, 0, len(requiredStatusIDs))
+		for _, id := range requiredStatusIDs {
+			t.RequiredStatusIDs = append(t.RequiredStatusIDs, int(id))
+		}
+		transitions = append(transitions, t)
+	}
+	return transitions, rows.Err()
+}
+
+// GET LATEST BY POSITION
+// The newest version of the workflow the position starts tickets in, with its first step
+func (r *WorkflowVersionRepository) FindLatestByPosition(ctx context.Context, positionID int) (versionID int, initialStatusID int, err error) {
+	query := `
+        SELECT wv.id, vs.status_ticket_id
+        FROM position_to_workflow_mapping ptwm
+        JOIN workflow_version wv ON wv.workflow_id = ptwm.workflow_id
+        JOIN workflow_version_step vs ON vs.workflow_version_id = wv.id
+        WHERE ptwm.employee_position_id = $1
+        ORDER BY wv.version_number DESC, vs.step_sequence ASC
+        LIMIT 1`
+	err = r.DB.QueryRowContext(ctx, query, positionID).Scan(&versionID, &initialStatusID)
+	return
+}
+
+func (r *WorkflowVersionRepository) GetInitialStatusID(ctx context.Context, versionID int) (int, error) {
+	var statusID int
+	query := `
+        SELECT status_ticket_id FROM workflow_version_step
+        WHERE workflow_version_id = $1
+        ORDER BY step_sequence ASC
+        LIMIT 1`
+	err := r.DB.QueryRowContext(ctx, query, versionID).Scan(&statusID)
+	return statusID, err
+}
+
+// Every status a ticket of the version can be in, steps as well as statuses only reached by a transition
+func (r *WorkflowVersionRepository) FindStatusIDs(ctx context.Context, versionID int) ([]int, error) {
+	query := `
+        SELECT status_ticket_id FROM workflow_version_step WHERE workflow_version_id = $1
+        UNION
+        SELECT to_status_id FROM workflow_version_transition WHERE workflow_version_id = $1
+        UNION
+        SELECT from_status_id FROM workflow_version_transition WHERE workflow_version_id = $1 AND from_status_id IS NOT NULL`
+	rows, err := r.DB.QueryContext(ctx, query, versionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statusIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		statusIDs = append(statusIDs, id)
+	}
+	return statusIDs, rows.Err()
+}
+
+// TRANSITIONS
+// Same as StatusTransitionRepository.FindAvailableTransitionsForRoles, read from the version
+func (r *WorkflowVersionRepository) FindTransitionsForRoles(versionID int, fromStatusID int, roleIDs []int) ([]dto.AvailableTicketActionResponse, error) {
+	if len(roleIDs) == 0 {
+		return []dto.AvailableTicketActionResponse{}, nil
+	}
+
+	query := `
+        SELECT
+            a.name as action_name,
+            a.id as action_id,
+            vt.to_status_id,
+            a.hex_code,
+            vt.require_reason,
+            vt.reason_label,
+            vt.require_file,
+            req.status_id as required_status_id,
+            rs.name as required_status_name,
+            vt.actor_role_id,
+            (SELECT MAX(q.required_approvals) FROM workflow_version_transition q
+             WHERE q.workflow_version_id = vt.workflow_version_id
+               AND q.from_status_id = vt.from_status_id AND q.action_id = vt.action_id) as required_approvals
+        FROM workflow_version_transition vt
+        JOIN action a ON vt.action_id = a.id
+        LEFT JOIN LATERAL unnest(vt.required_status_ids) AS req(status_id) ON true
+        LEFT JOIN status_ticket rs ON req.status_id = rs.id
+        WHERE vt.workflow_version_id = $1
+          AND vt.from_status_id = $2
+          AND vt.actor_role_id = ANY($3)
+          AND a.is_active = true`
+
+	rows, err := r.DB.Query(query, versionID, fromStatusID, pq.Array(roleIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var actions []dto.AvailableTicketActionResponse
+	for rows.Next() {
+		var a dto.AvailableTicketActionResponse
+		if err := rows.Scan(
+			&a.ActionName,
+			&a.ActionID,
+			&a.ToStatusID,
+			&a.HexCode,
+			&a.RequireReason,
+			&a.ReasonLabel,
+			&a.RequireFile,
+			&a.RequiredStatusID,
+			&a.RequiredStatusName,
+			&a.ActorRoleID,
+			&a.RequiredApprovals,
+		); err != nil {
+			return nil, err
+		}
+		actions = append(actions, a)
+	}
+	return actions, rows.Err()
+}
+
+// Same as StatusTransitionRepository.FindValidTransition, read from the version
+func (r *WorkflowVersionRepository) FindValidTransition(versionID int, fromStatusID int, actionName string) (int, []int, error) {
+	query := `
+        SELECT vt.to_status_id, vt.actor_role_id
+        FROM workflow_version_transition vt
+        JOIN action a ON vt.action_id = a.id
+        WHERE vt.workflow_version_id = $1 AND vt.from_status_id = $2 AND a.name = $3`
+
+	rows, err := r.DB.Query(query, versionID, fromStatusID, actionName)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer rows.Close()
+
+	var toStatusID int
+	var allowedRoleIDs []int
+	for rows.Next() {
+		var currentToStatusID, currentActorRoleID int
+		if err := rows.Scan(&currentToStatusID, &currentActorRoleID); err != nil {
+			return 0, nil, err
+		}
+		if len(allowedRoleIDs) > 0 && toStatusID != currentToStatusID {
+			return 0, nil, fmt.Errorf("data inconsistency: action '%s' from status %d leads to multiple different to_statuses in workflow version %d", actionName, fromStatusID, versionID)
+		}
+		toStatusID = currentToStatusID
+		allowedRoleIDs = append(allowedRoleIDs, currentActorRoleID)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, nil, err
+	}
+
+	if len(allowedRoleIDs) == 0 {
+		return 0, nil, sql.ErrNoRows
+	}
+	return toStatusID, allowedRoleIDs, nil
+}
+
+// Same as StatusTransitionRepository.FindActionFileRequirement, read from the version
+func (r *WorkflowVersionRepository) FindActionFileRequirement(ctx context.Context, versionID int, fromStatusID int, actionName string) (bool, bool, error) {
+	query := `
+        SELECT COUNT(*) > 0, COALESCE(bool_or(vt.require_file), false)
+        FROM workflow_version_transition vt
+        JOIN action a ON vt.action_id = a.id
+        WHERE vt.workflow_version_id = $1 AND vt.from_status_id = $2 AND a.name = $3`
+
+	var exists, requireFile bool
+	err := r.DB.QueryRowContext(ctx, query, versionID, fromStatusID, actionName).Scan(&exists, &requireFile)
+	return exists, requireFile, err
+}
+
+// Same as StatusTransitionRepository.IsActionTarget, read from the version
+func (r *WorkflowVersionRepository) IsActionTarget(ctx context.Context, versionID int, toStatusID int, actionName string) (bool, error) {
+	var exists bool
+	query := `
+        SELECT EXISTS (
+            SELECT 1 FROM workflow_version_transition vt
+            JOIN action a ON vt.action_id = a.id
+            WHERE vt.workflow_version_id = $1 AND vt.to_status_id = $2 AND a.name = $3
+        )`
+	err := r.DB.QueryRowContext(ctx, query, versionID, toStatusID, actionName).Scan(&exists)
+	return exists, err
+}
+
+// Names of the actor roles allowed to perform the action from the status in the version
+func (r *WorkflowVersionRepository) FindActorRoleNamesForAction(versionID int, fromStatusID int, actionName string) ([]string, error) {
+	query := `
+        SELECT ar.name
+        FROM workflow_version_transition vt
+        JOIN action a ON vt.action_id = a.id
+        JOIN actor_role ar ON vt.actor_role_id = ar.id
+        WHERE vt.workflow_version_id = $1 AND vt.from_status_id = $2 AND a.name = $3
+        ORDER BY ar.name`
+	rows, err := r.DB.Query(query, versionID, fromStatusID, actionName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// MIGRATION
+type WorkflowMigrationCandidate struct {
+	TicketID        int
+	CurrentStatusID int
+}
+
+// FindMigrationCandidates locks the open tickets pinned to fromVersionID. When it is not set the unpinned
+// tickets whose requestor's position starts tickets in the workflow are returned instead.
+func (r *WorkflowVersionRepository) FindMigrationCandidates(ctx context.Context, tx *sql.Tx, workflowID int, fromVersionID *int) ([]WorkflowMigrationCandidate, error) {
+	query := `
+        SELECT t.id, tst.status_ticket_id
+        FROM ticket t
+        JOIN track_status_ticket tst ON tst.ticket_id = t.id AND tst.finish_date IS NULL
+        JOIN status_ticket st ON tst.status_ticket_id = st.id
+        WHERE st.is_terminal = false
+          AND t.workflow_version_id IS NOT DISTINCT FROM $2
+          AND (
+            $2::integer IS NOT NULL
+            OR EXISTS (
+                SELECT 1 FROM employee e
+                JOIN position_to_workflow_mapping ptwm ON ptwm.employee_position_id = e.employee_position_id
+                WHERE e.npk = t.requestor AND ptwm.workflow_id = $1
+            )
+          )
+        ORDER BY t.id
+        FOR UPDATE OF t`
+
+	rows, err := tx.QueryContext(ctx, query, workflowID, toNullInt64(fromVersionID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []WorkflowMigrationCandidate
+	for rows.Next() {
+		var c WorkflowMigrationCandidate
+		if err := rows.Scan(&c.TicketID, &c.CurrentStatusID); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
+
+func (r *WorkflowVersionRepository) PinTicket(ctx context.Context, tx *sql.Tx, ticketID int, versionID int) error {
+	query := "UPDATE ticket SET workflow_version_id = $1, updated_at = NOW() WHERE id = $2"
+	_, err := tx.ExecContext(ctx, query, versionID, ticketID)
+	return err
+}
+
+func (r *WorkflowVersionRepository) RecordMigration(ctx context.Context, tx *sql.Tx, ticketID int, fromVersionID *int, toVersionID int, fromStatusID int, toStatusID int, migratedByNPK string) error {
+	query := `
+        INSERT INTO workflow_ticket_migration (ticket_id, from_version_id, to_version_id, from_status_id, to_status_id, migrated_by_npk)
+        VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := tx.ExecContext(ctx, query, ticketID, toNullInt64(fromVersionID), toVersionID, fromStatusID, toStatusID, toNullString(migratedByNPK))
+	return err
+}
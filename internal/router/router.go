@@ -10,24 +10,43 @@ import (
 )
 
 type AllHandlers struct {
-	AuthHandler                *handler.AuthHandler
-	DepartmentHandler          *handler.DepartmentHandler
-	AreaHandler                *handler.AreaHandler
-	EmployeeHandler            *handler.EmployeeHandler
-	PhysicalLocationHandler    *handler.PhysicalLocationHandler
-	AccessPermissionHandler    *handler.AccessPermissionHandler
-	SectionStatusTicketHandler *handler.SectionStatusTicketHandler
-	StatusTicketHandler        *handler.StatusTicketHandler
-	TicketHandler              *handler.TicketHandler
-	PositionPermissionHandler  *handler.PositionPermissionHandler
-	EmployeePositionHandler    *handler.EmployeePositionHandler
-	WorkflowHandler            *handler.WorkflowHandler
-	SpecifiedLocationHandler   *handler.SpecifiedLocationHandler
-	RejectedTicketHandler      *handler.RejectedTicketHandler
-	JobHandler                 *handler.JobHandler
-	ActionHandler              *handler.ActionHandler
-	FileHandler                *handler.FileHandler
-	SystemHandler              *handler.SystemHandler
+	AuthHandler                   *handler.AuthHandler
+	DepartmentHandler             *handler.DepartmentHandler
+	AreaHandler                   *handler.AreaHandler
+	EmployeeHandler               *handler.EmployeeHandler
+	PhysicalLocationHandler       *handler.PhysicalLocationHandler
+	AccessPermissionHandler       *handler.AccessPermissionHandler
+	SectionStatusTicketHandler    *handler.SectionStatusTicketHandler
+	StatusTicketHandler           *handler.StatusTicketHandler
+	TicketHandler                 *handler.TicketHandler
+	PositionPermissionHandler     *handler.PositionPermissionHandler
+	EmployeePositionHandler       *handler.EmployeePositionHandler
+	WorkflowHandler               *handler.WorkflowHandler
+	SpecifiedLocationHandler      *handler.SpecifiedLocationHandler
+	RejectedTicketHandler         *handler.RejectedTicketHandler
+	JobHandler                    *handler.JobHandler
+	ActionHandler                 *handler.ActionHandler
+	FileHandler                   *handler.FileHandler
+	SystemHandler                 *handler.SystemHandler
+	TransitionPrerequisiteHandler *handler.TransitionPrerequisiteHandler
+	SlaPolicyHandler              *handler.SlaPolicyHandler
+	WorkCalendarHandler           *handler.WorkCalendarHandler
+	EditingLockHandler            *handler.EditingLockHandler
+	NotificationPreferenceHandler *handler.NotificationPreferenceHandler
+	NotificationHandler           *handler.NotificationHandler
+	WebhookHandler                *handler.WebhookHandler
+	ActorRoleHandler              *handler.ActorRoleHandler
+	ActorRoleMappingHandler       *handler.ActorRoleMappingHandler
+	StatusTransitionHandler       *handler.StatusTransitionHandler
+	WorkflowGraphHandler          *handler.WorkflowGraphHandler
+	WorkflowSimulationHandler     *handler.WorkflowSimulationHandler
+	WorkflowVersionHandler        *handler.WorkflowVersionHandler
+	EmployeeDelegationHandler     *handler.EmployeeDelegationHandler
+	EscalationRuleHandler         *handler.EscalationRuleHandler
+	ActionReasonCodeHandler       *handler.ActionReasonCodeHandler
+	TicketLinkHandler             *handler.TicketLinkHandler
+	TicketCommentHandler          *handler.TicketCommentHandler
+	TicketWatcherHandler          *handler.TicketWatcherHandler
 }
 
 type AllRepositories struct {
@@ -182,6 +201,7 @@ func setupMasterDataRoutes(group *gin.RouterGroup, h *AllHandlers, r *AllReposit
 			statusTicketRoutes.GET("/:id", h.StatusTicketHandler.GetStatusTicketByID)
 			statusTicketRoutes.DELETE("/:id", h.StatusTicketHandler.DeleteStatusTicket)
 			statusTicketRoutes.PATCH("/:id/status", h.StatusTicketHandler.UpdateStatusTicketActiveStatus)
+			statusTicketRoutes.PATCH("/:id/terminal", h.StatusTicketHandler.UpdateStatusTicketTerminal)
 			statusTicketRoutes.PUT("/reorder", h.StatusTicketHandler.ReorderStatusTickets)
 		}
 
@@ -228,6 +248,121 @@ func setupMasterDataRoutes(group *gin.RouterGroup, h *AllHandlers, r *AllReposit
 				stepRoutes.PATCH("/:id/status", h.WorkflowHandler.UpdateWorkflowStepActiveStatus)
 			}
 		}
+		workflowDesignerRoutes := masterGroup.Group("/workflow")
+		{
+			workflowDesignerRoutes.POST("/simulate", h.WorkflowSimulationHandler.Simulate)
+			workflowDesignerRoutes.GET("/:id/graph", h.WorkflowGraphHandler.ExportGraph)
+			workflowDesignerRoutes.PUT("/:id/graph", h.WorkflowGraphHandler.ImportGraph)
+			workflowDesignerRoutes.POST("/:id/publish", h.WorkflowVersionHandler.Publish)
+			workflowDesignerRoutes.GET("/:id/versions", h.WorkflowVersionHandler.GetVersionsByWorkflowID)
+			workflowDesignerRoutes.POST("/:id/migrate-tickets", h.WorkflowVersionHandler.MigrateTickets)
+		}
+		versionRoutes := masterGroup.Group("/workflow-version")
+		{
+			versionRoutes.GET("/:id", h.WorkflowVersionHandler.GetVersionByID)
+		}
+		prerequisiteRoutes := masterGroup.Group("/transition-prerequisite")
+		{
+			prerequisiteRoutes.POST("", h.TransitionPrerequisiteHandler.CreateTransitionPrerequisite)
+			prerequisiteRoutes.GET("", h.TransitionPrerequisiteHandler.GetAllTransitionPrerequisites)
+			prerequisiteRoutes.GET("/:transitionId", h.TransitionPrerequisiteHandler.GetTransitionPrerequisiteByTransitionID)
+			prerequisiteRoutes.PUT("/:transitionId", h.TransitionPrerequisiteHandler.UpdateTransitionPrerequisite)
+			prerequisiteRoutes.DELETE("/:transitionId", h.TransitionPrerequisiteHandler.DeleteTransitionPrerequisite)
+			prerequisiteRoutes.PATCH("/:transitionId/status", h.TransitionPrerequisiteHandler.UpdateTransitionPrerequisiteActiveStatus)
+		}
+		slaPolicyRoutes := masterGroup.Group("/sla-policy")
+		{
+			slaPolicyRoutes.POST("", h.SlaPolicyHandler.CreateSlaPolicy)
+			slaPolicyRoutes.GET("", h.SlaPolicyHandler.GetAllSlaPolicies)
+			slaPolicyRoutes.GET("/:id", h.SlaPolicyHandler.GetSlaPolicyByID)
+			slaPolicyRoutes.PUT("/:id", h.SlaPolicyHandler.UpdateSlaPolicy)
+			slaPolicyRoutes.DELETE("/:id", h.SlaPolicyHandler.DeleteSlaPolicy)
+			slaPolicyRoutes.PATCH("/:id/status", h.SlaPolicyHandler.UpdateSlaPolicyActiveStatus)
+		}
+		escalationRuleRoutes := masterGroup.Group("/escalation-rule")
+		{
+			escalationRuleRoutes.POST("", h.EscalationRuleHandler.CreateEscalationRule)
+			escalationRuleRoutes.GET("", h.EscalationRuleHandler.GetAllEscalationRules)
+			escalationRuleRoutes.GET("/:id", h.EscalationRuleHandler.GetEscalationRuleByID)
+			escalationRuleRoutes.PUT("/:id", h.EscalationRuleHandler.UpdateEscalationRule)
+			escalationRuleRoutes.DELETE("/:id", h.EscalationRuleHandler.DeleteEscalationRule)
+			escalationRuleRoutes.PATCH("/:id/status", h.EscalationRuleHandler.UpdateEscalationRuleActiveStatus)
+		}
+		calendarRoutes := masterGroup.Group("/work-calendar")
+		{
+			calendarRoutes.GET("/work-days", h.WorkCalendarHandler.GetWorkDays)
+			calendarRoutes.PUT("/work-days", h.WorkCalendarHandler.UpdateWorkDays)
+			calendarRoutes.POST("/holidays", h.WorkCalendarHandler.CreateHoliday)
+			calendarRoutes.GET("/holidays", h.WorkCalendarHandler.GetAllHolidays)
+			calendarRoutes.GET("/holidays/:id", h.WorkCalendarHandler.GetHolidayByID)
+			calendarRoutes.PUT("/holidays/:id", h.WorkCalendarHandler.UpdateHoliday)
+			calendarRoutes.DELETE("/holidays/:id", h.WorkCalendarHandler.DeleteHoliday)
+			calendarRoutes.PATCH("/holidays/:id/status", h.WorkCalendarHandler.UpdateHolidayActiveStatus)
+		}
+		lockAdminRoutes := masterGroup.Group("/editing-lock")
+		{
+			lockAdminRoutes.GET("/active", h.EditingLockHandler.GetActiveLocks)
+			lockAdminRoutes.DELETE("/:entity/:contextId", h.EditingLockHandler.ForceReleaseLock)
+		}
+		webhookRoutes := masterGroup.Group("/webhook")
+		{
+			webhookRoutes.POST("", h.WebhookHandler.CreateEndpoint)
+			webhookRoutes.GET("", h.WebhookHandler.GetAllEndpoints)
+			webhookRoutes.GET("/:id", h.WebhookHandler.GetEndpointByID)
+			webhookRoutes.PUT("/:id", h.WebhookHandler.UpdateEndpoint)
+			webhookRoutes.DELETE("/:id", h.WebhookHandler.DeleteEndpoint)
+			webhookRoutes.PATCH("/:id/status", h.WebhookHandler.UpdateEndpointActiveStatus)
+		}
+		webhookDeliveryRoutes := masterGroup.Group("/webhook-delivery")
+		{
+			webhookDeliveryRoutes.GET("", h.WebhookHandler.GetDeliveries)
+			webhookDeliveryRoutes.POST("/:id/redeliver", h.WebhookHandler.Redeliver)
+		}
+		actionRoutes := masterGroup.Group("/action")
+		{
+			actionRoutes.POST("", h.ActionHandler.CreateAction)
+			actionRoutes.GET("/:id", h.ActionHandler.GetActionByID)
+			actionRoutes.PUT("/:id", h.ActionHandler.UpdateAction)
+			actionRoutes.DELETE("/:id", h.ActionHandler.DeleteAction)
+			actionRoutes.PATCH("/:id/status", h.ActionHandler.UpdateActionActiveStatus)
+		}
+		reasonCodeRoutes := masterGroup.Group("/action-reason-code")
+		{
+			reasonCodeRoutes.POST("", h.ActionReasonCodeHandler.CreateActionReasonCode)
+			reasonCodeRoutes.GET("", h.ActionReasonCodeHandler.GetAllActionReasonCodes)
+			reasonCodeRoutes.GET("/:id", h.ActionReasonCodeHandler.GetActionReasonCodeByID)
+			reasonCodeRoutes.PUT("/:id", h.ActionReasonCodeHandler.UpdateActionReasonCode)
+			reasonCodeRoutes.DELETE("/:id", h.ActionReasonCodeHandler.DeleteActionReasonCode)
+			reasonCodeRoutes.PATCH("/:id/status", h.ActionReasonCodeHandler.UpdateActionReasonCodeActiveStatus)
+		}
+		actorRoleRoutes := masterGroup.Group("/actor-role")
+		{
+			actorRoleRoutes.POST("", h.ActorRoleHandler.CreateActorRole)
+			actorRoleRoutes.GET("", h.ActorRoleHandler.GetAllActorRoles)
+			actorRoleRoutes.GET("/:id", h.ActorRoleHandler.GetActorRoleByID)
+			actorRoleRoutes.PUT("/:id", h.ActorRoleHandler.UpdateActorRole)
+			actorRoleRoutes.DELETE("/:id", h.ActorRoleHandler.DeleteActorRole)
+			actorRoleRoutes.PATCH("/:id/status", h.ActorRoleHandler.UpdateActorRoleActiveStatus)
+		}
+		actorRoleMappingRoutes := masterGroup.Group("/actor-role-mapping")
+		{
+			actorRoleMappingRoutes.POST("", h.ActorRoleMappingHandler.CreateActorRoleMapping)
+			actorRoleMappingRoutes.GET("", h.ActorRoleMappingHandler.GetAllActorRoleMappings)
+			actorRoleMappingRoutes.GET("/:id", h.ActorRoleMappingHandler.GetActorRoleMappingByID)
+			actorRoleMappingRoutes.PUT("/:id", h.ActorRoleMappingHandler.UpdateActorRoleMapping)
+			actorRoleMappingRoutes.DELETE("/:id", h.ActorRoleMappingHandler.DeleteActorRoleMapping)
+		}
+		transitionRoutes := masterGroup.Group("/status-transition")
+		{
+			transitionRoutes.POST("", h.StatusTransitionHandler.CreateStatusTransition)
+			transitionRoutes.GET("", h.StatusTransitionHandler.GetAllStatusTransitions)
+			transitionRoutes.GET("/validate", h.StatusTransitionHandler.ValidateGraph)
+			transitionRoutes.PUT("/graph", h.StatusTransitionHandler.SaveGraph)
+			transitionRoutes.GET("/:id", h.StatusTransitionHandler.GetStatusTransitionByID)
+			transitionRoutes.PUT("/:id", h.StatusTransitionHandler.UpdateStatusTransition)
+			transitionRoutes.DELETE("/:id", h.StatusTransitionHandler.DeleteStatusTransition)
+			transitionRoutes.PATCH("/:id/status", h.StatusTransitionHandler.UpdateStatusTransitionActiveStatus)
+		}
 	}
 }
 
@@ -239,11 +374,25 @@ func setupMainDataRoutes(group *gin.RouterGroup, h *AllHandlers, r *AllRepositor
 		ticketRoutes.PUT("/:id", editModeMiddleware.CheckEditMode(), h.TicketHandler.UpdateTicket)
 		ticketRoutes.PUT("/reorder", editModeMiddleware.CheckEditMode(), auth.RequirePermission("TICKET_PRIORITY_MANAGE", r.PositionPermissionRepo), h.TicketHandler.ReorderTickets)
 		ticketRoutes.POST("/:id/action", editModeMiddleware.CheckEditMode(), h.TicketHandler.ExecuteAction)
+		ticketRoutes.POST("/:id/cancel", editModeMiddleware.CheckEditMode(), h.TicketHandler.CancelTicket)
+		ticketRoutes.POST("/:id/reopen", editModeMiddleware.CheckEditMode(), h.TicketHandler.ReopenTicket)
 		ticketRoutes.GET("/:id/available-actions", h.TicketHandler.GetAvailableActions)
 		ticketRoutes.GET("/:id/files", h.FileHandler.GetAllFilesByTicketID)
 		ticketRoutes.POST("/:id/files", editModeMiddleware.CheckEditMode(), h.TicketHandler.AddSupportFiles)
 		ticketRoutes.DELETE("/:id/files", editModeMiddleware.CheckEditMode(), h.TicketHandler.RemoveSupportFiles)
 		ticketRoutes.GET("/:id/last-rejection", h.TicketHandler.GetLastRejectionDetail)
+		ticketRoutes.GET("/:id/timeline", h.TicketHandler.GetTicketTimeline)
+		ticketRoutes.GET("/:id/links", h.TicketLinkHandler.GetLinks)
+		ticketRoutes.POST("/:id/links", editModeMiddleware.CheckEditMode(), h.TicketLinkHandler.CreateLink)
+		ticketRoutes.DELETE("/:id/links/:linkId", editModeMiddleware.CheckEditMode(), h.TicketLinkHandler.DeleteLink)
+		ticketRoutes.GET("/:id/comments", h.TicketCommentHandler.GetComments)
+		ticketRoutes.POST("/:id/comments", editModeMiddleware.CheckEditMode(), h.TicketCommentHandler.CreateComment)
+		ticketRoutes.PUT("/:id/comments/:commentId", editModeMiddleware.CheckEditMode(), h.TicketCommentHandler.UpdateComment)
+		ticketRoutes.DELETE("/:id/comments/:commentId", editModeMiddleware.CheckEditMode(), h.TicketCommentHandler.DeleteComment)
+		ticketRoutes.GET("/:id/comments/:commentId/history", h.TicketCommentHandler.GetCommentHistory)
+		ticketRoutes.GET("/:id/watchers", h.TicketWatcherHandler.GetWatchers)
+		ticketRoutes.POST("/:id/watchers", editModeMiddleware.CheckEditMode(), h.TicketWatcherHandler.AddWatcher)
+		ticketRoutes.DELETE("/:id/watchers", editModeMiddleware.CheckEditMode(), h.TicketWatcherHandler.RemoveWatcher)
 	}
 
 	jobRoutes := group.Group("/jobs")
@@ -253,5 +402,36 @@ func setupMainDataRoutes(group *gin.RouterGroup, h *AllHandlers, r *AllRepositor
 		jobRoutes.GET("/:id/available-actions", h.JobHandler.GetAvailableActions)
 		jobRoutes.PUT("/:id/assign", editModeMiddleware.CheckEditMode(), auth.RequirePermission("JOB_ASSIGN_PIC", r.PositionPermissionRepo), h.JobHandler.AssignPIC)
 		jobRoutes.PUT("/reorder", editModeMiddleware.CheckEditMode(), auth.RequirePermission("JOB_PRIORITY_MANAGE", r.PositionPermissionRepo), h.JobHandler.ReorderJobs)
+		jobRoutes.POST("", editModeMiddleware.CheckEditMode(), auth.RequirePermission("JOB_ASSIGN_PIC", r.PositionPermissionRepo), h.JobHandler.CreateJob)
+		jobRoutes.DELETE("/:id", editModeMiddleware.CheckEditMode(), auth.RequirePermission("JOB_ASSIGN_PIC", r.PositionPermissionRepo), h.JobHandler.DeleteJob)
+		jobRoutes.POST("/:id/complete", editModeMiddleware.CheckEditMode(), h.JobHandler.CompleteJob)
+	}
+
+	editingLockRoutes := group.Group("/editing-lock")
+	{
+		editingLockRoutes.POST("", h.EditingLockHandler.AcquireLock)
+		editingLockRoutes.PUT("/heartbeat", h.EditingLockHandler.HeartbeatLock)
+		editingLockRoutes.DELETE("", h.EditingLockHandler.ReleaseLock)
+	}
+
+	notificationPreferenceRoutes := group.Group("/notification-preference")
+	{
+		notificationPreferenceRoutes.GET("", h.NotificationPreferenceHandler.GetPreference)
+		notificationPreferenceRoutes.PUT("", h.NotificationPreferenceHandler.UpdatePreference)
+	}
+
+	notificationRoutes := group.Group("/notifications")
+	{
+		notificationRoutes.GET("", h.NotificationHandler.GetNotifications)
+		notificationRoutes.GET("/unread-count", h.NotificationHandler.GetUnreadCount)
+		notificationRoutes.PATCH("/:id/read", h.NotificationHandler.MarkAsRead)
+		notificationRoutes.POST("/read-all", h.NotificationHandler.MarkAllAsRead)
+	}
+
+	delegationRoutes := group.Group("/delegation")
+	{
+		delegationRoutes.POST("", h.EmployeeDelegationHandler.CreateDelegation)
+		delegationRoutes.GET("", h.EmployeeDelegationHandler.GetDelegations)
+		delegationRoutes.DELETE("/:id", h.EmployeeDelegationHandler.RevokeDelegation)
 	}
 }
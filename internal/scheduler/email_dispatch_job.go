@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"e-memo-job-reservation-api/internal/repository"
+	"e-memo-job-reservation-api/pkg/mailer"
+)
+
+const (
+	emailBatchSize    = 50
+	emailMaxAttempts  = 6
+	emailBaseBackoff  = time.Minute
+	emailSendingLease = 5 * time.Minute
+)
+
+type EmailDispatchJob struct {
+	emailQueueRepo *repository.EmailQueueRepository
+	mailer         *mailer.Mailer
+}
+
+func NewEmailDispatchJob(emailQueueRepo *repository.EmailQueueRepository, mailer *mailer.Mailer) *EmailDispatchJob {
+	return &EmailDispatchJob{emailQueueRepo: emailQueueRepo, mailer: mailer}
+}
+
+// RUN
+func (j *EmailDispatchJob) Run() {
+	ctx := context.Background()
+
+	emails, err := j.emailQueueRepo.ClaimDue(ctx, emailBatchSize, emailSendingLease)
+	if err != nil {
+		log.Printf("ERROR: Could not claim queued emails: %v", err)
+		return
+	}
+	if len(emails) == 0 {
+		return
+	}
+
+	sent, retried, failed := 0, 0, 0
+	for _, email := range emails {
+		err := j.mailer.Send(email.RecipientEmail, email.Subject, email.Body)
+		if err == nil {
+			if err := j.emailQueueRepo.MarkSent(ctx, email.ID); err != nil {
+				log.Printf("ERROR: Failed to mark email %d as sent: %v", email.ID, err)
+			}
+			sent++
+			continue
+		}
+
+		attempts := email.Attempts + 1
+		if attempts >= emailMaxAttempts {
+			log.Printf("ERROR: Giving up on email %d to %s after %d attempts: %v", email.ID, email.RecipientEmail, attempts, err)
+			if err := j.emailQueueRepo.MarkFailed(ctx, email.ID, err.Error()); err != nil {
+				log.Printf("ERROR: Failed to mark email %d as failed: %v", email.ID, err)
+			}
+			failed++
+			continue
+		}
+
+		// 1m, 2m, 4m, 8m, ... between attempts
+		backoff := emailBaseBackoff * time.Duration(math.Pow(2, float64(attempts-1)))
+		if err := j.emailQueueRepo.MarkRetry(ctx, email.ID, time.Now().Add(backoff), err.Error()); err != nil {
+			log.Printf("ERROR: Failed to reschedule email %d: %v", email.ID, err)
+		}
+		retried++
+	}
+
+	log.Printf("Email dispatch finished: %d sent, %d rescheduled, %d failed", sent, retried, failed)
+}
@@ -20,13 +20,14 @@ type jobWithScore struct {
 }
 
 type JobReorderJob struct {
-	jobRepo *repository.JobRepository
-	db      *sql.DB
-	hub     *websocket.Hub
+	jobRepo          *repository.JobRepository
+	workCalendarRepo *repository.WorkCalendarRepository
+	db               *sql.DB
+	hub              *websocket.Hub
 }
 
-func NewJobReorderJob(db *sql.DB, jobRepo *repository.JobRepository, hub *websocket.Hub) *JobReorderJob {
-	return &JobReorderJob{db: db, jobRepo: jobRepo, hub: hub}
+func NewJobReorderJob(db *sql.DB, jobRepo *repository.JobRepository, workCalendarRepo *repository.WorkCalendarRepository, hub *websocket.Hub) *JobReorderJob {
+	return &JobReorderJob{db: db, jobRepo: jobRepo, workCalendarRepo: workCalendarRepo, hub: hub}
 }
 
 func (j *JobReorderJob) Run() {
@@ -38,9 +39,16 @@ func (j *JobReorderJob) Run() {
 		log.Printf("ERROR (Job Reorder): Could not get target departments: %v", err)
 		return
 	}
+
+	calendar, err := j.workCalendarRepo.LoadCalendar(ctx)
+	if err != nil {
+		log.Printf("ERROR (Job Reorder): Could not load work calendar: %v", err)
+		return
+	}
+
 	for _, deptID := range departmentIDs {
 		log.Printf("Processing JOBS for department ID: %d", deptID)
-		err := j.reorderJobsForDepartment(ctx, deptID)
+		err := j.reorderJobsForDepartment(ctx, deptID, calendar)
 		if err != nil {
 			log.Printf("ERROR (Job Reorder): Failed to reorder jobs for department %d: %v", deptID, err)
 			continue
@@ -58,7 +66,7 @@ func (j *JobReorderJob) Run() {
 	log.Println("JOB priority recalculation job finished.")
 }
 
-func (j *JobReorderJob) reorderJobsForDepartment(ctx context.Context, departmentID int) error {
+func (j *JobReorderJob) reorderJobsForDepartment(ctx context.Context, departmentID int, calendar *model.WorkCalendar) error {
 	tx, err := j.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -74,12 +82,13 @@ func (j *JobReorderJob) reorderJobsForDepartment(ctx context.Context, department
 		return nil
 	}
 
+	now := time.Now()
 	scoredJobs := make([]jobWithScore, len(jobs))
 	for i, job := range jobs {
-		ageInDays := time.Since(job.Ticket.CreatedAt).Hours() / 24
+		ageInDays := float64(calendar.WorkingDaysBetween(job.Ticket.CreatedAt, now))
 
 		ageWeight := calculateAgeWeight(ageInDays)
-		deadlineWeight := calculateDeadlineWeight(job.Ticket.Deadline)
+		deadlineWeight := calculateDeadlineWeight(job.Ticket.Deadline, calendar)
 
 		jobPriorityWeight := 2.0 / float64(job.JobPriority)
 
@@ -111,6 +120,7 @@ func (j *JobReorderJob) getActiveJobsByDepartment(ctx context.Context, tx *sql.T
         FROM job j
         JOIN ticket t ON j.ticket_id = t.id
         WHERE t.department_target_id = $1 -- [FIX] Menggunakan kolom dari tabel ticket
+        AND j.status = 'OPEN'
         AND EXISTS (
             SELECT 1 FROM track_status_ticket tst
             JOIN status_ticket st ON tst.status_ticket_id = st.id
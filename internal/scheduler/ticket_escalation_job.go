@@ -0,0 +1,36 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+
+	"e-memo-job-reservation-api/internal/service"
+)
+
+type TicketEscalationJob struct {
+	escalationService *service.TicketEscalationService
+}
+
+func NewTicketEscalationJob(escalationService *service.TicketEscalationService) *TicketEscalationJob {
+	return &TicketEscalationJob{escalationService: escalationService}
+}
+
+// RUN
+func (j *TicketEscalationJob) Run() {
+	log.Println("Starting ticket escalation job...")
+
+	ctx := context.Background()
+
+	reminded, err := j.escalationService.SendDueReminders(ctx)
+	if err != nil {
+		log.Printf("ERROR: Could not get tickets for escalation reminders: %v", err)
+	}
+
+	notified, executed, err := j.escalationService.EscalateDueTickets(ctx)
+	if err != nil {
+		log.Printf("ERROR: Could not get tickets for escalation: %v", err)
+		return
+	}
+
+	log.Printf("Ticket escalation job finished. Reminded: %d, notified: %d, auto-executed: %d", reminded, notified, executed)
+}
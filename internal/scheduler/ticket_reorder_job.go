@@ -21,13 +21,14 @@ type ticketWithScore struct {
 }
 
 type TicketReorderJob struct {
-	ticketRepo *repository.TicketRepository
-	db         *sql.DB
-	hub        *websocket.Hub
+	ticketRepo       *repository.TicketRepository
+	workCalendarRepo *repository.WorkCalendarRepository
+	db               *sql.DB
+	hub              *websocket.Hub
 }
 
-func NewTicketReorderJob(db *sql.DB, ticketRepo *repository.TicketRepository, hub *websocket.Hub) *TicketReorderJob {
-	return &TicketReorderJob{db: db, ticketRepo: ticketRepo, hub: hub}
+func NewTicketReorderJob(db *sql.DB, ticketRepo *repository.TicketRepository, workCalendarRepo *repository.WorkCalendarRepository, hub *websocket.Hub) *TicketReorderJob {
+	return &TicketReorderJob{db: db, ticketRepo: ticketRepo, workCalendarRepo: workCalendarRepo, hub: hub}
 }
 
 // RUN
@@ -42,9 +43,15 @@ func (j *TicketReorderJob) Run() {
 		return
 	}
 
+	calendar, err := j.workCalendarRepo.LoadCalendar(ctx)
+	if err != nil {
+		log.Printf("ERROR: Could not load work calendar: %v", err)
+		return
+	}
+
 	for _, deptID := range departmentIDs {
 		log.Printf("Processing department ID: %d", deptID)
-		err := j.reorderTicketsForDepartment(ctx, deptID)
+		err := j.reorderTicketsForDepartment(ctx, deptID, calendar)
 		if err != nil {
 			log.Printf("ERROR: Failed to reorder tickets for department %d: %v", deptID, err)
 			continue
@@ -62,7 +69,7 @@ func (j *TicketReorderJob) Run() {
 	log.Println("Ticket priority recalculation job finished.")
 }
 
-func (j *TicketReorderJob) reorderTicketsForDepartment(ctx context.Context, departmentID int) error {
+func (j *TicketReorderJob) reorderTicketsForDepartment(ctx context.Context, departmentID int, calendar *model.WorkCalendar) error {
 	tx, err := j.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -78,17 +85,18 @@ func (j *TicketReorderJob) reorderTicketsForDepartment(ctx context.Context, depa
 		return nil
 	}
 
-	// SCORING FOR EACH TICKET
+	// SCORING FOR EACH TICKET (IN WORKING DAYS)
+	now := time.Now()
 	scoredTickets := make([]ticketWithScore, len(tickets))
 	for i, ticket := range tickets {
-		ageInDays := time.Since(ticket.CreatedAt).Hours() / 24
+		ageInDays := float64(calendar.WorkingDaysBetween(ticket.CreatedAt, now))
 
 		// WEIGHT LOGIC
 		ageWeight := calculateAgeWeight(ageInDays)
 		priorityWeight := 2.0 / float64(ticket.TicketPriority)
 
 		// DEADLINE WEIGHT
-		deadlineWeight := calculateDeadlineWeight(ticket.Deadline)
+		deadlineWeight := calculateDeadlineWeight(ticket.Deadline, calendar)
 
 		score := (ageInDays * ageWeight * 1.0) + (priorityWeight * 1.5) + (deadlineWeight * 2.0)
 
@@ -125,12 +133,12 @@ func calculateAgeWeight(days float64) float64 {
 	return math.Sqrt(days) * 0.5
 }
 
-func calculateDeadlineWeight(deadline sql.NullTime) float64 {
+func calculateDeadlineWeight(deadline sql.NullTime, calendar *model.WorkCalendar) float64 {
 	if !deadline.Valid {
 		return 10.0
 	}
 
-	daysRemaining := time.Until(deadline.Time).Hours() / 24
+	daysRemaining := float64(calendar.WorkingDaysBetween(time.Now(), deadline.Time))
 
 	const steepnessFactor = 3.0
 	const baseScore = 100.0
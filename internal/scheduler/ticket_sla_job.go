@@ -0,0 +1,185 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"e-memo-job-reservation-api/internal/service"
+	"e-memo-job-reservation-api/internal/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+type ticketSlaState struct {
+	TrackID            int64
+	TicketID           int
+	DepartmentTargetID int
+	RequestorNPK       string
+	PicNPKs            pq.StringArray
+	WatcherNPKs        pq.StringArray
+	StatusID           int
+	StatusName         string
+	DueAt              time.Time
+	WarningSent        bool
+}
+
+type TicketSlaJob struct {
+	db             *sql.DB
+	hub            *websocket.Hub
+	webhookService *service.WebhookService
+}
+
+func NewTicketSlaJob(db *sql.DB, hub *websocket.Hub, webhookService *service.WebhookService) *TicketSlaJob {
+	return &TicketSlaJob{db: db, hub: hub, webhookService: webhookService}
+}
+
+// RUN
+func (j *TicketSlaJob) Run() {
+	log.Println("Starting ticket SLA evaluation job...")
+
+	ctx := context.Background()
+
+	tickets, err := j.getTicketsPastWarningThreshold(ctx)
+	if err != nil {
+		log.Printf("ERROR: Could not get tickets for SLA evaluation: %v", err)
+		return
+	}
+
+	now := time.Now()
+	warned, breached := 0, 0
+	for _, ticket := range tickets {
+		if !now.Before(ticket.DueAt) {
+			isMarked, err := j.markBreached(ctx, ticket.TrackID)
+			if err != nil {
+				log.Printf("ERROR: Failed to mark SLA breach for ticket %d: %v", ticket.TicketID, err)
+				continue
+			}
+			if isMarked {
+				j.broadcastSlaEvent("TICKET_SLA_BREACHED", ticket)
+				breached++
+			}
+			continue
+		}
+
+		if ticket.WarningSent {
+			continue
+		}
+		isMarked, err := j.markWarningSent(ctx, ticket.TrackID)
+		if err != nil {
+			log.Printf("ERROR: Failed to mark SLA warning for ticket %d: %v", ticket.TicketID, err)
+			continue
+		}
+		if isMarked {
+			j.broadcastSlaEvent("TICKET_SLA_WARNING", ticket)
+			warned++
+		}
+	}
+
+	log.Printf("Ticket SLA evaluation job finished. Warnings: %d, breaches: %d", warned, breached)
+}
+
+func (j *TicketSlaJob) broadcastSlaEvent(eventType string, ticket ticketSlaState) {
+	payload := gin.H{
+		"ticket_id":            ticket.TicketID,
+		"department_target_id": ticket.DepartmentTargetID,
+		"status_id":            ticket.StatusID,
+		"status_name":          ticket.StatusName,
+		"sla_due_at":           ticket.DueAt,
+	}
+	message, err := websocket.NewMessage(eventType, payload)
+	if err != nil {
+		log.Printf("CRITICAL: Failed to create websocket message for ticket SLA cron job: %v", err)
+		return
+	}
+
+	involvedNPKs := append([]string{ticket.RequestorNPK}, ticket.PicNPKs...)
+	audience := websocket.TicketAudience{
+		TicketID:           ticket.TicketID,
+		DepartmentTargetID: ticket.DepartmentTargetID,
+		InvolvedNPKs:       append(involvedNPKs, ticket.WatcherNPKs...),
+	}
+	j.hub.BroadcastTicketMessage(audience, message, message)
+
+	if err := j.webhookService.PublishEvent(eventType, payload, nil); err != nil {
+		log.Printf("ERROR: Failed to queue %s webhooks for ticket %d: %v", eventType, ticket.TicketID, err)
+	}
+}
+
+// HELPER
+
+// GET OPEN STATUS PERIODS THAT ALREADY PASSED THEIR WARNING THRESHOLD
+func (j *TicketSlaJob) getTicketsPastWarningThreshold(ctx context.Context) ([]ticketSlaState, error) {
+	query := `
+        SELECT
+            tst.id,
+            tst.ticket_id,
+            t.department_target_id,
+            t.requestor,
+            (SELECT array_agg(DISTINCT j.pic_job) FROM job j WHERE j.ticket_id = t.id AND j.pic_job IS NOT NULL),
+            ARRAY(SELECT tw.employee_npk FROM ticket_watcher tw WHERE tw.ticket_id = t.id),
+            st.id,
+            st.name,
+            sla.due_at,
+            tst.sla_warning_sent_at IS NOT NULL
+        FROM track_status_ticket tst
+        JOIN ticket t ON tst.ticket_id = t.id
+        JOIN status_ticket st ON tst.status_ticket_id = st.id
+        JOIN LATERAL (
+            SELECT
+                add_working_days(tst.start_date, sp.max_days) as due_at,
+                sp.warning_percentage
+            FROM sla_policy sp
+            WHERE sp.status_ticket_id = tst.status_ticket_id
+              AND sp.is_active = true
+              AND (sp.department_id = t.department_target_id OR sp.department_id IS NULL)
+            ORDER BY sp.department_id NULLS LAST
+            LIMIT 1
+        ) sla ON true
+        WHERE tst.finish_date IS NULL
+          AND tst.sla_breached_at IS NULL
+          AND NOW() >= tst.start_date + (sla.due_at - tst.start_date) * (sla.warning_percentage / 100.0)`
+
+	rows, err := j.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tickets []ticketSlaState
+	for rows.Next() {
+		var t ticketSlaState
+		if err := rows.Scan(&t.TrackID, &t.TicketID, &t.DepartmentTargetID, &t.RequestorNPK, &t.PicNPKs, &t.WatcherNPKs, &t.StatusID, &t.StatusName, &t.DueAt, &t.WarningSent); err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, t)
+	}
+	return tickets, rows.Err()
+}
+
+// MARK WARNING, RETURNS FALSE IF ANOTHER RUN ALREADY MARKED IT
+func (j *TicketSlaJob) markWarningSent(ctx context.Context, trackID int64) (bool, error) {
+	query := "UPDATE track_status_ticket SET sla_warning_sent_at = NOW() WHERE id = $1 AND sla_warning_sent_at IS NULL"
+	result, err := j.db.ExecContext(ctx, query, trackID)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	return rowsAffected > 0, err
+}
+
+// MARK BREACH, RETURNS FALSE IF ANOTHER RUN ALREADY MARKED IT
+func (j *TicketSlaJob) markBreached(ctx context.Context, trackID int64) (bool, error) {
+	query := `
+        UPDATE track_status_ticket
+        SET sla_breached_at = NOW(), sla_warning_sent_at = COALESCE(sla_warning_sent_at, NOW())
+        WHERE id = $1 AND sla_breached_at IS NULL`
+	result, err := j.db.ExecContext(ctx, query, trackID)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	return rowsAffected > 0, err
+}
@@ -0,0 +1,149 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+)
+
+const (
+	webhookBatchSize     = 50
+	webhookMaxAttempts   = 8
+	webhookBaseBackoff   = 30 * time.Second
+	webhookMaxBackoff    = 6 * time.Hour
+	webhookDeliveryLease = 5 * time.Minute
+	webhookTimeout       = 10 * time.Second
+)
+
+type WebhookDispatchJob struct {
+	deliveryRepo *repository.WebhookDeliveryRepository
+	endpointRepo *repository.WebhookEndpointRepository
+	client       *http.Client
+}
+
+func NewWebhookDispatchJob(deliveryRepo *repository.WebhookDeliveryRepository, endpointRepo *repository.WebhookEndpointRepository) *WebhookDispatchJob {
+	return &WebhookDispatchJob{
+		deliveryRepo: deliveryRepo,
+		endpointRepo: endpointRepo,
+		client:       &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of "<timestamp>.<body>", receivers recompute it
+// with their secret and compare it to the X-Webhook-Signature header (without the "sha256=" prefix)
+func SignWebhookPayload(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// RUN
+func (j *WebhookDispatchJob) Run() {
+	ctx := context.Background()
+
+	deliveries, err := j.deliveryRepo.ClaimDue(ctx, webhookBatchSize, webhookDeliveryLease)
+	if err != nil {
+		log.Printf("ERROR: Could not claim webhook deliveries: %v", err)
+		return
+	}
+	if len(deliveries) == 0 {
+		return
+	}
+
+	endpoints := make(map[int]*model.WebhookEndpoint)
+	delivered, retried, failed := 0, 0, 0
+	for _, delivery := range deliveries {
+		endpoint, ok := endpoints[delivery.EndpointID]
+		if !ok {
+			endpoint, err = j.endpointRepo.FindByID(delivery.EndpointID)
+			if err != nil {
+				log.Printf("ERROR: Could not load webhook endpoint %d: %v", delivery.EndpointID, err)
+				continue
+			}
+			endpoints[delivery.EndpointID] = endpoint
+		}
+
+		if !endpoint.IsActive {
+			if err := j.deliveryRepo.MarkFailed(ctx, delivery.ID, nil, "endpoint is inactive"); err != nil {
+				log.Printf("ERROR: Failed to mark webhook delivery %d as failed: %v", delivery.ID, err)
+			}
+			failed++
+			continue
+		}
+
+		statusCode, err := j.send(ctx, endpoint, delivery)
+		if err == nil {
+			if err := j.deliveryRepo.MarkSuccess(ctx, delivery.ID, *statusCode); err != nil {
+				log.Printf("ERROR: Failed to mark webhook delivery %d as delivered: %v", delivery.ID, err)
+			}
+			delivered++
+			continue
+		}
+
+		attempts := delivery.Attempts + 1
+		if attempts >= webhookMaxAttempts {
+			log.Printf("ERROR: Giving up on webhook delivery %d to %s after %d attempts: %v", delivery.ID, endpoint.URL, attempts, err)
+			if err := j.deliveryRepo.MarkFailed(ctx, delivery.ID, statusCode, err.Error()); err != nil {
+				log.Printf("ERROR: Failed to mark webhook delivery %d as failed: %v", delivery.ID, err)
+			}
+			failed++
+			continue
+		}
+
+		// 30s, 1m, 2m, 4m, ... capped at webhookMaxBackoff
+		backoff := webhookBaseBackoff * time.Duration(math.Pow(2, float64(attempts-1)))
+		if backoff > webhookMaxBackoff {
+			backoff = webhookMaxBackoff
+		}
+		if err := j.deliveryRepo.MarkRetry(ctx, delivery.ID, statusCode, time.Now().Add(backoff), err.Error()); err != nil {
+			log.Printf("ERROR: Failed to reschedule webhook delivery %d: %v", delivery.ID, err)
+		}
+		retried++
+	}
+
+	log.Printf("Webhook dispatch finished: %d delivered, %d rescheduled, %d failed", delivered, retried, failed)
+}
+
+// send posts the payload, any non 2xx answer counts as a failure
+func (j *WebhookDispatchJob) send(ctx context.Context, endpoint *model.WebhookEndpoint, delivery model.WebhookDelivery) (*int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "e-memo-webhook/1.0")
+	req.Header.Set("X-Webhook-Event", delivery.Event)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+SignWebhookPayload(endpoint.Secret, timestamp, delivery.Payload))
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	statusCode := resp.StatusCode
+	if statusCode < 200 || statusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &statusCode, fmt.Errorf("endpoint answered %d: %s", statusCode, bytes.TrimSpace(body))
+	}
+	io.Copy(io.Discard, resp.Body)
+	return &statusCode, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"errors"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type ActionReasonCodeService struct {
+	repo *repository.ActionReasonCodeRepository
+}
+
+func NewActionReasonCodeService(repo *repository.ActionReasonCodeRepository) *ActionReasonCodeService {
+	return &ActionReasonCodeService{repo: repo}
+}
+
+// HELPER
+func mapActionReasonCodeError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return errors.New("reason code already exists for this action")
+		case "23503":
+			return errors.New("invalid action_id")
+		}
+	}
+	return err
+}
+
+// CREATE
+func (s *ActionReasonCodeService) CreateActionReasonCode(req dto.CreateActionReasonCodeRequest) (*model.ActionReasonCode, error) {
+	newReasonCode, err := s.repo.Create(req)
+	if err != nil {
+		return nil, mapActionReasonCodeError(err)
+	}
+	return newReasonCode, nil
+}
+
+// GET ALL
+func (s *ActionReasonCodeService) GetAllActionReasonCodes(filters dto.ActionReasonCodeFilter) ([]model.ActionReasonCode, error) {
+	return s.repo.FindAll(filters)
+}
+
+// GET BY ID
+func (s *ActionReasonCodeService) GetActionReasonCodeByID(id int) (*model.ActionReasonCode, error) {
+	return s.repo.FindByID(id)
+}
+
+// UPDATE
+func (s *ActionReasonCodeService) UpdateActionReasonCode(id int, req dto.UpdateActionReasonCodeRequest) (*model.ActionReasonCode, error) {
+	updated, err := s.repo.Update(id, req)
+	if err != nil {
+		return nil, mapActionReasonCodeError(err)
+	}
+	return updated, nil
+}
+
+// CHANGE ACTIVE STATUS
+func (s *ActionReasonCodeService) UpdateActionReasonCodeActiveStatus(id int, req dto.UpdateActionReasonCodeStatusRequest) error {
+	return s.repo.UpdateActiveStatus(id, req.IsActive)
+}
+
+// DELETE
+// Codes already written to the action log can only be deactivated
+func (s *ActionReasonCodeService) DeleteActionReasonCode(id int) error {
+	err := s.repo.Delete(id)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return errors.New("reason code is used by ticket actions, deactivate it instead")
+	}
+	return err
+}
@@ -1,8 +1,13 @@
 package service
 
 import (
+	"errors"
+
 	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
 	"e-memo-job-reservation-api/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type ActionService struct {
@@ -13,6 +18,29 @@ func NewActionService(repo *repository.ActionRepository) *ActionService {
 	return &ActionService{repo: repo}
 }
 
+// HELPER
+func mapActionError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return errors.New("action name already exists")
+		case "23503":
+			return errors.New("action is still used by status transitions or action logs")
+		}
+	}
+	return err
+}
+
+// CREATE
+func (s *ActionService) CreateAction(req dto.CreateActionRequest) (*model.Action, error) {
+	newAction, err := s.repo.Create(req)
+	if err != nil {
+		return nil, mapActionError(err)
+	}
+	return newAction, nil
+}
+
 func (s *ActionService) GetAllActions() ([]dto.ActionResponse, error) {
 	actions, err := s.repo.FindAll()
 	if err != nil {
@@ -33,3 +61,27 @@ func (s *ActionService) GetAllActions() ([]dto.ActionResponse, error) {
 
 	return actionResponses, nil
 }
+
+// GET BY ID
+func (s *ActionService) GetActionByID(id int) (*model.Action, error) {
+	return s.repo.FindByID(id)
+}
+
+// UPDATE
+func (s *ActionService) UpdateAction(id int, req dto.UpdateActionRequest) (*model.Action, error) {
+	updated, err := s.repo.Update(id, req)
+	if err != nil {
+		return nil, mapActionError(err)
+	}
+	return updated, nil
+}
+
+// CHANGE ACTIVE STATUS
+func (s *ActionService) UpdateActionActiveStatus(id int, req dto.UpdateActionStatusRequest) error {
+	return s.repo.UpdateActiveStatus(id, req.IsActive)
+}
+
+// DELETE
+func (s *ActionService) DeleteAction(id int) error {
+	return mapActionError(s.repo.Delete(id))
+}
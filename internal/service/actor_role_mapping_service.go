@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type ActorRoleMappingService struct {
+	repo *repository.ActorRoleMappingRepository
+}
+
+func NewActorRoleMappingService(repo *repository.ActorRoleMappingRepository) *ActorRoleMappingService {
+	return &ActorRoleMappingService{repo: repo}
+}
+
+// HELPER
+func mapActorRoleMappingError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return errors.New("position already has an actor role for this context")
+		case "23503":
+			return errors.New("invalid employee_position_id or actor_role_id")
+		}
+	}
+	return err
+}
+
+// CREATE
+func (s *ActorRoleMappingService) CreateActorRoleMapping(req dto.ActorRoleMappingRequest) (*model.ActorRoleMapping, error) {
+	newMapping, err := s.repo.Create(req)
+	if err != nil {
+		return nil, mapActorRoleMappingError(err)
+	}
+	return newMapping, nil
+}
+
+// GET ALL
+func (s *ActorRoleMappingService) GetAllActorRoleMappings(filters dto.ActorRoleMappingFilter) ([]dto.ActorRoleMappingDetailResponse, error) {
+	return s.repo.FindAll(filters)
+}
+
+// GET BY ID
+func (s *ActorRoleMappingService) GetActorRoleMappingByID(id int) (*dto.ActorRoleMappingDetailResponse, error) {
+	return s.repo.FindByID(id)
+}
+
+// UPDATE
+func (s *ActorRoleMappingService) UpdateActorRoleMapping(id int, req dto.ActorRoleMappingRequest) (*model.ActorRoleMapping, error) {
+	updated, err := s.repo.Update(id, req)
+	if err != nil {
+		return nil, mapActorRoleMappingError(err)
+	}
+	return updated, nil
+}
+
+// DELETE
+func (s *ActorRoleMappingService) DeleteActorRoleMapping(id int) error {
+	return s.repo.Delete(id)
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"errors"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type ActorRoleService struct {
+	repo *repository.ActorRoleRepository
+}
+
+func NewActorRoleService(repo *repository.ActorRoleRepository) *ActorRoleService {
+	return &ActorRoleService{repo: repo}
+}
+
+// HELPER
+func mapActorRoleError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return errors.New("actor role name already exists")
+		case "23503":
+			return errors.New("actor role is still used by status transitions or mappings")
+		}
+	}
+	return err
+}
+
+// CREATE
+func (s *ActorRoleService) CreateActorRole(req dto.CreateActorRoleRequest) (*model.ActorRole, error) {
+	newRole, err := s.repo.Create(req)
+	if err != nil {
+		return nil, mapActorRoleError(err)
+	}
+	return newRole, nil
+}
+
+// GET ALL
+func (s *ActorRoleService) GetAllActorRoles() ([]model.ActorRole, error) {
+	return s.repo.FindAll()
+}
+
+// GET BY ID
+func (s *ActorRoleService) GetActorRoleByID(id int) (*model.ActorRole, error) {
+	return s.repo.FindByID(id)
+}
+
+// UPDATE
+func (s *ActorRoleService) UpdateActorRole(id int, req dto.UpdateActorRoleRequest) (*model.ActorRole, error) {
+	updated, err := s.repo.Update(id, req)
+	if err != nil {
+		return nil, mapActorRoleError(err)
+	}
+	return updated, nil
+}
+
+// CHANGE ACTIVE STATUS
+func (s *ActorRoleService) UpdateActorRoleActiveStatus(id int, req dto.UpdateActorRoleStatusRequest) error {
+	return s.repo.UpdateActiveStatus(id, req.IsActive)
+}
+
+// DELETE
+func (s *ActorRoleService) DeleteActorRole(id int) error {
+	return mapActorRoleError(s.repo.Delete(id))
+}
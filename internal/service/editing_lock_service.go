@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+	"e-memo-job-reservation-api/internal/websocket"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Entities used by the START_EDITING sessions that the REST commands respect.
+// The priority boards are keyed by department_target_id, ticket editing by ticket id.
+const (
+	editingEntityTicketPriority = "ticket_priority"
+	editingEntityJobPriority    = "job_priority"
+	editingEntityTicket         = "ticket"
+)
+
+// EditingLockedError is returned when another user holds the editing lock of the resource
+type EditingLockedError struct {
+	Lock dto.EditingLockResponse
+}
+
+func (e *EditingLockedError) Error() string {
+	return "resource is being edited by " + e.Lock.HolderName
+}
+
+type EditingLockService struct {
+	repo *repository.EditingLockRepository
+	hub  *websocket.Hub
+}
+
+func NewEditingLockService(repo *repository.EditingLockRepository, hub *websocket.Hub) *EditingLockService {
+	return &EditingLockService{repo: repo, hub: hub}
+}
+
+// HELPER
+func (s *EditingLockService) broadcastFinished(lock *model.EditingLock, forced bool) {
+	payload := gin.H{
+		"entity":     lock.Entity,
+		"context_id": lock.ContextID,
+		"forced":     forced,
+	}
+	message, err := websocket.NewMessage("EDITING_FINISHED", payload)
+	if err != nil {
+		log.Printf("CRITICAL: Failed to create websocket message for editing lock release: %v", err)
+		return
+	}
+	s.hub.BroadcastMessage(message)
+}
+
+func (s *EditingLockService) lockedError(ctx context.Context, lockKey string) error {
+	holder, err := s.repo.FindActiveByKey(ctx, lockKey)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// The holder released it in the meantime
+			return errors.New("editing lock is held by another user, please retry")
+		}
+		return err
+	}
+	return &EditingLockedError{Lock: *holder}
+}
+
+// ENSURE NOT LOCKED
+// Commands are allowed when nobody holds the lock or the caller holds it, checked inside the command transaction
+func (s *EditingLockService) EnsureNotLockedByOthers(ctx context.Context, tx *sql.Tx, entity string, contextID int, userNPK string) error {
+	lock, err := s.repo.FindActiveByKeyForShare(ctx, tx, model.EditingLockKey(entity, contextID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+	if lock.HolderNPK != nil && *lock.HolderNPK == userNPK {
+		return nil
+	}
+	return &EditingLockedError{Lock: *lock}
+}
+
+// ACQUIRE
+func (s *EditingLockService) AcquireLock(ctx context.Context, req dto.EditingLockRequest, userID int, userNPK string) (*dto.EditingLockResponse, error) {
+	lockKey := model.EditingLockKey(req.Entity, req.ContextID)
+	_, err := s.repo.Acquire(ctx, req.Entity, req.ContextID, userID, userNPK, "", websocket.EditingLockTTL)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, s.lockedError(ctx, lockKey)
+		}
+		return nil, err
+	}
+
+	lock, err := s.repo.FindActiveByKey(ctx, lockKey)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := gin.H{
+		"entity":     lock.Entity,
+		"context_id": lock.ContextID,
+		"user_id":    lock.UserID,
+		"expires_at": lock.ExpiresAt,
+	}
+	message, err := websocket.NewMessage("EDITING_STARTED", payload)
+	if err != nil {
+		log.Printf("CRITICAL: Failed to create websocket message for editing lock acquire: %v", err)
+	} else {
+		s.hub.BroadcastMessage(message)
+	}
+
+	return lock, nil
+}
+
+// HEARTBEAT
+func (s *EditingLockService) HeartbeatLock(ctx context.Context, req dto.EditingLockRequest, userID int) (*dto.EditingLockResponse, error) {
+	lockKey := model.EditingLockKey(req.Entity, req.ContextID)
+	_, err := s.repo.Heartbeat(ctx, lockKey, userID, websocket.EditingLockTTL)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("editing lock not found or expired")
+		}
+		return nil, err
+	}
+	return s.repo.FindActiveByKey(ctx, lockKey)
+}
+
+// RELEASE
+func (s *EditingLockService) ReleaseLock(ctx context.Context, req dto.EditingLockRequest, userID int) error {
+	lock, err := s.repo.Release(ctx, model.EditingLockKey(req.Entity, req.ContextID), userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("editing lock not found or expired")
+		}
+		return err
+	}
+	s.broadcastFinished(lock, false)
+	return nil
+}
+
+// GET ALL ACTIVE
+func (s *EditingLockService) GetActiveLocks(ctx context.Context, filters dto.EditingLockFilter) ([]dto.EditingLockResponse, error) {
+	return s.repo.FindAllActive(ctx, filters)
+}
+
+// FORCE RELEASE
+func (s *EditingLockService) ForceReleaseLock(ctx context.Context, entity string, contextID int) error {
+	lock, err := s.repo.ForceRelease(ctx, model.EditingLockKey(entity, contextID))
+	if err != nil {
+		return err
+	}
+	log.Printf("Editing lock %s held by user %d was force released", lock.LockKey, lock.UserID)
+
+	s.broadcastFinished(lock, true)
+
+	payload := gin.H{
+		"entity":     lock.Entity,
+		"context_id": lock.ContextID,
+	}
+	message, err := websocket.NewMessage("EDITING_LOCK_LOST", payload)
+	if err != nil {
+		log.Printf("CRITICAL: Failed to create websocket message for editing lock force release: %v", err)
+	} else {
+		s.hub.SendToUser(lock.UserID, message)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+)
+
+type EmailNotificationService struct {
+	recipientRepo  *repository.NotificationRecipientRepository
+	emailQueueRepo *repository.EmailQueueRepository
+	employeeRepo   *repository.EmployeeRepository
+	templates      map[string]map[string]emailTemplate
+}
+
+func NewEmailNotificationService(recipientRepo *repository.NotificationRecipientRepository, emailQueueRepo *repository.EmailQueueRepository, employeeRepo *repository.EmployeeRepository) *EmailNotificationService {
+	return &EmailNotificationService{
+		recipientRepo:  recipientRepo,
+		emailQueueRepo: emailQueueRepo,
+		employeeRepo:   employeeRepo,
+		templates:      parseTicketEmailTemplates(),
+	}
+}
+
+// NOTIFY TICKET EVENT
+// Renders one email per recipient and queues it, the worker does the actual sending.
+// Errors are returned to the outbox dispatcher, which retries the event.
+func (s *EmailNotificationService) NotifyTicketEvent(ctx context.Context, notification dto.TicketEventNotification) error {
+	templates, ok := s.templates[notification.Event]
+	if !ok || notification.Ticket == nil {
+		return nil
+	}
+	ticket := notification.Ticket
+
+	recipients, err := s.recipientRepo.FindTicketRecipients(ctx, ticket.TicketID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve email recipients for ticket %d: %w", ticket.TicketID, err)
+	}
+
+	actorName := notification.ActorNPK
+	if actor, err := s.employeeRepo.FindByNPK(notification.ActorNPK); err == nil {
+		actorName = actor.Name
+	}
+
+	data := ticketEmailData{
+		TicketID:             ticket.TicketID,
+		Description:          ticket.Description,
+		DepartmentTargetName: ticket.DepartmentTargetName,
+		RequestorName:        ticket.RequestorName,
+		ActorName:            actorName,
+		ActionName:           notification.ActionName,
+		Reason:               notification.Reason,
+	}
+	if ticket.CurrentStatus != nil {
+		data.CurrentStatus = *ticket.CurrentStatus
+	}
+	if ticket.Deadline != nil {
+		data.Deadline = ticket.Deadline.Format("02-01-2006")
+	}
+
+	var emails []model.EmailQueue
+	for _, recipient := range recipients {
+		// The actor already knows what they did
+		if recipient.NPK == notification.ActorNPK || !recipient.EmailEnabled || recipient.Email == nil {
+			continue
+		}
+
+		tmpl, ok := templates[recipient.Language]
+		if !ok {
+			tmpl = templates[languageIndonesian]
+		}
+
+		recipientData := data
+		recipientData.RecipientName = recipient.Name
+		if notification.ActorNPK == "" {
+			recipientData.ActorName = systemActorName(recipient.Language)
+		}
+		recipientData.NeedsAction = hasRole(recipient.Roles, "NEXT_ACTOR")
+		recipientData.IsRequestor = hasRole(recipient.Roles, "REQUESTOR")
+
+		subject, body, err := tmpl.render(recipientData)
+		if err != nil {
+			log.Printf("ERROR: Failed to render %s email for %s: %v", notification.Event, recipient.NPK, err)
+			continue
+		}
+
+		recipientNPK := recipient.NPK
+		ticketID := ticket.TicketID
+		emails = append(emails, model.EmailQueue{
+			RecipientNPK:   &recipientNPK,
+			RecipientEmail: *recipient.Email,
+			Subject:        subject,
+			Body:           body,
+			Event:          notification.Event,
+			TicketID:       &ticketID,
+		})
+	}
+
+	if err := s.emailQueueRepo.Enqueue(ctx, notification.OutboxEventID, emails); err != nil {
+		return fmt.Errorf("failed to queue %s emails for ticket %d: %w", notification.Event, ticket.TicketID, err)
+	}
+	return nil
+}
+
+func hasRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
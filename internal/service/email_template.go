@@ -0,0 +1,153 @@
+package service
+
+import (
+	"strings"
+	"text/template"
+)
+
+// Languages an employee can receive notifications in
+const (
+	languageIndonesian = "id"
+	languageEnglish    = "en"
+)
+
+type emailTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+type ticketEmailData struct {
+	RecipientName        string
+	TicketID             int
+	Description          string
+	DepartmentTargetName string
+	RequestorName        string
+	CurrentStatus        string
+	ActorName            string
+	ActionName           string
+	Reason               string
+	Deadline             string
+	NeedsAction          bool
+	IsRequestor          bool
+}
+
+var emailTemplateFuncs = template.FuncMap{
+	"truncate": func(s string, max int) string {
+		runes := []rune(strings.TrimSpace(s))
+		if len(runes) <= max {
+			return string(runes)
+		}
+		return string(runes[:max]) + "..."
+	},
+}
+
+// templates are keyed by event and language
+var ticketEmailTemplateSources = map[string]map[string][2]string{
+	"TICKET_CREATED": {
+		languageIndonesian: {
+			`[E-Memo] Tiket baru #{{.TicketID}}: {{truncate .Description 60}}`,
+			`Yth. {{.RecipientName}},
+
+{{.RequestorName}} telah membuat tiket baru untuk departemen {{.DepartmentTargetName}}.
+
+Nomor tiket : #{{.TicketID}}
+Deskripsi   : {{.Description}}
+Status      : {{.CurrentStatus}}
+{{- if .Deadline}}
+Tenggat     : {{.Deadline}}
+{{- end}}
+{{if .NeedsAction}}
+Tiket ini menunggu tindakan Anda.
+{{end}}
+Email ini dikirim otomatis oleh E-Memo Job Reservation, mohon tidak membalas email ini.
+`,
+		},
+		languageEnglish: {
+			`[E-Memo] New ticket #{{.TicketID}}: {{truncate .Description 60}}`,
+			`Dear {{.RecipientName}},
+
+{{.RequestorName}} has created a new ticket for the {{.DepartmentTargetName}} department.
+
+Ticket number : #{{.TicketID}}
+Description   : {{.Description}}
+Status        : {{.CurrentStatus}}
+{{- if .Deadline}}
+Deadline      : {{.Deadline}}
+{{- end}}
+{{if .NeedsAction}}
+This ticket is waiting for your action.
+{{end}}
+This email was sent automatically by E-Memo Job Reservation, please do not reply.
+`,
+		},
+	},
+	"TICKET_STATUS_CHANGED": {
+		languageIndonesian: {
+			`[E-Memo] Tiket #{{.TicketID}} - {{.ActionName}} ({{.CurrentStatus}})`,
+			`Yth. {{.RecipientName}},
+
+{{if .IsRequestor}}Tiket Anda{{else}}Tiket{{end}} #{{.TicketID}} telah diproses oleh {{.ActorName}} dengan tindakan "{{.ActionName}}".
+
+Deskripsi     : {{.Description}}
+Departemen    : {{.DepartmentTargetName}}
+Pemohon       : {{.RequestorName}}
+Status terkini: {{.CurrentStatus}}
+{{- if .Reason}}
+Alasan        : {{.Reason}}
+{{- end}}
+{{if .NeedsAction}}
+Tiket ini menunggu tindakan Anda.
+{{end}}
+Email ini dikirim otomatis oleh E-Memo Job Reservation, mohon tidak membalas email ini.
+`,
+		},
+		languageEnglish: {
+			`[E-Memo] Ticket #{{.TicketID}} - {{.ActionName}} ({{.CurrentStatus}})`,
+			`Dear {{.RecipientName}},
+
+{{if .IsRequestor}}Your ticket{{else}}Ticket{{end}} #{{.TicketID}} was processed by {{.ActorName}} with the action "{{.ActionName}}".
+
+Description    : {{.Description}}
+Department     : {{.DepartmentTargetName}}
+Requestor      : {{.RequestorName}}
+Current status : {{.CurrentStatus}}
+{{- if .Reason}}
+Reason         : {{.Reason}}
+{{- end}}
+{{if .NeedsAction}}
+This ticket is waiting for your action.
+{{end}}
+This email was sent automatically by E-Memo Job Reservation, please do not reply.
+`,
+		},
+	},
+}
+
+func parseTicketEmailTemplates() map[string]map[string]emailTemplate {
+	templates := make(map[string]map[string]emailTemplate, len(ticketEmailTemplateSources))
+	for event, languages := range ticketEmailTemplateSources {
+		templates[event] = make(map[string]emailTemplate, len(languages))
+		for language, source := range languages {
+			name := event + "_" + language
+			templates[event][language] = emailTemplate{
+				subject: template.Must(template.New(name + "_subject").Funcs(emailTemplateFuncs).Parse(source[0])),
+				body:    template.Must(template.New(name + "_body").Funcs(emailTemplateFuncs).Parse(source[1])),
+			}
+		}
+	}
+	return templates
+}
+
+func (t emailTemplate) render(data ticketEmailData) (subject string, body string, err error) {
+	var sb strings.Builder
+	if err = t.subject.Execute(&sb, data); err != nil {
+		return "", "", err
+	}
+	subject = sb.String()
+
+	sb.Reset()
+	if err = t.body.Execute(&sb, data); err != nil {
+		return "", "", err
+	}
+	return subject, sb.String(), nil
+}
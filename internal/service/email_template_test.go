@@ -0,0 +1,111 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTicketEmailTemplatesRender(t *testing.T) {
+	templates := parseTicketEmailTemplates()
+	data := ticketEmailData{
+		RecipientName:        "Budi",
+		TicketID:             42,
+		Description:          strings.Repeat("Perbaikan pompa air ", 5),
+		DepartmentTargetName: "Maintenance",
+		RequestorName:        "Sari",
+		CurrentStatus:        "Approval",
+		ActorName:            "Andi",
+		ActionName:           "Tolak",
+		Reason:               "Lampiran kurang",
+		Deadline:             "2026-02-01",
+		NeedsAction:          true,
+		IsRequestor:          true,
+	}
+
+	tests := []struct {
+		name        string
+		event       string
+		language    string
+		data        ticketEmailData
+		wantSubject string
+		wantBody    []string
+		notInBody   []string
+	}{
+		{
+			name:        "created in Indonesian",
+			event:       "TICKET_CREATED",
+			language:    languageIndonesian,
+			data:        data,
+			wantSubject: "[E-Memo] Tiket baru #42: Perbaikan pompa air Perbaikan pompa air Perbaikan pompa air ...",
+			wantBody:    []string{"Yth. Budi,", "Tenggat     : 2026-02-01", "Tiket ini menunggu tindakan Anda."},
+		},
+		{
+			name:     "created in English without deadline",
+			event:    "TICKET_CREATED",
+			language: languageEnglish,
+			data: ticketEmailData{
+				RecipientName: "Budi", TicketID: 42, Description: "Fix pump", DepartmentTargetName: "Maintenance",
+				RequestorName: "Sari", CurrentStatus: "Approval",
+			},
+			wantSubject: "[E-Memo] New ticket #42: Fix pump",
+			wantBody:    []string{"Dear Budi,", "Sari has created a new ticket for the Maintenance department."},
+			notInBody:   []string{"Deadline", "waiting for your action"},
+		},
+		{
+			name:        "status changed for the requestor",
+			event:       "TICKET_STATUS_CHANGED",
+			language:    languageEnglish,
+			data:        data,
+			wantSubject: "[E-Memo] Ticket #42 - Tolak (Approval)",
+			wantBody:    []string{`Your ticket #42 was processed by Andi with the action "Tolak".`, "Reason         : Lampiran kurang"},
+		},
+		{
+			name:     "status changed for someone else without reason",
+			event:    "TICKET_STATUS_CHANGED",
+			language: languageIndonesian,
+			data: ticketEmailData{
+				RecipientName: "Budi", TicketID: 42, Description: "Perbaikan pompa", ActorName: "Andi",
+				ActionName: "Setujui", CurrentStatus: "Dikerjakan",
+			},
+			wantSubject: "[E-Memo] Tiket #42 - Setujui (Dikerjakan)",
+			wantBody:    []string{`Tiket #42 telah diproses oleh Andi dengan tindakan "Setujui".`},
+			notInBody:   []string{"Alasan", "Tiket Anda"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, ok := templates[tt.event][tt.language]
+			if !ok {
+				t.Fatalf("no template for %s in %s", tt.event, tt.language)
+			}
+			subject, body, err := tmpl.render(tt.data)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if subject != tt.wantSubject {
+				t.Errorf("subject = %q, want %q", subject, tt.wantSubject)
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(body, want) {
+					t.Errorf("body does not contain %q:\n%s", want, body)
+				}
+			}
+			for _, unwanted := range tt.notInBody {
+				if strings.Contains(body, unwanted) {
+					t.Errorf("body contains %q:\n%s", unwanted, body)
+				}
+			}
+		})
+	}
+}
+
+func TestTicketEmailTemplatesCoverEveryLanguage(t *testing.T) {
+	for event, languages := range parseTicketEmailTemplates() {
+		for _, language := range []string{languageIndonesian, languageEnglish} {
+			if _, ok := languages[language]; !ok {
+				t.Errorf("%s has no %s template", event, language)
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/repository"
+)
+
+type EmployeeDelegationService struct {
+	repo         *repository.EmployeeDelegationRepository
+	employeeRepo *repository.EmployeeRepository
+}
+
+func NewEmployeeDelegationService(repo *repository.EmployeeDelegationRepository, employeeRepo *repository.EmployeeRepository) *EmployeeDelegationService {
+	return &EmployeeDelegationService{repo: repo, employeeRepo: employeeRepo}
+}
+
+// HELPER
+func parseDelegationDate(value string) (time.Time, error) {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, errors.New("invalid date format, expected YYYY-MM-DD")
+	}
+	return date, nil
+}
+
+// CREATE
+// The user delegates all of their actor roles, the delegate acts on their behalf until the end date
+func (s *EmployeeDelegationService) CreateDelegation(ctx context.Context, delegatorNPK string, req dto.CreateEmployeeDelegationRequest) (*dto.EmployeeDelegationResponse, error) {
+	if req.DelegateNPK == delegatorNPK {
+		return nil, errors.New("cannot delegate to yourself")
+	}
+
+	startDate, err := parseDelegationDate(req.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	endDate, err := parseDelegationDate(req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	if endDate.Before(startDate) {
+		return nil, errors.New("end date must not be before start date")
+	}
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+	if endDate.Before(today) {
+		return nil, errors.New("end date must not be in the past")
+	}
+
+	if _, err := s.employeeRepo.FindByNPK(delegatorNPK); err != nil {
+		return nil, errors.New("employee not found")
+	}
+	delegate, err := s.employeeRepo.FindByNPK(req.DelegateNPK)
+	if err != nil {
+		return nil, errors.New("delegate employee not found")
+	}
+	if !delegate.IsActive {
+		return nil, errors.New("delegate employee is not active")
+	}
+
+	overlap, err := s.repo.HasOverlap(ctx, delegatorNPK, req.DelegateNPK, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if overlap {
+		return nil, errors.New("delegation overlaps an existing delegation to the same employee")
+	}
+
+	id, err := s.repo.Create(ctx, delegatorNPK, req.DelegateNPK, startDate, endDate, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(ctx, id)
+}
+
+// GET ALL
+func (s *EmployeeDelegationService) GetDelegations(ctx context.Context, userNPK string, filters dto.EmployeeDelegationFilter) ([]dto.EmployeeDelegationResponse, error) {
+	return s.repo.FindByNPK(ctx, userNPK, filters)
+}
+
+// REVOKE
+// Only the delegator can take their roles back
+func (s *EmployeeDelegationService) RevokeDelegation(ctx context.Context, id int, userNPK string) error {
+	delegation, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if delegation.DelegatorNPK != userNPK {
+		return sql.ErrNoRows
+	}
+	if !delegation.IsActive {
+		return errors.New("delegation is already revoked")
+	}
+	return s.repo.Revoke(ctx, id)
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	escalationTypeNotify     = "NOTIFY"
+	escalationTypeAutoAction = "AUTO_ACTION"
+)
+
+// Actions the worker cannot perform, they need the position of the user, the job report or the original ticket
+var manualOnlyActionNames = map[string]bool{
+	"Revisi":                true,
+	"Selesaikan Job":        true,
+	actionNameMarkDuplicate: true,
+}
+
+type EscalationRuleService struct {
+	repo                 *repository.EscalationRuleRepository
+	actionRepo           *repository.ActionRepository
+	statusTransitionRepo *repository.StatusTransitionRepository
+}
+
+func NewEscalationRuleService(repo *repository.EscalationRuleRepository, actionRepo *repository.ActionRepository, statusTransitionRepo *repository.StatusTransitionRepository) *EscalationRuleService {
+	return &EscalationRuleService{
+		repo:                 repo,
+		actionRepo:           actionRepo,
+		statusTransitionRepo: statusTransitionRepo,
+	}
+}
+
+// HELPER
+func mapEscalationRuleError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return errors.New("invalid status_ticket_id, department_id, notify_actor_role_id or action_id")
+	}
+	return err
+}
+
+// validateEscalationRule checks the target of the rule, an automatic action must be allowed from the rule's status
+// and its reminder has to come before it
+func (s *EscalationRuleService) validateEscalationRule(statusTicketID int, escalationType string, afterHours int, notifyActorRoleID *int, actionID *int, reminderHours *int, onBehalfOfRequestor bool) error {
+	switch escalationType {
+	case escalationTypeNotify:
+		if notifyActorRoleID == nil {
+			return errors.New("notify_actor_role_id is required for NOTIFY rules")
+		}
+		if actionID != nil || reminderHours != nil || onBehalfOfRequestor {
+			return errors.New("action_id, reminder_hours and on_behalf_of_requestor are only used by AUTO_ACTION rules")
+		}
+		return nil
+	case escalationTypeAutoAction:
+		if actionID == nil {
+			return errors.New("action_id is required for AUTO_ACTION rules")
+		}
+		if notifyActorRoleID != nil {
+			return errors.New("notify_actor_role_id is only used by NOTIFY rules")
+		}
+		if reminderHours != nil && *reminderHours >= afterHours {
+			return errors.New("reminder_hours must be less than after_hours")
+		}
+	}
+
+	action, err := s.actionRepo.FindByID(*actionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("invalid status_ticket_id, department_id, notify_actor_role_id or action_id")
+		}
+		return err
+	}
+	if manualOnlyActionNames[action.Name] {
+		return errors.New("action cannot be performed automatically")
+	}
+	if _, _, err := s.statusTransitionRepo.FindValidTransition(statusTicketID, action.Name); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("action is not allowed from the status of the rule")
+		}
+		return err
+	}
+	return nil
+}
+
+// CREATE
+func (s *EscalationRuleService) CreateEscalationRule(req dto.CreateEscalationRuleRequest) (*model.EscalationRule, error) {
+	if err := s.validateEscalationRule(req.StatusTicketID, req.EscalationType, req.AfterHours, req.NotifyActorRoleID, req.ActionID, req.ReminderHours, req.OnBehalfOfRequestor); err != nil {
+		return nil, err
+	}
+
+	newRule, err := s.repo.Create(req)
+	if err != nil {
+		return nil, mapEscalationRuleError(err)
+	}
+	return newRule, nil
+}
+
+// GET ALL
+func (s *EscalationRuleService) GetAllEscalationRules(filters dto.EscalationRuleFilter) ([]model.EscalationRule, error) {
+	return s.repo.FindAll(filters)
+}
+
+// GET BY ID
+func (s *EscalationRuleService) GetEscalationRuleByID(id int) (*model.EscalationRule, error) {
+	return s.repo.FindByID(id)
+}
+
+// UPDATE
+func (s *EscalationRuleService) UpdateEscalationRule(id int, req dto.UpdateEscalationRuleRequest) (*model.EscalationRule, error) {
+	if err := s.validateEscalationRule(req.StatusTicketID, req.EscalationType, req.AfterHours, req.NotifyActorRoleID, req.ActionID, req.ReminderHours, req.OnBehalfOfRequestor); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.Update(id, req)
+	if err != nil {
+		return nil, mapEscalationRuleError(err)
+	}
+	return updated, nil
+}
+
+// CHANGE ACTIVE STATUS
+func (s *EscalationRuleService) UpdateEscalationRuleActiveStatus(id int, req dto.UpdateEscalationRuleStatusRequest) error {
+	return s.repo.UpdateActiveStatus(id, req.IsActive)
+}
+
+// DELETE
+func (s *EscalationRuleService) DeleteEscalationRule(id int) error {
+	return s.repo.Delete(id)
+}
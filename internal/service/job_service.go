@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"log"
+	"os"
 
 	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
 	"e-memo-job-reservation-api/internal/repository"
 	"e-memo-job-reservation-api/internal/websocket"
 
@@ -14,27 +16,204 @@ import (
 )
 
 type JobService struct {
-	jobCommandRepo *repository.JobRepository
-	jobQueryRepo   *repository.JobQueryRepository
-	employeeRepo   *repository.EmployeeRepository
-	posPermRepo    *repository.PositionPermissionRepository
-	db             *sql.DB
-	hub            *websocket.Hub
-	queryService   *TicketQueryService
+	jobCommandRepo  *repository.JobRepository
+	jobQueryRepo    *repository.JobQueryRepository
+	employeeRepo    *repository.EmployeeRepository
+	posPermRepo     *repository.PositionPermissionRepository
+	db              *sql.DB
+	hub             *websocket.Hub
+	queryService    *TicketQueryService
+	lockService     *EditingLockService
+	outboxService   *OutboxService
+	workflowService *TicketWorkflowService
 }
 
-func NewJobService(jobCommandRepo *repository.JobRepository, jobQueryRepo *repository.JobQueryRepository, employeeRepo *repository.EmployeeRepository, posPermRepo *repository.PositionPermissionRepository, db *sql.DB, hub *websocket.Hub, queryService *TicketQueryService) *JobService {
+func NewJobService(jobCommandRepo *repository.JobRepository, jobQueryRepo *repository.JobQueryRepository, employeeRepo *repository.EmployeeRepository, posPermRepo *repository.PositionPermissionRepository, db *sql.DB, hub *websocket.Hub, queryService *TicketQueryService, lockService *EditingLockService, outboxService *OutboxService, workflowService *TicketWorkflowService) *JobService {
 	return &JobService{
-		jobCommandRepo: jobCommandRepo,
-		jobQueryRepo:   jobQueryRepo,
-		employeeRepo:   employeeRepo,
-		posPermRepo:    posPermRepo,
-		db:             db,
-		hub:            hub,
-		queryService:   queryService,
+		jobCommandRepo:  jobCommandRepo,
+		jobQueryRepo:    jobQueryRepo,
+		employeeRepo:    employeeRepo,
+		posPermRepo:     posPermRepo,
+		db:              db,
+		hub:             hub,
+		queryService:    queryService,
+		lockService:     lockService,
+		outboxService:   outboxService,
+		workflowService: workflowService,
 	}
 }
 
+// CreateJob
+// Adds another job to a ticket of the user's department, e.g. civil work next to the electrical one
+func (s *JobService) CreateJob(ctx context.Context, req dto.CreateJobRequest, userNPK string) (*dto.JobDetailResponse, error) {
+	user, err := s.employeeRepo.FindByNPK(userNPK)
+	if err != nil {
+		return nil, errors.New("action performer not found")
+	}
+
+	departmentTargetID, isFinished, err := s.jobCommandRepo.GetTicketState(ctx, req.TicketID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("ticket not found")
+		}
+		return nil, err
+	}
+	if user.DepartmentID != departmentTargetID {
+		return nil, errors.New("user is not authorized to manage jobs of this ticket's department")
+	}
+	if isFinished {
+		return nil, errors.New("jobs cannot be added to a finished ticket")
+	}
+
+	var picNpk sql.NullString
+	if req.PicJob != nil {
+		pic, err := s.employeeRepo.FindByNPK(*req.PicJob)
+		if err != nil {
+			return nil, errors.New("new PIC employee data not found")
+		}
+		if pic.DepartmentID != departmentTargetID {
+			return nil, errors.New("new PIC must be from the same department as the job")
+		}
+		picNpk = sql.NullString{String: *req.PicJob, Valid: true}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	jobID, err := s.jobCommandRepo.AddJob(ctx, tx, req.TicketID, req.Title, picNpk)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.outboxService.RecordTicketEvent(ctx, tx, "TICKET_UPDATED", req.TicketID, dto.TicketOutboxPayload{ActorNPK: userNPK})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	s.outboxService.Wake()
+
+	return s.jobQueryRepo.FindByID(jobID)
+}
+
+// CompleteJob
+// The PIC finishes their job with its own report files and spending amount while the ticket is being worked on,
+// the files are required when the ticket's Selesaikan Job transition requires them.
+// The ticket itself is finished through the Selesaikan Job action once no other job is open
+func (s *JobService) CompleteJob(ctx context.Context, jobID int, req dto.CompleteJobRequest, filesMetadata []model.FileMetadata, userNPK string) error {
+	job, err := s.jobCommandRepo.FindByID(jobID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("job not found")
+		}
+		return err
+	}
+	if job.Status != "OPEN" {
+		return errors.New("job is already completed")
+	}
+	if !job.PicJob.Valid {
+		return errors.New("job has no PIC assigned yet")
+	}
+	if job.PicJob.String != userNPK {
+		return errors.New("only the PIC of the job can complete it")
+	}
+
+	isWorkStatus, requireFile, err := s.workflowService.jobCompletionState(ctx, job.TicketID)
+	if err != nil {
+		return err
+	}
+	if !isWorkStatus {
+		return errors.New("job can only be completed while the ticket is being worked on")
+	}
+	if requireFile && len(filesMetadata) == 0 {
+		return errors.New("file upload is required for this action")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = s.jobCommandRepo.Complete(ctx, tx, jobID, filesMetadata, req.SpendingAmount, userNPK)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("job is already completed")
+		}
+		return err
+	}
+
+	err = s.outboxService.RecordTicketEvent(ctx, tx, "JOB_COMPLETED", job.TicketID, dto.TicketOutboxPayload{ActorNPK: userNPK, CompletedJobID: jobID})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.outboxService.Wake()
+
+	// A reopened job gets new report files, the previous ones are removed from storage
+	if len(filesMetadata) > 0 {
+		for _, oldFile := range job.ReportFiles {
+			if err := os.Remove(oldFile.FilePath); err != nil {
+				log.Printf("WARNING: Failed to delete old report file from storage. File path: %s, Error: %v", oldFile.FilePath, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// DeleteJob
+func (s *JobService) DeleteJob(ctx context.Context, jobID int, userNPK string) error {
+	user, err := s.employeeRepo.FindByNPK(userNPK)
+	if err != nil {
+		return errors.New("action performer not found")
+	}
+
+	job, err := s.jobQueryRepo.FindByID(jobID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("job not found")
+		}
+		return err
+	}
+	if user.DepartmentID != job.AssignedDepartmentID {
+		return errors.New("user is not authorized to manage jobs of this ticket's department")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := s.jobCommandRepo.Delete(ctx, tx, jobID); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("only an open job of a ticket with other jobs can be deleted")
+		}
+		return err
+	}
+
+	err = s.outboxService.RecordTicketEvent(ctx, tx, "TICKET_UPDATED", job.TicketID, dto.TicketOutboxPayload{ActorNPK: userNPK})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.outboxService.Wake()
+
+	return nil
+}
+
 // AssignPIC
 func (s *JobService) AssignPIC(ctx context.Context, jobID int, req dto.AssignPICRequest, userNPK string) error {
 	user, err := s.employeeRepo.FindByNPK(userNPK)
@@ -63,26 +242,27 @@ func (s *JobService) AssignPIC(ctx context.Context, jobID int, req dto.AssignPIC
 		return errors.New("new PIC must be from the same department as the job")
 	}
 
-	err = s.jobCommandRepo.AssignPIC(jobID, req.PicJob)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = s.jobCommandRepo.AssignPIC(ctx, tx, jobID, req.PicJob)
 	if err != nil {
 		return err
 	}
 
-	job, _ = s.jobQueryRepo.FindByID(jobID)
-	if job != nil {
-		updatedTicketDetail, err := s.queryService.GetTicketByID(job.TicketID)
-		if err != nil {
-			log.Printf("CRITICAL: Failed to fetch updated ticket for broadcast after PIC assign. TicketID: %d, Error: %v", job.TicketID, err)
-		} else {
-			message, err := websocket.NewMessage("TICKET_UPDATED", updatedTicketDetail)
-			if err != nil {
-				log.Printf("CRITICAL: Failed to create websocket message for PIC assign: %v", err)
-			} else {
-				s.hub.BroadcastMessage(message)
-			}
-		}
+	err = s.outboxService.RecordTicketEvent(ctx, tx, "JOB_PIC_ASSIGNED", job.TicketID, dto.TicketOutboxPayload{ActorNPK: userNPK})
+	if err != nil {
+		return err
 	}
 
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.outboxService.Wake()
+
 	return nil
 }
 
@@ -115,6 +295,10 @@ func (s *JobService) ReorderJobs(ctx context.Context, req dto.ReorderJobsRequest
 	}
 	defer tx.Rollback()
 
+	if err := s.lockService.EnsureNotLockedByOthers(ctx, tx, editingEntityJobPriority, req.DepartmentTargetID, userNPK); err != nil {
+		return err
+	}
+
 	for i, item := range req.Items {
 		newPriority := i + 1
 		rowsAffected, err := s.jobCommandRepo.UpdatePriority(ctx, tx, item.JobID, item.Version, newPriority)
@@ -126,20 +310,18 @@ func (s *JobService) ReorderJobs(ctx context.Context, req dto.ReorderJobsRequest
 		}
 	}
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
-
 	payload := gin.H{
 		"department_target_id": req.DepartmentTargetID,
 		"message":              "Job priorities have been updated.",
 	}
-	message, err := websocket.NewMessage("JOB_PRIORITY_UPDATED", payload)
-	if err != nil {
-		log.Printf("CRITICAL: Failed to create websocket message for job reorder: %v", err)
-	} else {
-		s.hub.BroadcastMessage(message)
+	if err := s.outboxService.RecordDepartmentEvent(ctx, tx, "JOB_PRIORITY_UPDATED", req.DepartmentTargetID, payload); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
 	}
+	s.outboxService.Wake()
 
 	return nil
 }
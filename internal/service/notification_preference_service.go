@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+)
+
+type NotificationPreferenceService struct {
+	repo         *repository.NotificationPreferenceRepository
+	employeeRepo *repository.EmployeeRepository
+}
+
+func NewNotificationPreferenceService(repo *repository.NotificationPreferenceRepository, employeeRepo *repository.EmployeeRepository) *NotificationPreferenceService {
+	return &NotificationPreferenceService{repo: repo, employeeRepo: employeeRepo}
+}
+
+// GET
+// Employees without a stored preference get the defaults
+func (s *NotificationPreferenceService) GetPreference(ctx context.Context, userNPK string) (*model.NotificationPreference, error) {
+	preference, err := s.repo.FindByNPK(ctx, userNPK)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &model.NotificationPreference{
+				EmployeeNPK:  userNPK,
+				Language:     languageIndonesian,
+				EmailEnabled: true,
+			}, nil
+		}
+		return nil, err
+	}
+	return preference, nil
+}
+
+// UPDATE
+func (s *NotificationPreferenceService) UpdatePreference(ctx context.Context, userNPK string, req dto.UpdateNotificationPreferenceRequest) (*model.NotificationPreference, error) {
+	if _, err := s.employeeRepo.FindByNPK(userNPK); err != nil {
+		return nil, errors.New("employee not found")
+	}
+	return s.repo.Upsert(ctx, userNPK, req)
+}
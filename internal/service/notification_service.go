@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+	"e-memo-job-reservation-api/internal/websocket"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Inbox notification types
+const (
+	notificationTypeStatusChanged = "STATUS_CHANGED"
+	notificationTypeRejected      = "REJECTED"
+	notificationTypePicAssigned   = "PIC_ASSIGNED"
+	notificationTypeEscalated     = "ESCALATED"
+	notificationTypeAutoAction    = "AUTO_ACTION_REMINDER"
+	notificationTypeMentioned     = "MENTIONED"
+)
+
+// Length of the comment preview in a mention notification
+const mentionPreviewLength = 100
+
+// same actions the rejection history is built from
+var rejectionActionNames = map[string]bool{
+	"Tolak":           true,
+	"Tolak Hasil Job": true,
+}
+
+type NotificationService struct {
+	repo          *repository.NotificationRepository
+	recipientRepo *repository.NotificationRecipientRepository
+	appUserRepo   *repository.AppUserRepository
+	employeeRepo  *repository.EmployeeRepository
+	hub           *websocket.Hub
+}
+
+func NewNotificationService(repo *repository.NotificationRepository, recipientRepo *repository.NotificationRecipientRepository, appUserRepo *repository.AppUserRepository, employeeRepo *repository.EmployeeRepository, hub *websocket.Hub) *NotificationService {
+	return &NotificationService{
+		repo:          repo,
+		recipientRepo: recipientRepo,
+		appUserRepo:   appUserRepo,
+		employeeRepo:  employeeRepo,
+		hub:           hub,
+	}
+}
+
+// HELPER
+func notificationTypeForEvent(notification dto.TicketEventNotification) (string, bool) {
+	switch notification.Event {
+	case "TICKET_STATUS_CHANGED":
+		if rejectionActionNames[notification.ActionName] {
+			return notificationTypeRejected, true
+		}
+		return notificationTypeStatusChanged, true
+	case "JOB_PIC_ASSIGNED":
+		return notificationTypePicAssigned, true
+	case "TICKET_ESCALATED":
+		return notificationTypeEscalated, true
+	case "TICKET_AUTO_ACTION_REMINDER":
+		return notificationTypeAutoAction, true
+	case "TICKET_COMMENT_ADDED", "TICKET_COMMENT_UPDATED":
+		if len(notification.MentionedNPKs) > 0 {
+			return notificationTypeMentioned, true
+		}
+	}
+	return "", false
+}
+
+// systemActorName stands in for the actor of events the worker performed
+func systemActorName(language string) string {
+	if language == languageEnglish {
+		return "System"
+	}
+	return "Sistem"
+}
+
+func renderNotification(notificationType string, language string, notification dto.TicketEventNotification, actorName string) (string, string) {
+	ticket := notification.Ticket
+	reason := notification.Reason
+	status := ""
+	if ticket.CurrentStatus != nil {
+		status = *ticket.CurrentStatus
+	}
+	picName := ""
+	if ticket.PicName != nil {
+		picName = *ticket.PicName
+	}
+
+	var title, message string
+	switch notificationType {
+	case notificationTypeRejected:
+		if language == languageEnglish {
+			title = fmt.Sprintf("Ticket #%d was rejected", ticket.TicketID)
+			message = fmt.Sprintf("%s rejected the ticket, current status: %s.", actorName, status)
+		} else {
+			title = fmt.Sprintf("Tiket #%d ditolak", ticket.TicketID)
+			message = fmt.Sprintf("%s menolak tiket, status saat ini: %s.", actorName, status)
+		}
+		if reason != "" {
+			message += " " + reason
+		}
+	case notificationTypeEscalated:
+		if language == languageEnglish {
+			title = fmt.Sprintf("Ticket #%d was escalated", ticket.TicketID)
+			message = fmt.Sprintf("The ticket is waiting for your action in status %s.", status)
+		} else {
+			title = fmt.Sprintf("Tiket #%d dieskalasi", ticket.TicketID)
+			message = fmt.Sprintf("Tiket menunggu tindakan Anda di status %s.", status)
+		}
+		if reason != "" {
+			message += " " + reason
+		}
+	case notificationTypeAutoAction:
+		hoursLeft := 1
+		if notification.DueAt != nil {
+			hoursLeft = int(math.Max(1, math.Ceil(time.Until(*notification.DueAt).Hours())))
+		}
+		if language == languageEnglish {
+			title = fmt.Sprintf("Ticket #%d will be processed automatically", ticket.TicketID)
+			message = fmt.Sprintf("The system performs %s in about %d hours unless the ticket is handled first, current status: %s.", notification.ActionName, hoursLeft, status)
+		} else {
+			title = fmt.Sprintf("Tiket #%d akan diproses otomatis", ticket.TicketID)
+			message = fmt.Sprintf("Sistem akan menjalankan %s dalam sekitar %d jam jika tiket belum ditindaklanjuti, status saat ini: %s.", notification.ActionName, hoursLeft, status)
+		}
+	case notificationTypeMentioned:
+		if language == languageEnglish {
+			title = fmt.Sprintf("You were mentioned on ticket #%d", ticket.TicketID)
+			message = fmt.Sprintf("%s mentioned you in a comment.", actorName)
+		} else {
+			title = fmt.Sprintf("Anda disebut di tiket #%d", ticket.TicketID)
+			message = fmt.Sprintf("%s menyebut Anda dalam komentar.", actorName)
+		}
+		if notification.Comment != nil {
+			message += " " + truncateCommentBody(notification.Comment.Body)
+		}
+	case notificationTypePicAssigned:
+		if language == languageEnglish {
+			title = fmt.Sprintf("PIC assigned to ticket #%d", ticket.TicketID)
+			message = fmt.Sprintf("%s assigned %s as the PIC of the job.", actorName, picName)
+		} else {
+			title = fmt.Sprintf("PIC tiket #%d ditetapkan", ticket.TicketID)
+			message = fmt.Sprintf("%s menetapkan %s sebagai PIC pekerjaan.", actorName, picName)
+		}
+	default:
+		if language == languageEnglish {
+			title = fmt.Sprintf("Ticket #%d status changed", ticket.TicketID)
+			message = fmt.Sprintf("%s changed the status to %s.", actorName, status)
+		} else {
+			title = fmt.Sprintf("Status tiket #%d berubah", ticket.TicketID)
+			message = fmt.Sprintf("%s mengubah status menjadi %s.", actorName, status)
+		}
+	}
+	return title, message
+}
+
+// truncateCommentBody keeps the notification short, the full comment is read on the ticket
+func truncateCommentBody(body string) string {
+	runes := []rune(body)
+	if len(runes) <= mentionPreviewLength {
+		return body
+	}
+	return string(runes[:mentionPreviewLength]) + "..."
+}
+
+// pushUnreadCount sends the recipient's unread counter to every device they are connected from
+func (s *NotificationService) pushUnreadCount(ctx context.Context, npk string) {
+	count, err := s.repo.CountUnread(ctx, npk)
+	if err != nil {
+		log.Printf("ERROR: Failed to count unread notifications for %s: %v", npk, err)
+		return
+	}
+	userIDs, err := s.appUserRepo.FindIDsByEmployeeNPK(npk)
+	if err != nil {
+		log.Printf("ERROR: Failed to find users of employee %s: %v", npk, err)
+		return
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+
+	message, err := websocket.NewMessage("NOTIFICATION_UNREAD_COUNT", gin.H{"unread_count": count})
+	if err != nil {
+		log.Printf("CRITICAL: Failed to create websocket message for unread notification count: %v", err)
+		return
+	}
+	for _, userID := range userIDs {
+		s.hub.SendToUser(userID, message)
+	}
+}
+
+// NOTIFY TICKET EVENT
+// Writes an inbox entry for everyone involved in the ticket except the actor,
+// escalations and reminders of automatic actions go to the holders of the notified actor roles instead
+// and mentions only go to the mentioned employees.
+// Errors are returned to the outbox dispatcher, which retries the event.
+func (s *NotificationService) NotifyTicketEvent(ctx context.Context, notification dto.TicketEventNotification) error {
+	notificationType, ok := notificationTypeForEvent(notification)
+	if !ok || notification.Ticket == nil {
+		return nil
+	}
+	ticket := notification.Ticket
+
+	var recipients []dto.NotificationRecipient
+	var err error
+	if notificationType == notificationTypeEscalated || notificationType == notificationTypeAutoAction {
+		recipients, err = s.recipientRepo.FindActorRoleHolders(ctx, ticket.TicketID, notification.NotifyActorRoleIDs)
+	} else if notificationType == notificationTypeMentioned {
+		recipients, err = s.recipientRepo.FindEmployees(ctx, notification.MentionedNPKs, "MENTIONED")
+	} else {
+		recipients, err = s.recipientRepo.FindTicketRecipients(ctx, ticket.TicketID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to resolve notification recipients for ticket %d: %w", ticket.TicketID, err)
+	}
+
+	actorName := notification.ActorNPK
+	if actor, err := s.employeeRepo.FindByNPK(notification.ActorNPK); err == nil {
+		actorName = actor.Name
+	}
+
+	var notifications []model.Notification
+	for _, recipient := range recipients {
+		if recipient.NPK == notification.ActorNPK {
+			continue
+		}
+		recipientActorName := actorName
+		var actorNPK *string
+		if notification.ActorNPK == "" {
+			recipientActorName = systemActorName(recipient.Language)
+		} else {
+			npk := notification.ActorNPK
+			actorNPK = &npk
+		}
+		title, message := renderNotification(notificationType, recipient.Language, notification, recipientActorName)
+		ticketID := ticket.TicketID
+		notifications = append(notifications, model.Notification{
+			RecipientNPK: recipient.NPK,
+			TicketID:     &ticketID,
+			Type:         notificationType,
+			Title:        title,
+			Message:      message,
+			ActorNPK:     actorNPK,
+		})
+	}
+
+	if err := s.repo.CreateBatch(ctx, notification.OutboxEventID, notifications); err != nil {
+		return fmt.Errorf("failed to store %s notifications for ticket %d: %w", notificationType, ticket.TicketID, err)
+	}
+	for _, n := range notifications {
+		s.pushUnreadCount(ctx, n.RecipientNPK)
+	}
+	return nil
+}
+
+// GET ALL
+func (s *NotificationService) GetNotifications(ctx context.Context, userNPK string, filters dto.NotificationFilter) (*dto.PaginatedNotificationResponse, error) {
+	if filters.Page <= 0 {
+		filters.Page = 1
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = 10
+	}
+
+	notifications, totalItems, err := s.repo.FindByRecipient(ctx, userNPK, filters)
+	if err != nil {
+		return nil, err
+	}
+	if notifications == nil {
+		notifications = []dto.NotificationResponse{}
+	}
+
+	unreadCount, err := s.repo.CountUnread(ctx, userNPK)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := 0
+	if totalItems > 0 {
+		totalPages = int((totalItems + int64(filters.Limit) - 1) / int64(filters.Limit))
+	}
+
+	return &dto.PaginatedNotificationResponse{
+		Data:        notifications,
+		UnreadCount: unreadCount,
+		Pagination: dto.Pagination{
+			CurrentPage: filters.Page,
+			TotalPages:  totalPages,
+			TotalItems:  totalItems,
+			PageSize:    filters.Limit,
+		},
+	}, nil
+}
+
+// GET UNREAD COUNT
+func (s *NotificationService) GetUnreadCount(ctx context.Context, userNPK string) (int, error) {
+	return s.repo.CountUnread(ctx, userNPK)
+}
+
+// MARK AS READ
+func (s *NotificationService) MarkAsRead(ctx context.Context, id int64, userNPK string) error {
+	if err := s.repo.MarkAsRead(ctx, id, userNPK); err != nil {
+		return err
+	}
+	s.pushUnreadCount(ctx, userNPK)
+	return nil
+}
+
+// MARK ALL AS READ
+func (s *NotificationService) MarkAllAsRead(ctx context.Context, userNPK string) (int64, error) {
+	updated, err := s.repo.MarkAllAsRead(ctx, userNPK)
+	if err != nil {
+		return 0, err
+	}
+	s.pushUnreadCount(ctx, userNPK)
+	return updated, nil
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"e-memo-job-reservation-api/internal/dto"
+)
+
+func TestNotificationTypeForEvent(t *testing.T) {
+	tests := []struct {
+		name         string
+		notification dto.TicketEventNotification
+		wantType     string
+		wantNotify   bool
+	}{
+		{name: "status changed", notification: dto.TicketEventNotification{Event: "TICKET_STATUS_CHANGED", ActionName: "Setujui"}, wantType: notificationTypeStatusChanged, wantNotify: true},
+		{name: "rejected", notification: dto.TicketEventNotification{Event: "TICKET_STATUS_CHANGED", ActionName: "Tolak"}, wantType: notificationTypeRejected, wantNotify: true},
+		{name: "job result rejected", notification: dto.TicketEventNotification{Event: "TICKET_STATUS_CHANGED", ActionName: "Tolak Hasil Job"}, wantType: notificationTypeRejected, wantNotify: true},
+		{name: "PIC assigned", notification: dto.TicketEventNotification{Event: "JOB_PIC_ASSIGNED"}, wantType: notificationTypePicAssigned, wantNotify: true},
+		{name: "escalated", notification: dto.TicketEventNotification{Event: "TICKET_ESCALATED"}, wantType: notificationTypeEscalated, wantNotify: true},
+		{name: "auto action reminder", notification: dto.TicketEventNotification{Event: "TICKET_AUTO_ACTION_REMINDER"}, wantType: notificationTypeAutoAction, wantNotify: true},
+		{name: "comment with mentions", notification: dto.TicketEventNotification{Event: "TICKET_COMMENT_ADDED", MentionedNPKs: []string{"E002"}}, wantType: notificationTypeMentioned, wantNotify: true},
+		{name: "comment without mentions", notification: dto.TicketEventNotification{Event: "TICKET_COMMENT_UPDATED"}},
+		{name: "other event", notification: dto.TicketEventNotification{Event: "TICKET_UPDATED"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotType, gotNotify := notificationTypeForEvent(tt.notification)
+			if gotType != tt.wantType || gotNotify != tt.wantNotify {
+				t.Errorf("notificationTypeForEvent = %q, %v, want %q, %v", gotType, gotNotify, tt.wantType, tt.wantNotify)
+			}
+		})
+	}
+}
+
+func TestRenderNotification(t *testing.T) {
+	ticket := &dto.TicketDetailResponse{TicketID: 42, CurrentStatus: stringPtr("Approval"), PicName: stringPtr("Budi")}
+	notification := dto.TicketEventNotification{Ticket: ticket, ActionName: "Tutup Otomatis", Reason: "Lampiran kurang"}
+	mention := notification
+	mention.Comment = &dto.TicketCommentResponse{Body: strings.Repeat("a", mentionPreviewLength+10)}
+
+	tests := []struct {
+		name             string
+		notificationType string
+		language         string
+		notification     dto.TicketEventNotification
+		wantTitle        string
+		wantMessage      string
+	}{
+		{
+			name:             "rejected in Indonesian",
+			notificationType: notificationTypeRejected,
+			language:         languageIndonesian,
+			notification:     notification,
+			wantTitle:        "Tiket #42 ditolak",
+			wantMessage:      "Andi menolak tiket, status saat ini: Approval. Lampiran kurang",
+		},
+		{
+			name:             "rejected in English",
+			notificationType: notificationTypeRejected,
+			language:         languageEnglish,
+			notification:     notification,
+			wantTitle:        "Ticket #42 was rejected",
+			wantMessage:      "Andi rejected the ticket, current status: Approval. Lampiran kurang",
+		},
+		{
+			name:             "escalated",
+			notificationType: notificationTypeEscalated,
+			language:         languageEnglish,
+			notification:     dto.TicketEventNotification{Ticket: ticket},
+			wantTitle:        "Ticket #42 was escalated",
+			wantMessage:      "The ticket is waiting for your action in status Approval.",
+		},
+		{
+			name:             "auto action without due time",
+			notificationType: notificationTypeAutoAction,
+			language:         languageIndonesian,
+			notification:     notification,
+			wantTitle:        "Tiket #42 akan diproses otomatis",
+			wantMessage:      "Sistem akan menjalankan Tutup Otomatis dalam sekitar 1 jam jika tiket belum ditindaklanjuti, status saat ini: Approval.",
+		},
+		{
+			name:             "mention keeps a preview of the comment",
+			notificationType: notificationTypeMentioned,
+			language:         languageEnglish,
+			notification:     mention,
+			wantTitle:        "You were mentioned on ticket #42",
+			wantMessage:      "Andi mentioned you in a comment. " + strings.Repeat("a", mentionPreviewLength) + "...",
+		},
+		{
+			name:             "PIC assigned",
+			notificationType: notificationTypePicAssigned,
+			language:         languageIndonesian,
+			notification:     notification,
+			wantTitle:        "PIC tiket #42 ditetapkan",
+			wantMessage:      "Andi menetapkan Budi sebagai PIC pekerjaan.",
+		},
+		{
+			name:             "status changed",
+			notificationType: notificationTypeStatusChanged,
+			language:         languageEnglish,
+			notification:     notification,
+			wantTitle:        "Ticket #42 status changed",
+			wantMessage:      "Andi changed the status to Approval.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, message := renderNotification(tt.notificationType, tt.language, tt.notification, "Andi")
+			if title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", title, tt.wantTitle)
+			}
+			if message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", message, tt.wantMessage)
+			}
+		})
+	}
+}
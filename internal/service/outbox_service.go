@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+	"e-memo-job-reservation-api/internal/websocket"
+)
+
+const (
+	outboxAggregateTicket     = "ticket"
+	outboxAggregateDepartment = "department"
+
+	outboxBatchSize    = 100
+	outboxMaxAttempts  = 10
+	outboxBaseBackoff  = 5 * time.Second
+	outboxMaxBackoff   = 10 * time.Minute
+	outboxLease        = time.Minute
+	outboxPollInterval = 2 * time.Second
+)
+
+// OutboxService records ticket events inside the domain transaction and relays them after commit.
+// Delivery is at least once, a failing event is retried as a whole. The email, notification and webhook rows
+// carry the outbox event id under a unique constraint, so the channels that already succeeded are not repeated.
+type OutboxService struct {
+	repo                *repository.OutboxRepository
+	ticketRepo          *repository.TicketRepository
+	queryService        *TicketQueryService
+	hub                 *websocket.Hub
+	emailService        *EmailNotificationService
+	notificationService *NotificationService
+	webhookService      *WebhookService
+	wake                chan struct{}
+}
+
+func NewOutboxService(
+	repo *repository.OutboxRepository,
+	ticketRepo *repository.TicketRepository,
+	queryService *TicketQueryService,
+	hub *websocket.Hub,
+	emailService *EmailNotificationService,
+	notificationService *NotificationService,
+	webhookService *WebhookService,
+) *OutboxService {
+	return &OutboxService{
+		repo:                repo,
+		ticketRepo:          ticketRepo,
+		queryService:        queryService,
+		hub:                 hub,
+		emailService:        emailService,
+		notificationService: notificationService,
+		webhookService:      webhookService,
+		wake:                make(chan struct{}, 1),
+	}
+}
+
+// RECORD TICKET EVENT
+// The ticket row stays locked until the transaction ends, so events of one ticket get their ids in commit order
+func (s *OutboxService) RecordTicketEvent(ctx context.Context, tx *sql.Tx, event string, ticketID int, payload dto.TicketOutboxPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if err := s.ticketRepo.LockByID(ctx, tx, ticketID); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, tx, outboxAggregateTicket, ticketID, event, body)
+}
+
+// RECORD DEPARTMENT EVENT
+// For broadcasts that are complete at write time, the payload is sent to the department as is
+func (s *OutboxService) RecordDepartmentEvent(ctx context.Context, tx *sql.Tx, event string, departmentID int, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, tx, outboxAggregateDepartment, departmentID, event, body)
+}
+
+// WAKE
+// Called after commit so the dispatcher does not wait for the next poll
+func (s *OutboxService) Wake() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// RUN
+func (s *OutboxService) Run(ctx context.Context) {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+		s.DispatchPending(ctx)
+	}
+}
+
+// DISPATCH PENDING
+// Keeps claiming until nothing is due, each round holds at most one event per ticket
+func (s *OutboxService) DispatchPending(ctx context.Context) {
+	for {
+		events, err := s.repo.ClaimDue(ctx, outboxBatchSize, outboxLease)
+		if err != nil {
+			log.Printf("ERROR: Could not claim outbox events: %v", err)
+			return
+		}
+		if len(events) == 0 {
+			return
+		}
+
+		for _, event := range events {
+			err := s.dispatch(ctx, event)
+			if err == nil {
+				if err := s.repo.MarkProcessed(ctx, event.ID); err != nil {
+					log.Printf("ERROR: Failed to mark outbox event %d as processed: %v", event.ID, err)
+				}
+				continue
+			}
+
+			attempts := event.Attempts + 1
+			if attempts >= outboxMaxAttempts {
+				log.Printf("ERROR: Giving up on outbox event %d (%s) after %d attempts: %v", event.ID, event.Event, attempts, err)
+				if err := s.repo.MarkFailed(ctx, event.ID, err.Error()); err != nil {
+					log.Printf("ERROR: Failed to mark outbox event %d as failed: %v", event.ID, err)
+				}
+				continue
+			}
+
+			backoff := outboxBaseBackoff * time.Duration(math.Pow(2, float64(attempts-1)))
+			if backoff > outboxMaxBackoff {
+				backoff = outboxMaxBackoff
+			}
+			if err := s.repo.MarkRetry(ctx, event.ID, time.Now().Add(backoff), err.Error()); err != nil {
+				log.Printf("ERROR: Failed to reschedule outbox event %d: %v", event.ID, err)
+			}
+		}
+	}
+}
+
+func (s *OutboxService) dispatch(ctx context.Context, event model.OutboxEvent) error {
+	switch event.AggregateType {
+	case outboxAggregateTicket:
+		return s.dispatchTicketEvent(ctx, event)
+	case outboxAggregateDepartment:
+		message, err := websocket.NewMessage(event.Event, event.Payload)
+		if err != nil {
+			return err
+		}
+		s.hub.BroadcastTicketMessage(websocket.TicketAudience{DepartmentTargetID: event.AggregateID}, message, message)
+		return s.webhookService.PublishEvent(event.Event, event.Payload, &event.ID)
+	default:
+		return fmt.Errorf("unsupported outbox aggregate type %s", event.AggregateType)
+	}
+}
+
+func (s *OutboxService) dispatchTicketEvent(ctx context.Context, event model.OutboxEvent) error {
+	var payload dto.TicketOutboxPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return err
+	}
+
+	ticket, err := s.queryService.GetTicketByID(ctx, event.AggregateID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch ticket %d: %w", event.AggregateID, err)
+	}
+
+	notification := dto.TicketEventNotification{
+		OutboxEventID: event.ID,
+
+		Event:      event.Event,
+		Ticket:     ticket,
+		ActorNPK:   payload.ActorNPK,
+		ActionName: payload.ActionName,
+		Reason:     payload.Reason,
+
+		NotifyActorRoleIDs: payload.NotifyActorRoleIDs,
+		DueAt:              payload.DueAt,
+		Comment:            payload.Comment,
+		MentionedNPKs:      payload.MentionedNPKs,
+	}
+
+	switch event.Event {
+	case "TICKET_CREATED":
+		broadcastTicketEvent(s.hub, "TICKET_CREATED", ticket)
+		if err := s.webhookService.PublishTicketEvent("TICKET_CREATED", ticket, event.ID); err != nil {
+			return err
+		}
+		return s.emailService.NotifyTicketEvent(ctx, notification)
+	case "TICKET_UPDATED":
+		broadcastTicketEvent(s.hub, "TICKET_UPDATED", ticket)
+		return s.webhookService.PublishTicketEvent("TICKET_UPDATED", ticket, event.ID)
+	case "JOB_COMPLETED":
+		// A PIC completed their job, the ticket itself stays in its status
+		broadcastTicketEvent(s.hub, "TICKET_UPDATED", ticket)
+		if err := s.webhookService.PublishTicketEvent("TICKET_UPDATED", ticket, event.ID); err != nil {
+			return err
+		}
+		return s.publishJobCompleted(ticket, payload.CompletedJobID, event.ID)
+	case "TICKET_STATUS_CHANGED":
+		broadcastTicketEvent(s.hub, "TICKET_STATUS_CHANGED", ticket)
+		if err := s.webhookService.PublishTicketEvent("TICKET_STATUS_CHANGED", ticket, event.ID); err != nil {
+			return err
+		}
+		// The Selesaikan Job action completed the last open job
+		if payload.CompletedJobID != 0 {
+			if err := s.publishJobCompleted(ticket, payload.CompletedJobID, event.ID); err != nil {
+				return err
+			}
+		}
+		if err := s.emailService.NotifyTicketEvent(ctx, notification); err != nil {
+			return err
+		}
+		return s.notificationService.NotifyTicketEvent(ctx, notification)
+	case "JOB_PIC_ASSIGNED":
+		// Websocket clients only need the refreshed ticket
+		broadcastTicketEvent(s.hub, "TICKET_UPDATED", ticket)
+		if err := s.webhookService.PublishTicketEvent("TICKET_UPDATED", ticket, event.ID); err != nil {
+			return err
+		}
+		if err := s.webhookService.PublishTicketEvent("JOB_PIC_ASSIGNED", ticket, event.ID); err != nil {
+			return err
+		}
+		return s.notificationService.NotifyTicketEvent(ctx, notification)
+	case "TICKET_ESCALATED":
+		broadcastTicketEvent(s.hub, "TICKET_ESCALATED", ticket)
+		return s.notificationService.NotifyTicketEvent(ctx, notification)
+	case "TICKET_AUTO_ACTION_REMINDER":
+		return s.notificationService.NotifyTicketEvent(ctx, notification)
+	case "TICKET_COMMENT_ADDED", "TICKET_COMMENT_UPDATED", "TICKET_COMMENT_DELETED":
+		// Comments are only pushed to the employees involved in the ticket and the newly mentioned ones,
+		// not to the ticket's department or other subscribers
+		message, err := websocket.NewMessage(event.Event, payload.Comment)
+		if err != nil {
+			return err
+		}
+		audience := websocket.TicketAudience{
+			InvolvedNPKs: append(ticketAudience(ticket).InvolvedNPKs, payload.MentionedNPKs...),
+			InvolvedOnly: true,
+		}
+		s.hub.BroadcastTicketMessage(audience, message, nil)
+		return s.notificationService.NotifyTicketEvent(ctx, notification)
+	default:
+		return fmt.Errorf("unsupported outbox event %s", event.Event)
+	}
+}
+
+func (s *OutboxService) publishJobCompleted(ticket *dto.TicketDetailResponse, jobID int, outboxEventID int64) error {
+	data := dto.JobCompletedEventResponse{CompletedJobID: jobID, TicketDetailResponse: ticket}
+	return s.webhookService.PublishEvent("JOB_COMPLETED", data, &outboxEventID)
+}
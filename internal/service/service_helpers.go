@@ -1,8 +1,18 @@
 package service
 
-import "e-memo-job-reservation-api/internal/model"
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
 
-func determineUserContexts(user *model.Employee, ticket *model.Ticket, requestor *model.Employee, job *model.Job) []string {
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+	"e-memo-job-reservation-api/internal/websocket"
+)
+
+func determineUserContexts(user *model.Employee, ticket *model.Ticket, requestor *model.Employee, jobs []model.Job) []string {
 	var contexts []string
 	if user.NPK == ticket.Requestor {
 		contexts = append(contexts, "SELF")
@@ -13,8 +23,148 @@ func determineUserContexts(user *model.Employee, ticket *model.Ticket, requestor
 	if user.DepartmentID == ticket.DepartmentTargetID {
 		contexts = append(contexts, "TARGET_DEPT")
 	}
-	if job != nil && job.PicJob.Valid && user.NPK == job.PicJob.String {
+	if isJobPic(jobs, user.NPK) {
 		contexts = append(contexts, "ASSIGNED")
 	}
 	return contexts
 }
+
+// the user is assigned to the ticket when they are the PIC of one of its jobs
+func isJobPic(jobs []model.Job, npk string) bool {
+	for _, job := range jobs {
+		if job.PicJob.Valid && job.PicJob.String == npk {
+			return true
+		}
+	}
+	return false
+}
+
+// looks the action up in the workflow version the ticket is pinned to,
+// tickets created before their workflow was published follow the live transitions
+func findTicketTransition(transitionRepo *repository.StatusTransitionRepository, versionRepo *repository.WorkflowVersionRepository, ticket *model.Ticket, fromStatusID int, actionName string) (int, []int, error) {
+	if ticket.WorkflowVersionID.Valid {
+		return versionRepo.FindValidTransition(int(ticket.WorkflowVersionID.Int64), fromStatusID, actionName)
+	}
+	return transitionRepo.FindValidTransition(fromStatusID, actionName)
+}
+
+// the status a revised ticket goes back to, the first step of its pinned version or of the position's workflow
+func ticketInitialStatusID(ctx context.Context, workflowRepo *repository.WorkflowRepository, versionRepo *repository.WorkflowVersionRepository, ticket *model.Ticket, positionID int) (int, error) {
+	if ticket.WorkflowVersionID.Valid {
+		return versionRepo.GetInitialStatusID(ctx, int(ticket.WorkflowVersionID.Int64))
+	}
+	return workflowRepo.GetInitialStatusByPosition(ctx, positionID)
+}
+
+// marks every action whose prerequisite status has not been passed through yet
+func applyTransitionPrerequisites(actions []dto.AvailableTicketActionResponse, visitedStatusIDs []int) []dto.AvailableTicketActionResponse {
+	visited := make(map[int]bool, len(visitedStatusIDs))
+	for _, id := range visitedStatusIDs {
+		visited[id] = true
+	}
+
+	for i := range actions {
+		if actions[i].RequiredStatusID == nil || visited[*actions[i].RequiredStatusID] {
+			continue
+		}
+		requiredName := fmt.Sprintf("status %d", *actions[i].RequiredStatusID)
+		if actions[i].RequiredStatusName != nil {
+			requiredName = *actions[i].RequiredStatusName
+		}
+		reason := fmt.Sprintf("ticket must pass through status '%s' before this action can be performed", requiredName)
+		actions[i].IsBlocked = true
+		actions[i].BlockedReason = &reason
+	}
+	return actions
+}
+
+// Action finishing the jobs of a ticket
+const actionNameCompleteJob = "Selesaikan Job"
+
+// jobCompletionRequirement tells whether the ticket's jobs are worked on in the status, that is whether
+// they can be completed from it, and whether completing them needs a report file
+func jobCompletionRequirement(ctx context.Context, transitionRepo *repository.StatusTransitionRepository, versionRepo *repository.WorkflowVersionRepository, ticket *model.Ticket, statusID int) (bool, bool, error) {
+	if ticket.WorkflowVersionID.Valid {
+		return versionRepo.FindActionFileRequirement(ctx, int(ticket.WorkflowVersionID.Int64), statusID, actionNameCompleteJob)
+	}
+	return transitionRepo.FindActionFileRequirement(ctx, statusID, actionNameCompleteJob)
+}
+
+// isJobCompletedStatus tells whether the ticket enters the status once its jobs are completed
+func isJobCompletedStatus(ctx context.Context, transitionRepo *repository.StatusTransitionRepository, versionRepo *repository.WorkflowVersionRepository, ticket *model.Ticket, statusID int) (bool, error) {
+	if ticket.WorkflowVersionID.Valid {
+		return versionRepo.IsActionTarget(ctx, int(ticket.WorkflowVersionID.Int64), statusID, actionNameCompleteJob)
+	}
+	return transitionRepo.IsActionTarget(ctx, statusID, actionNameCompleteJob)
+}
+
+// marks the actions starting the job, those entering a status the jobs are worked in, while tickets
+// linked as blocking this one are still open. Actions already blocked by a prerequisite keep that reason
+func applyBlockingTickets(actions []dto.AvailableTicketActionResponse, workStatusIDs map[int]bool, blockerIDs []int) []dto.AvailableTicketActionResponse {
+	ticketRefs := make([]string, len(blockerIDs))
+	for i, id := range blockerIDs {
+		ticketRefs[i] = fmt.Sprintf("#%d", id)
+	}
+	reason := fmt.Sprintf("ticket is blocked by open ticket %s", strings.Join(ticketRefs, ", "))
+
+	for i := range actions {
+		if !workStatusIDs[actions[i].ToStatusID] || actions[i].IsBlocked {
+			continue
+		}
+		actions[i].IsBlocked = true
+		actions[i].IsBlockedByTicket = true
+		actions[i].BlockedReason = &reason
+	}
+	return actions
+}
+
+// sends a ticket event to the subscribed clients, public clients receive the redacted ticket
+func broadcastTicketEvent(hub *websocket.Hub, event string, ticket *dto.TicketDetailResponse) {
+	message, err := websocket.NewMessage(event, ticket)
+	if err != nil {
+		log.Printf("CRITICAL: Failed to create websocket message for %s: %v", event, err)
+		return
+	}
+	publicMessage, err := websocket.NewMessage(event, toPublicTicketDetail(ticket))
+	if err != nil {
+		log.Printf("CRITICAL: Failed to create public websocket message for %s: %v", event, err)
+		return
+	}
+	hub.BroadcastTicketMessage(ticketAudience(ticket), message, publicMessage)
+}
+
+func ticketAudience(ticket *dto.TicketDetailResponse) websocket.TicketAudience {
+	involvedNPKs := append([]string{ticket.RequestorNPK}, ticket.JobPicNPKs...)
+	involvedNPKs = append(involvedNPKs, ticket.WatcherNPKs...)
+	return websocket.TicketAudience{
+		TicketID:           ticket.TicketID,
+		DepartmentTargetID: ticket.DepartmentTargetID,
+		InvolvedNPKs:       involvedNPKs,
+	}
+}
+
+func toPublicTicketDetail(ticket *dto.TicketDetailResponse) dto.PublicTicketDetailResponse {
+	return dto.PublicTicketDetailResponse{
+		TicketID:             ticket.TicketID,
+		Description:          ticket.Description,
+		TicketPriority:       ticket.TicketPriority,
+		Version:              ticket.Version,
+		DepartmentTargetID:   ticket.DepartmentTargetID,
+		DepartmentTargetName: ticket.DepartmentTargetName,
+		JobID:                ticket.JobID,
+		JobPriority:          ticket.JobPriority,
+		JobCount:             ticket.JobCount,
+		CompletedJobCount:    ticket.CompletedJobCount,
+		LocationName:         ticket.LocationName,
+		CreatedAt:            ticket.CreatedAt,
+		TicketAgeDays:        ticket.TicketAgeDays,
+		Deadline:             ticket.Deadline,
+		DaysRemaining:        ticket.DaysRemaining,
+		RequestorDepartment:  ticket.RequestorDepartment,
+		CurrentStatus:        ticket.CurrentStatus,
+		CurrentStatusHexCode: ticket.CurrentStatusHexCode,
+		CurrentSectionName:   ticket.CurrentSectionName,
+		SlaDueAt:             ticket.SlaDueAt,
+		IsSlaBreached:        ticket.IsSlaBreached,
+	}
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func stringPtr(v string) *string {
+	return &v
+}
+
+func TestApplyTransitionPrerequisites(t *testing.T) {
+	tests := []struct {
+		name       string
+		action     dto.AvailableTicketActionResponse
+		visited    []int
+		wantBlock  bool
+		wantReason string
+	}{
+		{
+			name:   "action without prerequisite",
+			action: dto.AvailableTicketActionResponse{ActionName: "Setujui"},
+		},
+		{
+			name:    "prerequisite status visited",
+			action:  dto.AvailableTicketActionResponse{ActionName: "Setujui", RequiredStatusID: intPtr(3)},
+			visited: []int{1, 3},
+		},
+		{
+			name:       "prerequisite status not visited",
+			action:     dto.AvailableTicketActionResponse{ActionName: "Setujui", RequiredStatusID: intPtr(3), RequiredStatusName: stringPtr("Review")},
+			visited:    []int{1, 2},
+			wantBlock:  true,
+			wantReason: "ticket must pass through status 'Review' before this action can be performed",
+		},
+		{
+			name:       "prerequisite status without name",
+			action:     dto.AvailableTicketActionResponse{ActionName: "Setujui", RequiredStatusID: intPtr(3)},
+			wantBlock:  true,
+			wantReason: "ticket must pass through status 'status 3' before this action can be performed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actions := applyTransitionPrerequisites([]dto.AvailableTicketActionResponse{tt.action}, tt.visited)
+			got := actions[0]
+			if got.IsBlocked != tt.wantBlock {
+				t.Fatalf("IsBlocked = %v, want %v", got.IsBlocked, tt.wantBlock)
+			}
+			if !tt.wantBlock {
+				if got.BlockedReason != nil {
+					t.Errorf("BlockedReason = %q, want none", *got.BlockedReason)
+				}
+				return
+			}
+			if got.BlockedReason == nil || *got.BlockedReason != tt.wantReason {
+				t.Errorf("BlockedReason = %v, want %q", got.BlockedReason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestDetermineUserContexts(t *testing.T) {
+	ticket := &model.Ticket{Requestor: "E001", DepartmentTargetID: 20}
+	requestor := &model.Employee{NPK: "E001", DepartmentID: 10}
+	jobs := []model.Job{
+		{ID: 1},
+		{ID: 2, PicJob: sql.NullString{String: "E002", Valid: true}},
+		{ID: 3, PicJob: sql.NullString{String: "E003", Valid: true}},
+	}
+
+	tests := []struct {
+		name string
+		user *model.Employee
+		jobs []model.Job
+		want []string
+	}{
+		{
+			name: "requestor",
+			user: requestor,
+			jobs: jobs,
+			want: []string{"SELF", "REQUESTOR_DEPT"},
+		},
+		{
+			name: "PIC of a later job",
+			user: &model.Employee{NPK: "E003", DepartmentID: 20},
+			jobs: jobs,
+			want: []string{"TARGET_DEPT", "ASSIGNED"},
+		},
+		{
+			name: "target department without a job",
+			user: &model.Employee{NPK: "E004", DepartmentID: 20},
+			jobs: jobs,
+			want: []string{"TARGET_DEPT"},
+		},
+		{
+			name: "ticket without jobs",
+			user: &model.Employee{NPK: "E002", DepartmentID: 30},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := determineUserContexts(tt.user, ticket, requestor, tt.jobs)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("contexts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyBlockingTickets(t *testing.T) {
+	workStatusIDs := map[int]bool{5: true}
+	prerequisiteReason := "ticket must pass through status 'Review' before this action can be performed"
+
+	tests := []struct {
+		name         string
+		action       dto.AvailableTicketActionResponse
+		blockerIDs   []int
+		wantBlock    bool
+		wantByTicket bool
+		wantReason   string
+	}{
+		{
+			name:       "action not entering a work status",
+			action:     dto.AvailableTicketActionResponse{ActionName: "Tolak", ToStatusID: 4},
+			blockerIDs: []int{7},
+		},
+		{
+			name:         "action entering a work status",
+			action:       dto.AvailableTicketActionResponse{ActionName: "Kerjakan", ToStatusID: 5},
+			blockerIDs:   []int{7, 9},
+			wantBlock:    true,
+			wantByTicket: true,
+			wantReason:   "ticket is blocked by open ticket #7, #9",
+		},
+		{
+			name:       "prerequisite block keeps its reason",
+			action:     dto.AvailableTicketActionResponse{ActionName: "Kerjakan", ToStatusID: 5, IsBlocked: true, BlockedReason: &prerequisiteReason},
+			blockerIDs: []int{7},
+			wantBlock:  true,
+			wantReason: prerequisiteReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actions := applyBlockingTickets([]dto.AvailableTicketActionResponse{tt.action}, workStatusIDs, tt.blockerIDs)
+			got := actions[0]
+			if got.IsBlocked != tt.wantBlock || got.IsBlockedByTicket != tt.wantByTicket {
+				t.Fatalf("IsBlocked = %v, IsBlockedByTicket = %v, want %v, %v", got.IsBlocked, got.IsBlockedByTicket, tt.wantBlock, tt.wantByTicket)
+			}
+			if !tt.wantBlock {
+				return
+			}
+			if got.BlockedReason == nil || *got.BlockedReason != tt.wantReason {
+				t.Errorf("BlockedReason = %v, want %q", got.BlockedReason, tt.wantReason)
+			}
+		})
+	}
+}
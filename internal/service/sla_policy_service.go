@@ -0,0 +1,77 @@
+package service
+
+import (
+	"errors"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const defaultSlaWarningPercentage = 80
+
+type SlaPolicyService struct {
+	repo *repository.SlaPolicyRepository
+}
+
+func NewSlaPolicyService(repo *repository.SlaPolicyRepository) *SlaPolicyService {
+	return &SlaPolicyService{repo: repo}
+}
+
+// HELPER
+func mapSlaPolicyError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return errors.New("sla policy for this status and department already exists")
+		case "23503":
+			return errors.New("invalid status_ticket_id or department_id")
+		}
+	}
+	return err
+}
+
+// CREATE
+func (s *SlaPolicyService) CreateSlaPolicy(req dto.CreateSlaPolicyRequest) (*model.SlaPolicy, error) {
+	if req.WarningPercentage == 0 {
+		req.WarningPercentage = defaultSlaWarningPercentage
+	}
+
+	newPolicy, err := s.repo.Create(req)
+	if err != nil {
+		return nil, mapSlaPolicyError(err)
+	}
+	return newPolicy, nil
+}
+
+// GET ALL
+func (s *SlaPolicyService) GetAllSlaPolicies(filters dto.SlaPolicyFilter) ([]model.SlaPolicy, error) {
+	return s.repo.FindAll(filters)
+}
+
+// GET BY ID
+func (s *SlaPolicyService) GetSlaPolicyByID(id int) (*model.SlaPolicy, error) {
+	return s.repo.FindByID(id)
+}
+
+// UPDATE
+func (s *SlaPolicyService) UpdateSlaPolicy(id int, req dto.UpdateSlaPolicyRequest) (*model.SlaPolicy, error) {
+	updated, err := s.repo.Update(id, req)
+	if err != nil {
+		return nil, mapSlaPolicyError(err)
+	}
+	return updated, nil
+}
+
+// CHANGE ACTIVE STATUS
+func (s *SlaPolicyService) UpdateSlaPolicyActiveStatus(id int, req dto.UpdateSlaPolicyStatusRequest) error {
+	return s.repo.UpdateActiveStatus(id, req.IsActive)
+}
+
+// DELETE
+func (s *SlaPolicyService) DeleteSlaPolicy(id int) error {
+	return s.repo.Delete(id)
+}
@@ -51,6 +51,11 @@ func (s *StatusTicketService) UpdateStatusTicketActiveStatus(id int, req dto.Upd
 	return s.repo.UpdateActiveStatus(id, req.IsActive)
 }
 
+// CHANGE TERMINAL FLAG
+func (s *StatusTicketService) UpdateStatusTicketTerminal(id int, req dto.UpdateStatusTicketTerminalRequest) error {
+	return s.repo.UpdateTerminalStatus(id, req.IsTerminal)
+}
+
 // REORDER
 func (s *StatusTicketService) ReorderStatusTickets(req dto.ReorderStatusTicketsRequest) error {
 	ctx := context.Background()
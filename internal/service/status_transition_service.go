@@ -0,0 +1,451 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type StatusTransitionService struct {
+	db               *sql.DB
+	repo             *repository.StatusTransitionRepository
+	statusTicketRepo *repository.StatusTicketRepository
+	workflowRepo     *repository.WorkflowRepository
+}
+
+func NewStatusTransitionService(db *sql.DB, repo *repository.StatusTransitionRepository, statusTicketRepo *repository.StatusTicketRepository, workflowRepo *repository.WorkflowRepository) *StatusTransitionService {
+	return &StatusTransitionService{
+		db:               db,
+		repo:             repo,
+		statusTicketRepo: statusTicketRepo,
+		workflowRepo:     workflowRepo,
+	}
+}
+
+// WorkflowGraphInvalidError is returned when a graph is refused, it carries every issue found
+type WorkflowGraphInvalidError struct {
+	Issues []dto.WorkflowGraphIssue
+}
+
+func (e *WorkflowGraphInvalidError) Error() string {
+	return "workflow graph is invalid"
+}
+
+// HELPER
+func mapStatusTransitionError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		if pgErr.ConstraintName == "transition_id" {
+			return errors.New("transition still has a prerequisite")
+		}
+		return errors.New("invalid status, action or actor role")
+	}
+	return err
+}
+
+func (s *StatusTransitionService) ensureNoConflict(fromStatusID *int, actionID int, toStatusID int, excludeID int) error {
+	conflict, err := s.repo.HasConflictingTransition(fromStatusID, actionID, toStatusID, excludeID)
+	if err != nil {
+		return err
+	}
+	if conflict {
+		return errors.New("action already leads to a different status from this status")
+	}
+	return nil
+}
+
+// CREATE
+func (s *StatusTransitionService) CreateStatusTransition(req dto.CreateStatusTransitionRequest) (*dto.StatusTransitionDetailResponse, error) {
+	if err := s.ensureNoConflict(req.FromStatusID, req.ActionID, req.ToStatusID, 0); err != nil {
+		return nil, err
+	}
+
+	id, err := s.repo.Create(req)
+	if err != nil {
+		return nil, mapStatusTransitionError(err)
+	}
+	return s.repo.FindByID(id)
+}
+
+// GET ALL
+func (s *StatusTransitionService) GetAllStatusTransitions(filters dto.StatusTransitionFilter) ([]dto.StatusTransitionDetailResponse, error) {
+	return s.repo.FindAll(filters)
+}
+
+// GET BY ID
+func (s *StatusTransitionService) GetStatusTransitionByID(id int) (*dto.StatusTransitionDetailResponse, error) {
+	return s.repo.FindByID(id)
+}
+
+// UPDATE
+func (s *StatusTransitionService) UpdateStatusTransition(id int, req dto.UpdateStatusTransitionRequest) (*dto.StatusTransitionDetailResponse, error) {
+	if req.IsActive {
+		if err := s.ensureNoConflict(req.FromStatusID, req.ActionID, req.ToStatusID, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Update(id, req); err != nil {
+		return nil, mapStatusTransitionError(err)
+	}
+	return s.repo.FindByID(id)
+}
+
+// CHANGE ACTIVE STATUS
+func (s *StatusTransitionService) UpdateStatusTransitionActiveStatus(id int, req dto.UpdateStatusTransitionStatusRequest) error {
+	if req.IsActive {
+		transition, err := s.repo.FindByID(id)
+		if err != nil {
+			return err
+		}
+		if err := s.ensureNoConflict(transition.FromStatusID, transition.ActionID, transition.ToStatusID, id); err != nil {
+			return err
+		}
+	}
+	return s.repo.UpdateActiveStatus(id, req.IsActive)
+}
+
+// DELETE
+func (s *StatusTransitionService) DeleteStatusTransition(id int) error {
+	return mapStatusTransitionError(s.repo.Delete(id))
+}
+
+// VALIDATE GRAPH
+// Checks the transitions currently stored, single CRUD calls only guard against ambiguous actions
+// because a graph is usually incomplete while it is being edited row by row
+func (s *StatusTransitionService) ValidateGraph(ctx context.Context) (*dto.WorkflowGraphValidationResponse, error) {
+	existing, err := s.repo.FindAll(dto.StatusTransitionFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	transitions := make([]dto.WorkflowGraphTransition, 0, len(existing))
+	for _, t := range existing {
+		transitions = append(transitions, toWorkflowGraphTransition(t))
+	}
+
+	issues, err := s.validateGraph(ctx, transitions, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.WorkflowGraphValidationResponse{IsValid: len(issues) == 0, Issues: issues}, nil
+}
+
+// SAVE GRAPH
+// Replaces every transition with the submitted graph in one transaction
+func (s *StatusTransitionService) SaveGraph(ctx context.Context, req dto.SaveWorkflowGraphRequest) (*dto.SaveWorkflowGraphResponse, error) {
+	plan, err := s.planGraph(ctx, req.Transitions, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := s.writeGraph(ctx, tx, plan)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// workflowGraphPlan holds a validated graph and the stored rows it replaces
+type workflowGraphPlan struct {
+	transitions   []dto.WorkflowGraphTransition
+	existingByKey map[string]dto.StatusTransitionDetailResponse
+}
+
+// planGraph prepares the replacement of the stored transitions selected by inScope (all of them when nil).
+// The result is validated together with the transitions outside the scope before anything is written,
+// initialStatusIDs overrides the workflow starts read from the database when it is not nil.
+func (s *StatusTransitionService) planGraph(ctx context.Context, transitions []dto.WorkflowGraphTransition, inScope func(dto.StatusTransitionDetailResponse) bool, initialStatusIDs []int) (*workflowGraphPlan, error) {
+	existing, err := s.repo.FindAll(dto.StatusTransitionFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	var merged []dto.WorkflowGraphTransition
+	existingByKey := make(map[string]dto.StatusTransitionDetailResponse)
+	for _, t := range existing {
+		if inScope != nil && !inScope(t) {
+			merged = append(merged, toWorkflowGraphTransition(t))
+			continue
+		}
+		existingByKey[workflowGraphKey(t.FromStatusID, t.ActionID, t.ActorRoleID)] = t
+	}
+	merged = append(merged, transitions...)
+
+	var issues []dto.WorkflowGraphIssue
+	seen := make(map[string]bool)
+	for _, t := range transitions {
+		key := workflowGraphKey(t.FromStatusID, t.ActionID, t.ActorRoleID)
+		if seen[key] {
+			actionID := t.ActionID
+			issues = append(issues, dto.WorkflowGraphIssue{
+				Code:     "DUPLICATE_TRANSITION",
+				Message:  fmt.Sprintf("transition for action %d and actor role %d is listed more than once from the same status", t.ActionID, t.ActorRoleID),
+				StatusID: t.FromStatusID,
+				ActionID: &actionID,
+			})
+		}
+		seen[key] = true
+	}
+
+	graphIssues, err := s.validateGraph(ctx, merged, initialStatusIDs)
+	if err != nil {
+		return nil, err
+	}
+	issues = append(issues, graphIssues...)
+	if len(issues) > 0 {
+		return nil, &WorkflowGraphInvalidError{Issues: issues}
+	}
+
+	return &workflowGraphPlan{transitions: transitions, existingByKey: existingByKey}, nil
+}
+
+// writeGraph applies a plan inside the caller's transaction
+func (s *StatusTransitionService) writeGraph(ctx context.Context, tx *sql.Tx, plan *workflowGraphPlan) (*dto.SaveWorkflowGraphResponse, error) {
+	result := &dto.SaveWorkflowGraphResponse{}
+	for _, t := range plan.transitions {
+		key := workflowGraphKey(t.FromStatusID, t.ActionID, t.ActorRoleID)
+		if current, ok := plan.existingByKey[key]; ok {
+			if err := s.repo.UpdateGraphTransition(ctx, tx, current.ID, t); err != nil {
+				return nil, mapStatusTransitionError(err)
+			}
+			delete(plan.existingByKey, key)
+			result.Updated++
+			continue
+		}
+		if err := s.repo.InsertGraphTransition(ctx, tx, t); err != nil {
+			return nil, mapStatusTransitionError(err)
+		}
+		result.Created++
+	}
+
+	var removedIDs []int
+	for _, t := range plan.existingByKey {
+		removedIDs = append(removedIDs, t.ID)
+	}
+	if err := s.repo.DeleteGraphTransitions(ctx, tx, removedIDs); err != nil {
+		return nil, err
+	}
+	result.Deleted = len(removedIDs)
+
+	return result, nil
+}
+
+func (s *StatusTransitionService) validateGraph(ctx context.Context, transitions []dto.WorkflowGraphTransition, initialStatusIDs []int) ([]dto.WorkflowGraphIssue, error) {
+	statuses, err := s.statusTicketRepo.FindAll(dto.StatusTicketFilter{})
+	if err != nil {
+		return nil, err
+	}
+	if initialStatusIDs == nil {
+		initialStatusIDs, err = s.workflowRepo.FindInitialStatusIDs(ctx, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return validateWorkflowGraph(transitions, statuses, initialStatusIDs), nil
+}
+
+func toWorkflowGraphTransition(t dto.StatusTransitionDetailResponse) dto.WorkflowGraphTransition {
+	return dto.WorkflowGraphTransition{
+		FromStatusID:      t.FromStatusID,
+		ToStatusID:        t.ToStatusID,
+		ActionID:          t.ActionID,
+		ActorRoleID:       t.ActorRoleID,
+		RequireReason:     t.RequireReason,
+		ReasonLabel:       t.ReasonLabel,
+		RequireFile:       t.RequireFile,
+		IsActive:          t.IsActive,
+		RequiredApprovals: t.RequiredApprovals,
+	}
+}
+
+func workflowGraphKey(fromStatusID *int, actionID int, actorRoleID int) string {
+	from := 0
+	if fromStatusID != nil {
+		from = *fromStatusID
+	}
+	return fmt.Sprintf("%d:%d:%d", from, actionID, actorRoleID)
+}
+
+type workflowActionKey struct {
+	fromStatusID int
+	actionID     int
+}
+
+func sortWorkflowActionKeys(keys []workflowActionKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].fromStatusID != keys[j].fromStatusID {
+			return keys[i].fromStatusID < keys[j].fromStatusID
+		}
+		return keys[i].actionID < keys[j].actionID
+	})
+}
+
+// validateWorkflowGraph checks the active transitions for actions that lead to more than one status or need more approvals than they have roles,
+// statuses that cannot be reached from a workflow start, and non-terminal statuses without a way out
+func validateWorkflowGraph(transitions []dto.WorkflowGraphTransition, statuses []model.StatusTicket, initialStatusIDs []int) []dto.WorkflowGraphIssue {
+	statusByID := make(map[int]model.StatusTicket, len(statuses))
+	for _, st := range statuses {
+		statusByID[st.ID] = st
+	}
+	statusName := func(id int) string {
+		if st, ok := statusByID[id]; ok {
+			return st.Name
+		}
+		return fmt.Sprintf("status %d", id)
+	}
+
+	var issues []dto.WorkflowGraphIssue
+	nodes := make(map[int]bool)
+	outgoing := make(map[int][]int)
+	targetsByAction := make(map[workflowActionKey]map[int]bool)
+	rolesByAction := make(map[workflowActionKey]map[int]bool)
+	quorumByAction := make(map[workflowActionKey]int)
+	var roots []int
+
+	for _, id := range initialStatusIDs {
+		nodes[id] = true
+		roots = append(roots, id)
+	}
+
+	for _, t := range transitions {
+		if !t.IsActive {
+			continue
+		}
+		for _, id := range []*int{t.FromStatusID, &t.ToStatusID} {
+			if id == nil {
+				continue
+			}
+			if _, ok := statusByID[*id]; !ok {
+				statusID := *id
+				issues = append(issues, dto.WorkflowGraphIssue{
+					Code:     "UNKNOWN_STATUS",
+					Message:  fmt.Sprintf("status %d does not exist", statusID),
+					StatusID: &statusID,
+				})
+			}
+		}
+
+		nodes[t.ToStatusID] = true
+		if t.FromStatusID == nil {
+			// Transitions without a from status start a ticket
+			roots = append(roots, t.ToStatusID)
+		} else {
+			nodes[*t.FromStatusID] = true
+			outgoing[*t.FromStatusID] = append(outgoing[*t.FromStatusID], t.ToStatusID)
+		}
+
+		key := workflowActionKey{actionID: t.ActionID}
+		if t.FromStatusID != nil {
+			key.fromStatusID = *t.FromStatusID
+		}
+		if targetsByAction[key] == nil {
+			targetsByAction[key] = make(map[int]bool)
+		}
+		targetsByAction[key][t.ToStatusID] = true
+
+		if rolesByAction[key] == nil {
+			rolesByAction[key] = make(map[int]bool)
+		}
+		rolesByAction[key][t.ActorRoleID] = true
+		if t.RequiredApprovals > quorumByAction[key] {
+			quorumByAction[key] = t.RequiredApprovals
+		}
+	}
+
+	var ambiguousKeys, unreachableQuorumKeys []workflowActionKey
+	for key, targets := range targetsByAction {
+		if len(targets) > 1 {
+			ambiguousKeys = append(ambiguousKeys, key)
+		}
+		if quorumByAction[key] > len(rolesByAction[key]) {
+			unreachableQuorumKeys = append(unreachableQuorumKeys, key)
+		}
+	}
+	sortWorkflowActionKeys(ambiguousKeys)
+	sortWorkflowActionKeys(unreachableQuorumKeys)
+	for _, key := range ambiguousKeys {
+		fromID, actionID := key.fromStatusID, key.actionID
+		issue := dto.WorkflowGraphIssue{
+			Code:     "AMBIGUOUS_ACTION",
+			ActionID: &actionID,
+		}
+		if fromID != 0 {
+			issue.StatusID = &fromID
+			issue.Message = fmt.Sprintf("action %d leads to more than one status from '%s'", actionID, statusName(fromID))
+		} else {
+			issue.Message = fmt.Sprintf("action %d leads to more than one starting status", actionID)
+		}
+		issues = append(issues, issue)
+	}
+	// A quorum counts distinct actor roles, so it cannot be larger than the roles allowed to perform the action
+	for _, key := range unreachableQuorumKeys {
+		fromID, actionID := key.fromStatusID, key.actionID
+		issue := dto.WorkflowGraphIssue{
+			Code:     "QUORUM_UNREACHABLE",
+			ActionID: &actionID,
+			Message:  fmt.Sprintf("action %d requires %d approvals but only %d actor roles may perform it", actionID, quorumByAction[key], len(rolesByAction[key])),
+		}
+		if fromID != 0 {
+			issue.StatusID = &fromID
+			issue.Message += fmt.Sprintf(" from '%s'", statusName(fromID))
+		}
+		issues = append(issues, issue)
+	}
+
+	reachable := make(map[int]bool)
+	queue := append([]int{}, roots...)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if reachable[current] {
+			continue
+		}
+		reachable[current] = true
+		queue = append(queue, outgoing[current]...)
+	}
+
+	nodeIDs := make([]int, 0, len(nodes))
+	for id := range nodes {
+		nodeIDs = append(nodeIDs, id)
+	}
+	sort.Ints(nodeIDs)
+
+	for _, id := range nodeIDs {
+		statusID := id
+		if !reachable[id] {
+			issues = append(issues, dto.WorkflowGraphIssue{
+				Code:     "UNREACHABLE_STATUS",
+				Message:  fmt.Sprintf("status '%s' cannot be reached from any workflow start", statusName(id)),
+				StatusID: &statusID,
+			})
+		}
+		if len(outgoing[id]) == 0 && !statusByID[id].IsTerminal {
+			issues = append(issues, dto.WorkflowGraphIssue{
+				Code:     "DEAD_END_STATUS",
+				Message:  fmt.Sprintf("status '%s' has no outgoing transition and is not terminal", statusName(id)),
+				StatusID: &statusID,
+			})
+		}
+	}
+
+	return issues
+}
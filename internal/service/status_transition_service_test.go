@@ -0,0 +1,97 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+)
+
+func TestValidateWorkflowGraph(t *testing.T) {
+	statuses := []model.StatusTicket{
+		{ID: 1, Name: "Approval"},
+		{ID: 2, Name: "Dikerjakan"},
+		{ID: 3, Name: "Selesai", IsTerminal: true},
+		{ID: 4, Name: "Ditolak", IsTerminal: true},
+	}
+	transition := func(from, action, role, to int) dto.WorkflowGraphTransition {
+		return dto.WorkflowGraphTransition{FromStatusID: intPtr(from), ActionID: action, ActorRoleID: role, ToStatusID: to, IsActive: true, RequiredApprovals: 1}
+	}
+	valid := []dto.WorkflowGraphTransition{
+		transition(1, 10, 1, 2),
+		transition(1, 11, 1, 4),
+		transition(2, 12, 2, 3),
+	}
+
+	tests := []struct {
+		name        string
+		transitions func() []dto.WorkflowGraphTransition
+		wantCodes   []string
+	}{
+		{
+			name:        "valid graph",
+			transitions: func() []dto.WorkflowGraphTransition { return valid },
+		},
+		{
+			name: "action leading to two statuses",
+			transitions: func() []dto.WorkflowGraphTransition {
+				return append(append([]dto.WorkflowGraphTransition{}, valid...), transition(1, 10, 2, 4))
+			},
+			wantCodes: []string{"AMBIGUOUS_ACTION"},
+		},
+		{
+			name: "quorum larger than the roles of the action",
+			transitions: func() []dto.WorkflowGraphTransition {
+				quorum := append([]dto.WorkflowGraphTransition{}, valid...)
+				quorum[0].RequiredApprovals = 2
+				return append(quorum, transition(1, 10, 2, 2))
+			},
+		},
+		{
+			name: "quorum without enough roles",
+			transitions: func() []dto.WorkflowGraphTransition {
+				quorum := append([]dto.WorkflowGraphTransition{}, valid...)
+				quorum[0].RequiredApprovals = 2
+				return quorum
+			},
+			wantCodes: []string{"QUORUM_UNREACHABLE"},
+		},
+		{
+			name: "non-terminal status without a way out",
+			transitions: func() []dto.WorkflowGraphTransition {
+				return valid[:2]
+			},
+			wantCodes: []string{"DEAD_END_STATUS"},
+		},
+		{
+			name: "inactive transitions are ignored",
+			transitions: func() []dto.WorkflowGraphTransition {
+				inactive := append([]dto.WorkflowGraphTransition{}, valid...)
+				inactive[0].IsActive = false
+				return inactive
+			},
+			wantCodes: []string{"UNREACHABLE_STATUS", "UNREACHABLE_STATUS"},
+		},
+		{
+			name: "unknown status",
+			transitions: func() []dto.WorkflowGraphTransition {
+				return append(append([]dto.WorkflowGraphTransition{}, valid...), transition(3, 13, 1, 9))
+			},
+			wantCodes: []string{"UNKNOWN_STATUS", "DEAD_END_STATUS"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := validateWorkflowGraph(tt.transitions(), statuses, []int{1})
+			var codes []string
+			for _, issue := range issues {
+				codes = append(codes, issue.Code)
+			}
+			if !reflect.DeepEqual(codes, tt.wantCodes) {
+				t.Errorf("issues = %v, want %v", issues, tt.wantCodes)
+			}
+		})
+	}
+}
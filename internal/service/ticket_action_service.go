@@ -2,10 +2,10 @@ package service
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 
 	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
 	"e-memo-job-reservation-api/internal/repository"
 )
 
@@ -17,6 +17,10 @@ type TicketActionService struct {
 	statusTransitionRepo  *repository.StatusTransitionRepository
 	actorRoleMappingRepo  *repository.ActorRoleMappingRepository
 	actorRoleRepo         *repository.ActorRoleRepository
+	workflowVersionRepo   *repository.WorkflowVersionRepository
+	delegationRepo        *repository.EmployeeDelegationRepository
+	reasonCodeRepo        *repository.ActionReasonCodeRepository
+	ticketLinkRepo        *repository.TicketLinkRepository
 }
 
 type TicketActionServiceConfig struct {
@@ -27,6 +31,10 @@ type TicketActionServiceConfig struct {
 	StatusTransitionRepo  *repository.StatusTransitionRepository
 	ActorRoleMappingRepo  *repository.ActorRoleMappingRepository
 	ActorRoleRepo         *repository.ActorRoleRepository
+	WorkflowVersionRepo   *repository.WorkflowVersionRepository
+	DelegationRepo        *repository.EmployeeDelegationRepository
+	ReasonCodeRepo        *repository.ActionReasonCodeRepository
+	TicketLinkRepo        *repository.TicketLinkRepository
 }
 
 func NewTicketActionService(cfg *TicketActionServiceConfig) *TicketActionService {
@@ -38,10 +46,17 @@ func NewTicketActionService(cfg *TicketActionServiceConfig) *TicketActionService
 		statusTransitionRepo:  cfg.StatusTransitionRepo,
 		actorRoleMappingRepo:  cfg.ActorRoleMappingRepo,
 		actorRoleRepo:         cfg.ActorRoleRepo,
+		workflowVersionRepo:   cfg.WorkflowVersionRepo,
+		delegationRepo:        cfg.DelegationRepo,
+		reasonCodeRepo:        cfg.ReasonCodeRepo,
+		ticketLinkRepo:        cfg.TicketLinkRepo,
 	}
 }
 
 // GET AVAILABLE ACTIONS
+// Besides the user's own roles, the roles of the employees who currently delegate to the user are included,
+// those actions carry the NPK of the employee the user would act for. Actions with reason codes list the active ones.
+// Starting the job is blocked while a ticket blocking this one is still open.
 func (s *TicketActionService) GetAvailableActions(ctx context.Context, ticketID int, userNPK string) ([]dto.AvailableTicketActionResponse, error) {
 	user, err := s.employeeRepo.FindByNPK(userNPK)
 	if err != nil {
@@ -58,36 +73,133 @@ func (s *TicketActionService) GetAvailableActions(ctx context.Context, ticketID
 		return nil, errors.New("requestor employee not found")
 	}
 
-	job, err := s.jobRepo.FindByTicketID(ctx, ticketID)
-	if err != nil && err != sql.ErrNoRows {
+	jobs, err := s.jobRepo.FindAllByTicketID(ctx, ticketID)
+	if err != nil {
 		return nil, err
 	}
 
-	userContexts := determineUserContexts(user, ticket, requestor, job)
+	_, userRoleIDs, err := s.resolveActorRoles(user, ticket, requestor, jobs)
+	if err != nil {
+		return nil, err
+	}
 
-	userRoleIDs, err := s.actorRoleMappingRepo.GetRoleIDsForUserContext(user.Position.ID, userContexts)
+	currentStatusID, _, err := s.trackStatusTicketRepo.GetCurrentStatusByTicketID(ctx, ticketID)
+	if err != nil {
+		return nil, errors.New("current status not found")
+	}
+
+	findTransitions := s.statusTransitionRepo.FindAvailableTransitionsForRoles
+	if ticket.WorkflowVersionID.Valid {
+		versionID := int(ticket.WorkflowVersionID.Int64)
+		findTransitions = func(fromStatusID int, roleIDs []int) ([]dto.AvailableTicketActionResponse, error) {
+			return s.workflowVersionRepo.FindTransitionsForRoles(versionID, fromStatusID, roleIDs)
+		}
+	}
+
+	availableActions, err := findTransitions(currentStatusID, userRoleIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	if job != nil && job.PicJob.Valid && user.NPK == job.PicJob.String {
-		assignedPicRoleIDs, err := s.actorRoleRepo.GetRoleIDsByNames([]string{"ASSIGNED_PIC"})
+	delegatorNPKs, err := s.delegationRepo.FindCurrentDelegatorNPKs(ctx, userNPK)
+	if err != nil {
+		return nil, err
+	}
+	ownRoleIDs := make(map[int]bool, len(userRoleIDs))
+	for _, id := range userRoleIDs {
+		ownRoleIDs[id] = true
+	}
+	for _, delegatorNPK := range delegatorNPKs {
+		delegator, err := s.employeeRepo.FindByNPK(delegatorNPK)
+		if err != nil {
+			continue
+		}
+		_, delegatorRoleIDs, err := s.resolveActorRoles(delegator, ticket, requestor, jobs)
 		if err != nil {
 			return nil, err
-		} else {
-			userRoleIDs = append(userRoleIDs, assignedPicRoleIDs...)
 		}
+
+		// Roles the user already holds are used in the user's own name
+		var delegatedRoleIDs []int
+		for _, id := range delegatorRoleIDs {
+			if !ownRoleIDs[id] {
+				delegatedRoleIDs = append(delegatedRoleIDs, id)
+			}
+		}
+		delegatedActions, err := findTransitions(currentStatusID, delegatedRoleIDs)
+		if err != nil {
+			return nil, err
+		}
+		for i := range delegatedActions {
+			npk := delegator.NPK
+			delegatedActions[i].OnBehalfOfNPK = &npk
+		}
+		availableActions = append(availableActions, delegatedActions...)
 	}
 
-	currentStatusID, _, err := s.trackStatusTicketRepo.GetCurrentStatusByTicketID(ctx, ticketID)
+	for i := range availableActions {
+		availableActions[i].FromStatusID = currentStatusID
+	}
+
+	visitedStatusIDs, err := s.trackStatusTicketRepo.GetVisitedStatusIDs(ctx, ticketID)
 	if err != nil {
-		return nil, errors.New("current status not found")
+		return nil, err
 	}
 
-	availableActions, err := s.statusTransitionRepo.FindAvailableTransitionsForRoles(currentStatusID, userRoleIDs)
+	actionIDs := make([]int, 0, len(availableActions))
+	for _, action := range availableActions {
+		actionIDs = append(actionIDs, action.ActionID)
+	}
+	reasonCodes, err := s.reasonCodeRepo.FindActiveByActionIDs(ctx, actionIDs)
 	if err != nil {
 		return nil, err
 	}
+	for i := range availableActions {
+		availableActions[i].ReasonCodes = reasonCodes[availableActions[i].ActionID]
+	}
+
+	availableActions = applyTransitionPrerequisites(availableActions, visitedStatusIDs)
+
+	blockerIDs, err := s.ticketLinkRepo.FindOpenBlockerIDs(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if len(blockerIDs) > 0 {
+		// The job is started by entering a status it can be completed from, as configured in the workflow
+		workStatusIDs := make(map[int]bool)
+		for _, action := range availableActions {
+			if _, checked := workStatusIDs[action.ToStatusID]; checked {
+				continue
+			}
+			isWorkStatus, _, err := jobCompletionRequirement(ctx, s.statusTransitionRepo, s.workflowVersionRepo, ticket, action.ToStatusID)
+			if err != nil {
+				return nil, err
+			}
+			workStatusIDs[action.ToStatusID] = isWorkStatus
+		}
+		availableActions = applyBlockingTickets(availableActions, workStatusIDs, blockerIDs)
+	}
 
 	return availableActions, nil
 }
+
+// HELPER
+// resolveActorRoles returns the contexts the user has on the ticket and the actor roles they grant for the user's position
+func (s *TicketActionService) resolveActorRoles(user *model.Employee, ticket *model.Ticket, requestor *model.Employee, jobs []model.Job) ([]string, []int, error) {
+	userContexts := determineUserContexts(user, ticket, requestor, jobs)
+
+	userRoleIDs, err := s.actorRoleMappingRepo.GetRoleIDsForUserContext(user.Position.ID, userContexts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if isJobPic(jobs, user.NPK) {
+		assignedPicRoleIDs, err := s.actorRoleRepo.GetRoleIDsByNames([]string{"ASSIGNED_PIC"})
+		if err != nil {
+			return nil, nil, err
+		}
+		userRoleIDs = append(userRoleIDs, assignedPicRoleIDs...)
+	}
+
+	return userContexts, userRoleIDs, nil
+}
@@ -24,6 +24,7 @@ type TicketCommandService struct {
 	ticketRepo            *repository.TicketRepository
 	jobRepo               *repository.JobRepository
 	workflowRepo          *repository.WorkflowRepository
+	workflowVersionRepo   *repository.WorkflowVersionRepository
 	trackStatusTicketRepo *repository.TrackStatusTicketRepository
 	employeeRepo          *repository.EmployeeRepository
 	departmentRepo        *repository.DepartmentRepository
@@ -33,6 +34,8 @@ type TicketCommandService struct {
 	specifiedLocationRepo *repository.SpecifiedLocationRepository
 	hub                   *websocket.Hub
 	queryService          *TicketQueryService
+	lockService           *EditingLockService
+	outboxService         *OutboxService
 }
 
 type TicketCommandServiceConfig struct {
@@ -40,6 +43,7 @@ type TicketCommandServiceConfig struct {
 	TicketRepo            *repository.TicketRepository
 	JobRepo               *repository.JobRepository
 	WorkflowRepo          *repository.WorkflowRepository
+	WorkflowVersionRepo   *repository.WorkflowVersionRepository
 	TrackStatusTicketRepo *repository.TrackStatusTicketRepository
 	EmployeeRepo          *repository.EmployeeRepository
 	DepartmentRepo        *repository.DepartmentRepository
@@ -49,6 +53,8 @@ type TicketCommandServiceConfig struct {
 	SpecifiedLocationRepo *repository.SpecifiedLocationRepository
 	Hub                   *websocket.Hub
 	QueryService          *TicketQueryService
+	LockService           *EditingLockService
+	OutboxService         *OutboxService
 }
 
 func NewTicketCommandService(cfg *TicketCommandServiceConfig) *TicketCommandService {
@@ -57,6 +63,7 @@ func NewTicketCommandService(cfg *TicketCommandServiceConfig) *TicketCommandServ
 		ticketRepo:            cfg.TicketRepo,
 		jobRepo:               cfg.JobRepo,
 		workflowRepo:          cfg.WorkflowRepo,
+		workflowVersionRepo:   cfg.WorkflowVersionRepo,
 		trackStatusTicketRepo: cfg.TrackStatusTicketRepo,
 		employeeRepo:          cfg.EmployeeRepo,
 		departmentRepo:        cfg.DepartmentRepo,
@@ -66,6 +73,8 @@ func NewTicketCommandService(cfg *TicketCommandServiceConfig) *TicketCommandServ
 		specifiedLocationRepo: cfg.SpecifiedLocationRepo,
 		hub:                   cfg.Hub,
 		queryService:          cfg.QueryService,
+		lockService:           cfg.LockService,
+		outboxService:         cfg.OutboxService,
 	}
 }
 
@@ -88,13 +97,22 @@ func (s *TicketCommandService) CreateTicket(ctx context.Context, req dto.CreateT
 		return nil, err
 	}
 
-	// GET INITIAL STATUS FROM PREVIOUS GET EMPLOYEE DATA
-	initialStatusID, err := s.workflowRepo.GetInitialStatusByPosition(ctx, positionID)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, errors.New("no workflow defined for this user's position")
-		}
+	// GET INITIAL STATUS FROM THE LATEST PUBLISHED VERSION, THE TICKET IS PINNED TO IT
+	var workflowVersionID sql.NullInt64
+	versionID, initialStatusID, err := s.workflowVersionRepo.FindLatestByPosition(ctx, positionID)
+	if err == nil {
+		workflowVersionID = sql.NullInt64{Int64: int64(versionID), Valid: true}
+	} else if err != sql.ErrNoRows {
 		return nil, err
+	} else {
+		// WORKFLOW NOT PUBLISHED YET, FOLLOW THE LIVE STEPS
+		initialStatusID, err = s.workflowRepo.GetInitialStatusByPosition(ctx, positionID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, errors.New("no workflow defined for this user's position")
+			}
+			return nil, err
+		}
 	}
 
 	deadline, err := repository.ParseDeadline(req.Deadline)
@@ -132,6 +150,7 @@ func (s *TicketCommandService) CreateTicket(ctx context.Context, req dto.CreateT
 		TicketPriority:      lastPriority,
 		Deadline:            deadline,
 		SupportFiles:        filesMetadata,
+		WorkflowVersionID:   workflowVersionID,
 	}
 
 	// INSERT DATA TO TICKET TABLE
@@ -152,24 +171,18 @@ func (s *TicketCommandService) CreateTicket(ctx context.Context, req dto.CreateT
 		return nil, err
 	}
 
-	// COMMIT DATA
-	if err := tx.Commit(); err != nil {
+	err = s.outboxService.RecordTicketEvent(ctx, tx, "TICKET_CREATED", createdTicket.ID, dto.TicketOutboxPayload{ActorNPK: requestor})
+	if err != nil {
 		return nil, err
 	}
 
-	ticketDetail, err := s.queryService.GetTicketByID(createdTicket.ID)
-	if err != nil {
-		log.Printf("CRITICAL: Failed to fetch new ticket details for broadcast. TicketID: %d, Error: %v", createdTicket.ID, err)
-	} else {
-		message, err := websocket.NewMessage("TICKET_CREATED", ticketDetail)
-		if err != nil {
-			log.Printf("CRITICAL: Failed to create websocket message for new ticket: %v", err)
-		} else {
-			s.hub.BroadcastMessage(message)
-		}
+	// COMMIT DATA
+	if err := tx.Commit(); err != nil {
+		return nil, err
 	}
+	s.outboxService.Wake()
 
-	return createdTicket, err
+	return createdTicket, nil
 }
 
 // UPDATE TICKET
@@ -209,7 +222,7 @@ func (s *TicketCommandService) UpdateTicket(ctx context.Context, ticketID int, r
 		return err
 	}
 
-	_, allowedRoleIDsForRevise, err := s.statusTransitionRepo.FindValidTransition(currentStatusID, "Revisi")
+	_, allowedRoleIDsForRevise, err := findTicketTransition(s.statusTransitionRepo, s.workflowVersionRepo, originalTicket, currentStatusID, "Revisi")
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -241,6 +254,10 @@ func (s *TicketCommandService) UpdateTicket(ctx context.Context, ticketID int, r
 	}
 	defer tx.Rollback()
 
+	if err := s.lockService.EnsureNotLockedByOthers(ctx, tx, editingEntityTicket, ticketID, userNPK); err != nil {
+		return err
+	}
+
 	var specifiedLocationID sql.NullInt64
 	if req.SpecifiedLocationName != nil && req.PhysicalLocationID != nil {
 		id, err := s.specifiedLocationRepo.FindOrCreate(ctx, tx, *req.SpecifiedLocationName, *req.PhysicalLocationID)
@@ -267,19 +284,17 @@ func (s *TicketCommandService) UpdateTicket(ctx context.Context, ticketID int, r
 		}
 	}
 
-	updatedTicketDetail, err := s.queryService.GetTicketByID(ticketID)
+	err = s.outboxService.RecordTicketEvent(ctx, tx, "TICKET_UPDATED", ticketID, dto.TicketOutboxPayload{ActorNPK: userNPK})
 	if err != nil {
-		log.Printf("CRITICAL: Failed to fetch updated ticket for broadcast after update. TicketID: %d, Error: %v", ticketID, err)
-	} else {
-		message, err := websocket.NewMessage("TICKET_UPDATED", updatedTicketDetail)
-		if err != nil {
-			log.Printf("CRITICAL: Failed to create websocket message for updated ticket: %v", err)
-		} else {
-			s.hub.BroadcastMessage(message)
-		}
+		return err
 	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.outboxService.Wake()
+
+	return nil
 }
 
 func (s *TicketCommandService) AddSupportFiles(ctx context.Context, c *gin.Context, ticketID int, userNPK string, files []*multipart.FileHeader) error {
@@ -318,7 +333,7 @@ func (s *TicketCommandService) AddSupportFiles(ctx context.Context, c *gin.Conte
 		return err
 	}
 
-	_, allowedRoleIDsForRevise, err := s.statusTransitionRepo.FindValidTransition(currentStatusID, "Revisi")
+	_, allowedRoleIDsForRevise, err := findTicketTransition(s.statusTransitionRepo, s.workflowVersionRepo, originalTicket, currentStatusID, "Revisi")
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -353,26 +368,34 @@ func (s *TicketCommandService) AddSupportFiles(ctx context.Context, c *gin.Conte
 		return nil
 	}
 
-	if err := s.ticketRepo.AddSupportFiles(ctx, ticketID, savedFilesMetadata); err != nil {
+	if err := s.saveSupportFiles(ctx, ticketID, userNPK, savedFilesMetadata); err != nil {
 		for _, metadata := range savedFilesMetadata {
 			os.Remove(metadata.FilePath)
 		}
 		return err
 	}
+	s.outboxService.Wake()
+
+	return nil
+}
+
+func (s *TicketCommandService) saveSupportFiles(ctx context.Context, ticketID int, userNPK string, filesMetadata []model.FileMetadata) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-	updatedTicketDetail, err := s.queryService.GetTicketByID(ticketID)
+	if err := s.ticketRepo.AddSupportFiles(ctx, tx, ticketID, filesMetadata); err != nil {
+		return err
+	}
+
+	err = s.outboxService.RecordTicketEvent(ctx, tx, "TICKET_UPDATED", ticketID, dto.TicketOutboxPayload{ActorNPK: userNPK})
 	if err != nil {
-		log.Printf("CRITICAL: Failed to fetch updated ticket for broadcast after adding files. TicketID: %d, Error: %v", ticketID, err)
-	} else {
-		message, err := websocket.NewMessage("TICKET_UPDATED", updatedTicketDetail)
-		if err != nil {
-			log.Printf("CRITICAL: Failed to create websocket message for updated ticket files: %v", err)
-		} else {
-			s.hub.BroadcastMessage(message)
-		}
+		return err
 	}
 
-	return nil
+	return tx.Commit()
 }
 
 func (s *TicketCommandService) RemoveSupportFiles(ctx context.Context, ticketID int, userNPK string, req dto.DeleteFilesRequest) error {
@@ -411,7 +434,7 @@ func (s *TicketCommandService) RemoveSupportFiles(ctx context.Context, ticketID
 		return err
 	}
 
-	_, allowedRoleIDsForRevise, err := s.statusTransitionRepo.FindValidTransition(currentStatusID, "Revisi")
+	_, allowedRoleIDsForRevise, err := findTicketTransition(s.statusTransitionRepo, s.workflowVersionRepo, originalTicket, currentStatusID, "Revisi")
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -437,31 +460,35 @@ func (s *TicketCommandService) RemoveSupportFiles(ctx context.Context, ticketID
 		return errors.New("user is not authorized to edit this ticket")
 	}
 
-	if err := s.ticketRepo.RemoveSupportFiles(ctx, ticketID, req.FilePathsToDelete); err != nil {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := s.ticketRepo.RemoveSupportFiles(ctx, tx, ticketID, req.FilePathsToDelete); err != nil {
 		if err == sql.ErrNoRows {
 			return errors.New("ticket not found")
 		}
 		return err
 	}
 
+	err = s.outboxService.RecordTicketEvent(ctx, tx, "TICKET_UPDATED", ticketID, dto.TicketOutboxPayload{ActorNPK: userNPK})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.outboxService.Wake()
+
 	for _, filePath := range req.FilePathsToDelete {
 		if err := os.Remove(filePath); err != nil {
 			log.Printf("WARNING: Failed to delete file from storage, but DB record was removed. File path: %s, Error: %v", filePath, err)
 		}
 	}
 
-	updatedTicketDetail, err := s.queryService.GetTicketByID(ticketID)
-	if err != nil {
-		log.Printf("CRITICAL: Failed to fetch updated ticket for broadcast after removing files. TicketID: %d, Error: %v", ticketID, err)
-	} else {
-		message, err := websocket.NewMessage("TICKET_UPDATED", updatedTicketDetail)
-		if err != nil {
-			log.Printf("CRITICAL: Failed to create websocket message for removed ticket files: %v", err)
-		} else {
-			s.hub.BroadcastMessage(message)
-		}
-	}
-
 	return nil
 }
 
@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+)
+
+// An @ followed by an NPK mentions the employee, unknown NPKs are left as plain text
+var mentionPattern = regexp.MustCompile(`@([A-Za-z0-9._-]+)`)
+
+type TicketCommentService struct {
+	db            *sql.DB
+	commentRepo   *repository.TicketCommentRepository
+	ticketRepo    *repository.TicketRepository
+	jobRepo       *repository.JobRepository
+	employeeRepo  *repository.EmployeeRepository
+	outboxService *OutboxService
+}
+
+type TicketCommentServiceConfig struct {
+	DB            *sql.DB
+	CommentRepo   *repository.TicketCommentRepository
+	TicketRepo    *repository.TicketRepository
+	JobRepo       *repository.JobRepository
+	EmployeeRepo  *repository.EmployeeRepository
+	OutboxService *OutboxService
+}
+
+func NewTicketCommentService(cfg *TicketCommentServiceConfig) *TicketCommentService {
+	return &TicketCommentService{
+		db:            cfg.DB,
+		commentRepo:   cfg.CommentRepo,
+		ticketRepo:    cfg.TicketRepo,
+		jobRepo:       cfg.JobRepo,
+		employeeRepo:  cfg.EmployeeRepo,
+		outboxService: cfg.OutboxService,
+	}
+}
+
+// HELPER
+func parseMentionCandidates(body string) []string {
+	seen := make(map[string]bool)
+	var npks []string
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		npk := strings.TrimRight(match[1], "._-")
+		if npk == "" || seen[npk] {
+			continue
+		}
+		seen[npk] = true
+		npks = append(npks, npk)
+	}
+	return npks
+}
+
+// resolveMentions keeps the mentioned NPKs that belong to active employees
+func (s *TicketCommentService) resolveMentions(ctx context.Context, body string) ([]string, error) {
+	candidates := parseMentionCandidates(body)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	return s.commentRepo.FindActiveNPKs(ctx, candidates)
+}
+
+// ensureCanComment allows everyone involved in the ticket, including the job PICs, and the employees
+// mentioned in its discussion
+func (s *TicketCommentService) ensureCanComment(ctx context.Context, ticketID int, userNPK string) (*model.Employee, error) {
+	ticket, err := s.ticketRepo.FindByIDAsStruct(ctx, ticketID)
+	if err != nil {
+		return nil, errors.New("ticket not found")
+	}
+	user, err := s.employeeRepo.FindByNPK(userNPK)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	requestor, err := s.employeeRepo.FindByNPK(ticket.Requestor)
+	if err != nil {
+		return nil, errors.New("requestor employee not found")
+	}
+	jobs, err := s.jobRepo.FindAllByTicketID(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if len(determineUserContexts(user, ticket, requestor, jobs)) > 0 {
+		return user, nil
+	}
+
+	mentioned, err := s.commentRepo.IsMentioned(ctx, ticketID, userNPK)
+	if err != nil {
+		return nil, err
+	}
+	if !mentioned {
+		return nil, errors.New("user is not involved in this ticket")
+	}
+	return user, nil
+}
+
+// findOwnComment returns the comment of the ticket written by the user, comments of other tickets are not found
+func (s *TicketCommentService) findOwnComment(ctx context.Context, ticketID int, commentID int64, userNPK string) (*model.TicketComment, error) {
+	comment, err := s.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.TicketID != ticketID {
+		return nil, sql.ErrNoRows
+	}
+	if comment.AuthorNPK != userNPK {
+		return nil, errors.New("only the author can change this comment")
+	}
+	if comment.DeletedAt.Valid {
+		return nil, errors.New("comment has been deleted")
+	}
+	return comment, nil
+}
+
+// newMentions drops the employees that were already mentioned and the author
+func newMentions(mentioned []string, previous []string, authorNPK string) []string {
+	seen := map[string]bool{authorNPK: true}
+	for _, npk := range previous {
+		seen[npk] = true
+	}
+	var npks []string
+	for _, npk := range mentioned {
+		if !seen[npk] {
+			npks = append(npks, npk)
+		}
+	}
+	return npks
+}
+
+func toTicketCommentResponse(comment model.TicketComment) dto.TicketCommentResponse {
+	response := dto.TicketCommentResponse{
+		ID:            comment.ID,
+		TicketID:      comment.TicketID,
+		AuthorNPK:     comment.AuthorNPK,
+		AuthorName:    comment.AuthorName,
+		Body:          comment.Body,
+		Attachments:   formatFileMetadataToResponse(comment.Attachments),
+		MentionedNPKs: []string(comment.MentionedNPKs),
+		IsEdited:      comment.EditedAt.Valid,
+		IsDeleted:     comment.DeletedAt.Valid,
+		CreatedAt:     comment.CreatedAt,
+		Replies:       []dto.TicketCommentResponse{},
+	}
+	if comment.ParentID.Valid {
+		parentID := comment.ParentID.Int64
+		response.ParentID = &parentID
+	}
+	if comment.EditedAt.Valid {
+		editedAt := comment.EditedAt.Time
+		response.EditedAt = &editedAt
+	}
+	if response.MentionedNPKs == nil {
+		response.MentionedNPKs = []string{}
+	}
+	if response.IsDeleted {
+		response.Body = ""
+		response.Attachments = []dto.FileResponse{}
+		response.MentionedNPKs = []string{}
+	}
+	return response
+}
+
+// buildCommentTree nests the replies under the comment they answer, comments keep their creation order
+func buildCommentTree(comments []model.TicketComment) []dto.TicketCommentResponse {
+	children := make(map[int64][]model.TicketComment)
+	var roots []model.TicketComment
+	for _, c := range comments {
+		if c.ParentID.Valid {
+			children[c.ParentID.Int64] = append(children[c.ParentID.Int64], c)
+		} else {
+			roots = append(roots, c)
+		}
+	}
+
+	var build func(comment model.TicketComment) dto.TicketCommentResponse
+	build = func(comment model.TicketComment) dto.TicketCommentResponse {
+		response := toTicketCommentResponse(comment)
+		for _, child := range children[comment.ID] {
+			response.Replies = append(response.Replies, build(child))
+		}
+		return response
+	}
+
+	tree := make([]dto.TicketCommentResponse, 0, len(roots))
+	for _, root := range roots {
+		tree = append(tree, build(root))
+	}
+	return tree
+}
+
+// recordCommentEvent relays the comment as it was written
+func (s *TicketCommentService) recordCommentEvent(ctx context.Context, tx *sql.Tx, event string, userNPK string, comment model.TicketComment, mentioned []string) (*dto.TicketCommentResponse, error) {
+	response := toTicketCommentResponse(comment)
+	payload := dto.TicketOutboxPayload{ActorNPK: userNPK, Comment: &response, MentionedNPKs: mentioned}
+	if err := s.outboxService.RecordTicketEvent(ctx, tx, event, comment.TicketID, payload); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// CREATE
+// Mentioned employees are notified, a reply must answer a comment of the same ticket
+func (s *TicketCommentService) CreateComment(ctx context.Context, ticketID int, userNPK string, req dto.CreateTicketCommentRequest, filesMetadata []model.FileMetadata) (*dto.TicketCommentResponse, error) {
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, errors.New("comment body is required")
+	}
+	user, err := s.ensureCanComment(ctx, ticketID, userNPK)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentID sql.NullInt64
+	if req.ParentID != nil {
+		parent, err := s.commentRepo.FindByID(ctx, *req.ParentID)
+		if err != nil || parent.TicketID != ticketID {
+			return nil, errors.New("parent comment not found")
+		}
+		parentID = sql.NullInt64{Int64: parent.ID, Valid: true}
+	}
+
+	mentioned, err := s.resolveMentions(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	comment, err := s.commentRepo.Create(ctx, tx, model.TicketComment{
+		TicketID:      ticketID,
+		ParentID:      parentID,
+		AuthorNPK:     userNPK,
+		Body:          body,
+		Attachments:   filesMetadata,
+		MentionedNPKs: mentioned,
+	})
+	if err != nil {
+		return nil, err
+	}
+	comment.AuthorName = user.Name
+
+	response, err := s.recordCommentEvent(ctx, tx, "TICKET_COMMENT_ADDED", userNPK, *comment, newMentions(mentioned, nil, userNPK))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	s.outboxService.Wake()
+	return response, nil
+}
+
+// GET ALL
+// The discussion is shown to the same employees that can take part in it
+func (s *TicketCommentService) GetComments(ctx context.Context, ticketID int, userNPK string) ([]dto.TicketCommentResponse, error) {
+	if _, err := s.ensureCanComment(ctx, ticketID, userNPK); err != nil {
+		return nil, err
+	}
+	comments, err := s.commentRepo.FindByTicketID(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	return buildCommentTree(comments), nil
+}
+
+// UPDATE
+// The previous body goes to the history, only employees mentioned for the first time are notified
+func (s *TicketCommentService) UpdateComment(ctx context.Context, ticketID int, commentID int64, userNPK string, req dto.UpdateTicketCommentRequest) (*dto.TicketCommentResponse, error) {
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, errors.New("comment body is required")
+	}
+	comment, err := s.findOwnComment(ctx, ticketID, commentID, userNPK)
+	if err != nil {
+		return nil, err
+	}
+
+	mentioned, err := s.resolveMentions(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := s.commentRepo.CreateHistory(ctx, tx, comment.ID, comment.Body, "EDITED", userNPK); err != nil {
+		return nil, err
+	}
+	editedAt, err := s.commentRepo.Update(ctx, tx, comment.ID, body, mentioned)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := comment.MentionedNPKs
+	comment.Body = body
+	comment.MentionedNPKs = mentioned
+	comment.EditedAt = sql.NullTime{Time: editedAt, Valid: true}
+
+	response, err := s.recordCommentEvent(ctx, tx, "TICKET_COMMENT_UPDATED", userNPK, *comment, newMentions(mentioned, previous, userNPK))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	s.outboxService.Wake()
+	return response, nil
+}
+
+// DELETE
+// The comment keeps its place in the thread without its content, the body is kept in the history
+func (s *TicketCommentService) DeleteComment(ctx context.Context, ticketID int, commentID int64, userNPK string) error {
+	comment, err := s.findOwnComment(ctx, ticketID, commentID, userNPK)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := s.commentRepo.CreateHistory(ctx, tx, comment.ID, comment.Body, "DELETED", userNPK); err != nil {
+		return err
+	}
+	deletedAt, err := s.commentRepo.SoftDelete(ctx, tx, comment.ID)
+	if err != nil {
+		return err
+	}
+	comment.DeletedAt = sql.NullTime{Time: deletedAt, Valid: true}
+
+	if _, err := s.recordCommentEvent(ctx, tx, "TICKET_COMMENT_DELETED", userNPK, *comment, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.outboxService.Wake()
+	return nil
+}
+
+// GET HISTORY
+// Previous versions are shown to everyone taking part in the discussion
+func (s *TicketCommentService) GetCommentHistory(ctx context.Context, ticketID int, commentID int64, userNPK string) ([]dto.TicketCommentHistoryResponse, error) {
+	comment, err := s.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.TicketID != ticketID {
+		return nil, sql.ErrNoRows
+	}
+	if _, err := s.ensureCanComment(ctx, ticketID, userNPK); err != nil {
+		return nil, err
+	}
+	return s.commentRepo.FindHistory(ctx, commentID)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"e-memo-job-reservation-api/internal/model"
+
+	"github.com/lib/pq"
+)
+
+func TestParseMentionCandidates(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{name: "no mention", body: "please check the pump"},
+		{name: "single mention", body: "@E001 please check", want: []string{"E001"}},
+		{name: "trailing punctuation", body: "thanks @E001. and @E002-", want: []string{"E001", "E002"}},
+		{name: "duplicates kept once", body: "@E001 @E002 @E001", want: []string{"E001", "E002"}},
+		{name: "lone at sign", body: "meet @ 10 or @.", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseMentionCandidates(tt.body)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("candidates = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMentions(t *testing.T) {
+	tests := []struct {
+		name      string
+		mentioned []string
+		previous  []string
+		want      []string
+	}{
+		{name: "first mention", mentioned: []string{"E002", "E003"}, want: []string{"E002", "E003"}},
+		{name: "already mentioned", mentioned: []string{"E002", "E003"}, previous: []string{"E002"}, want: []string{"E003"}},
+		{name: "author mentioning themselves", mentioned: []string{"E001"}, want: nil},
+		{name: "nothing new", mentioned: []string{"E002"}, previous: []string{"E002"}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newMentions(tt.mentioned, tt.previous, "E001")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mentions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildCommentTree(t *testing.T) {
+	createdAt := time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)
+	parent := func(id int64) sql.NullInt64 {
+		return sql.NullInt64{Int64: id, Valid: true}
+	}
+	comments := []model.TicketComment{
+		{ID: 1, Body: "first", CreatedAt: createdAt},
+		{ID: 2, ParentID: parent(1), Body: "reply", CreatedAt: createdAt.Add(time.Minute)},
+		{ID: 3, Body: "second", CreatedAt: createdAt.Add(2 * time.Minute)},
+		{ID: 4, ParentID: parent(2), Body: "nested reply", MentionedNPKs: pq.StringArray{"E002"}, CreatedAt: createdAt.Add(3 * time.Minute)},
+		{ID: 5, ParentID: parent(1), Body: "removed", MentionedNPKs: pq.StringArray{"E003"}, DeletedAt: sql.NullTime{Time: createdAt, Valid: true}, CreatedAt: createdAt.Add(4 * time.Minute)},
+	}
+
+	tree := buildCommentTree(comments)
+
+	if len(tree) != 2 || tree[0].ID != 1 || tree[1].ID != 3 {
+		t.Fatalf("roots = %+v, want comments 1 and 3", tree)
+	}
+	replies := tree[0].Replies
+	if len(replies) != 2 || replies[0].ID != 2 || replies[1].ID != 5 {
+		t.Fatalf("replies of comment 1 = %+v, want comments 2 and 5", replies)
+	}
+	if len(replies[0].Replies) != 1 || replies[0].Replies[0].ID != 4 {
+		t.Fatalf("replies of comment 2 = %+v, want comment 4", replies[0].Replies)
+	}
+	if got := replies[0].Replies[0].MentionedNPKs; !reflect.DeepEqual(got, []string{"E002"}) {
+		t.Errorf("mentions of comment 4 = %v, want [E002]", got)
+	}
+	if deleted := replies[1]; !deleted.IsDeleted || deleted.Body != "" || len(deleted.MentionedNPKs) != 0 {
+		t.Errorf("deleted comment = %+v, want it redacted", deleted)
+	}
+	if tree[1].Replies == nil || len(tree[1].Replies) != 0 {
+		t.Errorf("replies of comment 3 = %v, want an empty list", tree[1].Replies)
+	}
+}
+
+func TestBuildCommentTreeEmpty(t *testing.T) {
+	tree := buildCommentTree(nil)
+	if tree == nil || len(tree) != 0 {
+		t.Errorf("tree = %v, want an empty list", tree)
+	}
+}
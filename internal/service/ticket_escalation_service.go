@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+)
+
+// Written to ticket_action_log.system_actor for the entries of the escalation job
+const systemActorEscalation = "ESCALATION"
+
+type TicketEscalationService struct {
+	db                    *sql.DB
+	escalationRuleRepo    *repository.EscalationRuleRepository
+	ticketRepo            *repository.TicketRepository
+	trackStatusTicketRepo *repository.TrackStatusTicketRepository
+	statusTransitionRepo  *repository.StatusTransitionRepository
+	workflowVersionRepo   *repository.WorkflowVersionRepository
+	ticketActionLogRepo   *repository.TicketActionLogRepository
+	outboxService         *OutboxService
+}
+
+type TicketEscalationServiceConfig struct {
+	DB                    *sql.DB
+	EscalationRuleRepo    *repository.EscalationRuleRepository
+	TicketRepo            *repository.TicketRepository
+	TrackStatusTicketRepo *repository.TrackStatusTicketRepository
+	StatusTransitionRepo  *repository.StatusTransitionRepository
+	WorkflowVersionRepo   *repository.WorkflowVersionRepository
+	TicketActionLogRepo   *repository.TicketActionLogRepository
+	OutboxService         *OutboxService
+}
+
+func NewTicketEscalationService(cfg *TicketEscalationServiceConfig) *TicketEscalationService {
+	return &TicketEscalationService{
+		db:                    cfg.DB,
+		escalationRuleRepo:    cfg.EscalationRuleRepo,
+		ticketRepo:            cfg.TicketRepo,
+		trackStatusTicketRepo: cfg.TrackStatusTicketRepo,
+		statusTransitionRepo:  cfg.StatusTransitionRepo,
+		workflowVersionRepo:   cfg.WorkflowVersionRepo,
+		ticketActionLogRepo:   cfg.TicketActionLogRepo,
+		outboxService:         cfg.OutboxService,
+	}
+}
+
+// ESCALATE DUE TICKETS
+// Applies every rule whose time has passed, each in its own transaction so one failing ticket does not hold back the others.
+// Once an automatic action moved a ticket, the remaining rules of its old status no longer apply.
+func (s *TicketEscalationService) EscalateDueTickets(ctx context.Context) (notified int, executed int, err error) {
+	escalations, err := s.escalationRuleRepo.FindDue(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, escalation := range escalations {
+		isApplied, err := s.escalate(ctx, escalation)
+		if err != nil {
+			log.Printf("ERROR: Failed to apply escalation rule %d to ticket %d: %v", escalation.RuleID, escalation.TicketID, err)
+			continue
+		}
+		if !isApplied {
+			continue
+		}
+		if escalation.EscalationType == escalationTypeAutoAction {
+			executed++
+		} else {
+			notified++
+		}
+	}
+	return notified, executed, nil
+}
+
+// SEND DUE REMINDERS
+// Tells the employees who may still perform the action of an AUTO_ACTION rule that the system is about to do it
+func (s *TicketEscalationService) SendDueReminders(ctx context.Context) (int, error) {
+	reminders, err := s.escalationRuleRepo.FindDueReminders(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, reminder := range reminders {
+		isSent, err := s.remind(ctx, reminder)
+		if err != nil {
+			log.Printf("ERROR: Failed to send the reminder of escalation rule %d for ticket %d: %v", reminder.RuleID, reminder.TicketID, err)
+			continue
+		}
+		if isSent {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// HELPER
+// findAutoActionTransition resolves the rule's action from the ticket's workflow version, or the live transitions
+func (s *TicketEscalationService) findAutoActionTransition(escalation repository.DueEscalation) (int, []int, error) {
+	var toStatusID int
+	var allowedRoleIDs []int
+	var err error
+	if escalation.WorkflowVersionID.Valid {
+		toStatusID, allowedRoleIDs, err = s.workflowVersionRepo.FindValidTransition(int(escalation.WorkflowVersionID.Int64), escalation.StatusID, escalation.ActionName.String)
+	} else {
+		toStatusID, allowedRoleIDs, err = s.statusTransitionRepo.FindValidTransition(escalation.StatusID, escalation.ActionName.String)
+	}
+	if err == sql.ErrNoRows {
+		return 0, nil, fmt.Errorf("action '%s' is not allowed from status '%s'", escalation.ActionName.String, escalation.StatusName)
+	}
+	return toStatusID, allowedRoleIDs, err
+}
+
+func (s *TicketEscalationService) remind(ctx context.Context, reminder repository.DueEscalation) (bool, error) {
+	_, allowedRoleIDs, err := s.findAutoActionTransition(reminder)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	isRecorded, err := s.escalationRuleRepo.RecordReminder(ctx, tx, reminder.RuleID, reminder.TrackID)
+	if err != nil || !isRecorded {
+		return false, err
+	}
+
+	dueAt := reminder.DueAt
+	err = s.outboxService.RecordTicketEvent(ctx, tx, "TICKET_AUTO_ACTION_REMINDER", reminder.TicketID, dto.TicketOutboxPayload{
+		ActionName:         reminder.ActionName.String,
+		NotifyActorRoleIDs: allowedRoleIDs,
+		DueAt:              &dueAt,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	s.outboxService.Wake()
+	return true, nil
+}
+
+// escalate writes the escalation to the action log with the system as performer. An automatic action
+// follows the transition of the ticket's workflow version without checking actor roles, quorums or prerequisites,
+// the rule itself authorizes it.
+func (s *TicketEscalationService) escalate(ctx context.Context, escalation repository.DueEscalation) (bool, error) {
+	toStatusID := escalation.StatusID
+	if escalation.EscalationType == escalationTypeAutoAction {
+		var err error
+		toStatusID, _, err = s.findAutoActionTransition(escalation)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	if err := s.ticketRepo.LockByID(ctx, tx, escalation.TicketID); err != nil {
+		return false, err
+	}
+	isRecorded, err := s.escalationRuleRepo.RecordEscalation(ctx, tx, escalation.RuleID, escalation.TrackID)
+	if err != nil || !isRecorded {
+		return false, err
+	}
+
+	details := fmt.Sprintf("Escalation rule '%s': ticket stayed in status %s for more than %d hours", escalation.RuleName, escalation.StatusName, escalation.AfterHours)
+	logEntry := model.TicketActionLog{
+		TicketID:     int64(escalation.TicketID),
+		ActionID:     int(escalation.ActionID.Int64),
+		DetailsText:  sql.NullString{String: details, Valid: true},
+		FromStatusID: sql.NullInt32{Int32: int32(escalation.StatusID), Valid: true},
+		ToStatusID:   toStatusID,
+		SystemActor:  sql.NullString{String: systemActorEscalation, Valid: true},
+	}
+	if escalation.OnBehalfOfRequestor {
+		logEntry.OnBehalfOfNpk = sql.NullString{String: escalation.RequestorNPK, Valid: true}
+	}
+	if err := s.ticketActionLogRepo.Create(ctx, tx, logEntry); err != nil {
+		return false, err
+	}
+
+	if escalation.EscalationType == escalationTypeAutoAction {
+		if err := s.trackStatusTicketRepo.UpdateStatus(ctx, tx, escalation.TicketID, toStatusID); err != nil {
+			return false, err
+		}
+		err = s.outboxService.RecordTicketEvent(ctx, tx, "TICKET_STATUS_CHANGED", escalation.TicketID, dto.TicketOutboxPayload{
+			ActionName: escalation.ActionName.String,
+			Reason:     details,
+		})
+	} else {
+		err = s.outboxService.RecordTicketEvent(ctx, tx, "TICKET_ESCALATED", escalation.TicketID, dto.TicketOutboxPayload{
+			Reason:             details,
+			NotifyActorRoleIDs: []int{int(escalation.NotifyActorRoleID.Int64)},
+		})
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	s.outboxService.Wake()
+	return true, nil
+}
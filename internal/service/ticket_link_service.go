@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Action closing a duplicate into its original
+const actionNameMarkDuplicate = "Tandai Duplikat"
+
+type TicketLinkService struct {
+	db              *sql.DB
+	ticketLinkRepo  *repository.TicketLinkRepository
+	ticketRepo      *repository.TicketRepository
+	employeeRepo    *repository.EmployeeRepository
+	workflowService *TicketWorkflowService
+	outboxService   *OutboxService
+}
+
+type TicketLinkServiceConfig struct {
+	DB              *sql.DB
+	TicketLinkRepo  *repository.TicketLinkRepository
+	TicketRepo      *repository.TicketRepository
+	EmployeeRepo    *repository.EmployeeRepository
+	WorkflowService *TicketWorkflowService
+	OutboxService   *OutboxService
+}
+
+func NewTicketLinkService(cfg *TicketLinkServiceConfig) *TicketLinkService {
+	return &TicketLinkService{
+		db:              cfg.DB,
+		ticketLinkRepo:  cfg.TicketLinkRepo,
+		ticketRepo:      cfg.TicketRepo,
+		employeeRepo:    cfg.EmployeeRepo,
+		workflowService: cfg.WorkflowService,
+		outboxService:   cfg.OutboxService,
+	}
+}
+
+// HELPER
+func mapTicketLinkError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return errors.New("tickets are already linked with this type")
+	}
+	return err
+}
+
+// ensureInvolved allows the requestor's department and the target department of the ticket to manage its links
+func (s *TicketLinkService) ensureInvolved(ctx context.Context, ticketID int, userNPK string) error {
+	ticket, err := s.ticketRepo.FindByIDAsStruct(ctx, ticketID)
+	if err != nil {
+		return errors.New("ticket not found")
+	}
+	user, err := s.employeeRepo.FindByNPK(userNPK)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	requestor, err := s.employeeRepo.FindByNPK(ticket.Requestor)
+	if err != nil {
+		return errors.New("requestor employee not found")
+	}
+	if len(determineUserContexts(user, ticket, requestor, nil)) == 0 {
+		return errors.New("user is not involved in this ticket")
+	}
+	return nil
+}
+
+// ensureInvolvedInDependent keeps a BLOCKS or PARENT_OF link from being put on or taken off someone else's ticket,
+// the user has to be involved in the ticket that is blocked or made a child as well
+func (s *TicketLinkService) ensureInvolvedInDependent(ctx context.Context, linkType string, dependentTicketID int, userNPK string) error {
+	if linkType != "BLOCKS" && linkType != "PARENT_OF" {
+		return nil
+	}
+	if err := s.ensureInvolved(ctx, dependentTicketID, userNPK); err != nil {
+		if err.Error() == "user is not involved in this ticket" {
+			return errors.New("user is not involved in the linked ticket")
+		}
+		return err
+	}
+	return nil
+}
+
+// CREATE
+// BLOCKED_BY and CHILD_OF are stored from the other ticket. Marking a duplicate closes it into the
+// original through the duplicate action, the link is only written when the action goes through.
+func (s *TicketLinkService) CreateLink(ctx context.Context, ticketID int, userNPK string, req dto.CreateTicketLinkRequest) ([]dto.TicketLinkResponse, error) {
+	if req.LinkedTicketID == ticketID {
+		return nil, errors.New("ticket cannot be linked to itself")
+	}
+	if err := s.ensureInvolved(ctx, ticketID, userNPK); err != nil {
+		return nil, err
+	}
+	if _, err := s.ticketRepo.FindByIDAsStruct(ctx, req.LinkedTicketID); err != nil {
+		return nil, errors.New("linked ticket not found")
+	}
+
+	fromID, toID, linkType := ticketID, req.LinkedTicketID, req.LinkType
+	switch req.LinkType {
+	case "BLOCKED_BY":
+		fromID, toID, linkType = req.LinkedTicketID, ticketID, "BLOCKS"
+	case "CHILD_OF":
+		fromID, toID, linkType = req.LinkedTicketID, ticketID, "PARENT_OF"
+	}
+	if err := s.ensureInvolvedInDependent(ctx, linkType, toID, userNPK); err != nil {
+		return nil, err
+	}
+
+	createLink := func(tx *sql.Tx) error {
+		if _, err := s.ticketLinkRepo.Create(ctx, tx, fromID, toID, linkType, userNPK); err != nil {
+			return mapTicketLinkError(err)
+		}
+		// Both tickets are locked by their events, always in id order so concurrent links cannot deadlock
+		for _, id := range []int{min(ticketID, req.LinkedTicketID), max(ticketID, req.LinkedTicketID)} {
+			if err := s.outboxService.RecordTicketEvent(ctx, tx, "TICKET_UPDATED", id, dto.TicketOutboxPayload{ActorNPK: userNPK}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if linkType == "DUPLICATE_OF" {
+		isDuplicate, err := s.ticketLinkRepo.IsDuplicate(ctx, req.LinkedTicketID)
+		if err != nil {
+			return nil, err
+		}
+		if isDuplicate {
+			return nil, errors.New("original ticket is itself marked as a duplicate")
+		}
+
+		reason := fmt.Sprintf("Duplicate of ticket #%d", req.LinkedTicketID)
+		if req.Reason != "" {
+			reason += " - " + req.Reason
+		}
+		actionReq := dto.ExecuteActionRequest{ActionName: actionNameMarkDuplicate, Reason: reason}
+		if err := s.workflowService.executeAction(ctx, ticketID, userNPK, actionReq, nil, createLink); err != nil {
+			return nil, err
+		}
+		return s.GetLinks(ctx, ticketID)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := createLink(tx); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	s.outboxService.Wake()
+
+	return s.GetLinks(ctx, ticketID)
+}
+
+// GET ALL
+func (s *TicketLinkService) GetLinks(ctx context.Context, ticketID int) ([]dto.TicketLinkResponse, error) {
+	if _, err := s.ticketRepo.FindByIDAsStruct(ctx, ticketID); err != nil {
+		return nil, errors.New("ticket not found")
+	}
+	return s.ticketLinkRepo.FindByTicketID(ctx, ticketID)
+}
+
+// DELETE
+// A duplicate link stays, the duplicate has been closed into its original through the workflow
+func (s *TicketLinkService) DeleteLink(ctx context.Context, ticketID int, linkID int, userNPK string) error {
+	link, err := s.ticketLinkRepo.FindByID(ctx, linkID)
+	if err != nil {
+		return err
+	}
+	if link.TicketID != ticketID && link.LinkedTicketID != ticketID {
+		return sql.ErrNoRows
+	}
+	if link.LinkType == "DUPLICATE_OF" {
+		return errors.New("duplicate link cannot be removed")
+	}
+	if err := s.ensureInvolved(ctx, ticketID, userNPK); err != nil {
+		return err
+	}
+	if err := s.ensureInvolvedInDependent(ctx, link.LinkType, link.LinkedTicketID, userNPK); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := s.ticketLinkRepo.Delete(ctx, tx, linkID); err != nil {
+		return err
+	}
+	for _, id := range []int{min(link.TicketID, link.LinkedTicketID), max(link.TicketID, link.LinkedTicketID)} {
+		if err := s.outboxService.RecordTicketEvent(ctx, tx, "TICKET_UPDATED", id, dto.TicketOutboxPayload{ActorNPK: userNPK}); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.outboxService.Wake()
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"log"
 
 	"e-memo-job-reservation-api/internal/dto"
 	"e-memo-job-reservation-api/internal/repository"
@@ -14,18 +13,22 @@ import (
 )
 
 type TicketPriorityService struct {
-	db           *sql.DB
-	hub          *websocket.Hub
-	ticketRepo   *repository.TicketRepository
-	employeeRepo *repository.EmployeeRepository
+	db            *sql.DB
+	hub           *websocket.Hub
+	ticketRepo    *repository.TicketRepository
+	employeeRepo  *repository.EmployeeRepository
+	lockService   *EditingLockService
+	outboxService *OutboxService
 }
 
-func NewTicketPriorityService(db *sql.DB, hub *websocket.Hub, ticketRepo *repository.TicketRepository, employeeRepo *repository.EmployeeRepository) *TicketPriorityService {
+func NewTicketPriorityService(db *sql.DB, hub *websocket.Hub, ticketRepo *repository.TicketRepository, employeeRepo *repository.EmployeeRepository, lockService *EditingLockService, outboxService *OutboxService) *TicketPriorityService {
 	return &TicketPriorityService{
-		db:           db,
-		hub:          hub,
-		ticketRepo:   ticketRepo,
-		employeeRepo: employeeRepo,
+		db:            db,
+		hub:           hub,
+		ticketRepo:    ticketRepo,
+		employeeRepo:  employeeRepo,
+		lockService:   lockService,
+		outboxService: outboxService,
 	}
 }
 
@@ -47,6 +50,10 @@ func (s *TicketPriorityService) ReorderTickets(ctx context.Context, req dto.Reor
 	}
 	defer tx.Rollback()
 
+	if err := s.lockService.EnsureNotLockedByOthers(ctx, tx, editingEntityTicketPriority, req.DepartmentTargetID, userNPK); err != nil {
+		return err
+	}
+
 	for i, item := range req.Items {
 		newPriority := i + 1
 		rowsAffected, err := s.ticketRepo.UpdatePriority(ctx, tx, item.TicketID, item.Version, newPriority)
@@ -58,20 +65,18 @@ func (s *TicketPriorityService) ReorderTickets(ctx context.Context, req dto.Reor
 		}
 	}
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
-
 	payload := gin.H{
 		"department_target_id": req.DepartmentTargetID,
 		"message":              "Ticket priorities have been updated.",
 	}
-	message, err := websocket.NewMessage("TICKET_PRIORITY_UPDATED", payload)
-	if err != nil {
-		log.Printf("CRITICAL: Failed to create websocket message for ticket reorder: %v", err)
-	} else {
-		s.hub.BroadcastMessage(message)
+	if err := s.outboxService.RecordDepartmentEvent(ctx, tx, "TICKET_PRIORITY_UPDATED", req.DepartmentTargetID, payload); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
 	}
+	s.outboxService.Wake()
 
 	return nil
 }
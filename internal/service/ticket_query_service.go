@@ -13,13 +13,15 @@ type TicketQueryService struct {
 	ticketRepo            *repository.TicketRepository
 	trackStatusTicketRepo *repository.TrackStatusTicketRepository
 	ticketActionLogRepo   *repository.TicketActionLogRepository
+	ticketLinkRepo        *repository.TicketLinkRepository
 }
 
-func NewTicketQueryService(ticketRepo *repository.TicketRepository, trackStatusTicketRepo *repository.TrackStatusTicketRepository, ticketActionLogRepo *repository.TicketActionLogRepository) *TicketQueryService {
+func NewTicketQueryService(ticketRepo *repository.TicketRepository, trackStatusTicketRepo *repository.TrackStatusTicketRepository, ticketActionLogRepo *repository.TicketActionLogRepository, ticketLinkRepo *repository.TicketLinkRepository) *TicketQueryService {
 	return &TicketQueryService{
 		ticketRepo:            ticketRepo,
 		trackStatusTicketRepo: trackStatusTicketRepo,
 		ticketActionLogRepo:   ticketActionLogRepo,
+		ticketLinkRepo:        ticketLinkRepo,
 	}
 }
 
@@ -29,8 +31,23 @@ func (s *TicketQueryService) GetAllTickets(filters dto.TicketFilter) ([]dto.Tick
 }
 
 // GET BY ID
-func (s *TicketQueryService) GetTicketByID(id int) (*dto.TicketDetailResponse, error) {
-	return s.ticketRepo.FindByID(id)
+// The detail also lists the approvals collected so far for actions that need a quorum and the linked tickets
+func (s *TicketQueryService) GetTicketByID(ctx context.Context, id int) (*dto.TicketDetailResponse, error) {
+	ticket, err := s.ticketRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket.Approvals, err = s.ticketActionLogRepo.FindApprovalProgressByTicketID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket.Links, err = s.ticketLinkRepo.FindByTicketID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return ticket, nil
 }
 
 func (s *TicketQueryService) GetTicketSummary(filters dto.TicketSummaryFilter) ([]dto.TicketSummaryResponse, error) {
@@ -56,3 +73,22 @@ func (s *TicketQueryService) GetLastRejectionDetail(ctx context.Context, ticketI
 
 	return nil, nil
 }
+
+// GET TIMELINE
+func (s *TicketQueryService) GetTicketTimeline(ctx context.Context, ticketID int) ([]dto.TicketTimelineEntryResponse, error) {
+	if _, err := s.ticketRepo.FindByIDAsStruct(ctx, ticketID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("ticket not found")
+		}
+		return nil, err
+	}
+
+	timeline, err := s.ticketActionLogRepo.FindTimelineByTicketID(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if timeline == nil {
+		return []dto.TicketTimelineEntryResponse{}, nil
+	}
+	return timeline, nil
+}
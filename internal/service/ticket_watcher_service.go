@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/repository"
+)
+
+type TicketWatcherService struct {
+	db            *sql.DB
+	watcherRepo   *repository.TicketWatcherRepository
+	ticketRepo    *repository.TicketRepository
+	jobRepo       *repository.JobRepository
+	employeeRepo  *repository.EmployeeRepository
+	outboxService *OutboxService
+}
+
+type TicketWatcherServiceConfig struct {
+	DB            *sql.DB
+	WatcherRepo   *repository.TicketWatcherRepository
+	TicketRepo    *repository.TicketRepository
+	JobRepo       *repository.JobRepository
+	EmployeeRepo  *repository.EmployeeRepository
+	OutboxService *OutboxService
+}
+
+func NewTicketWatcherService(cfg *TicketWatcherServiceConfig) *TicketWatcherService {
+	return &TicketWatcherService{
+		db:            cfg.DB,
+		watcherRepo:   cfg.WatcherRepo,
+		ticketRepo:    cfg.TicketRepo,
+		jobRepo:       cfg.JobRepo,
+		employeeRepo:  cfg.EmployeeRepo,
+		outboxService: cfg.OutboxService,
+	}
+}
+
+// HELPER
+// ensureCanManageWatchers lets anyone follow or unfollow a ticket for themselves, adding or removing
+// someone else is left to the people involved in the ticket
+func (s *TicketWatcherService) ensureCanManageWatchers(ctx context.Context, ticketID int, userNPK string, employeeNPK string) error {
+	ticket, err := s.ticketRepo.FindByIDAsStruct(ctx, ticketID)
+	if err != nil {
+		return errors.New("ticket not found")
+	}
+	if employeeNPK == userNPK {
+		return nil
+	}
+
+	user, err := s.employeeRepo.FindByNPK(userNPK)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	requestor, err := s.employeeRepo.FindByNPK(ticket.Requestor)
+	if err != nil {
+		return errors.New("requestor employee not found")
+	}
+	jobs, err := s.jobRepo.FindAllByTicketID(ctx, ticketID)
+	if err != nil {
+		return err
+	}
+	if len(determineUserContexts(user, ticket, requestor, jobs)) == 0 {
+		return errors.New("user is not involved in this ticket")
+	}
+	return nil
+}
+
+// CREATE
+func (s *TicketWatcherService) AddWatcher(ctx context.Context, ticketID int, userNPK string, req dto.AddTicketWatcherRequest) ([]dto.TicketWatcherResponse, error) {
+	employeeNPK := req.EmployeeNPK
+	if employeeNPK == "" {
+		employeeNPK = userNPK
+	}
+	if err := s.ensureCanManageWatchers(ctx, ticketID, userNPK, employeeNPK); err != nil {
+		return nil, err
+	}
+	employee, err := s.employeeRepo.FindByNPK(employeeNPK)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+	if !employee.IsActive {
+		return nil, errors.New("employee is not active")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := s.watcherRepo.Create(ctx, tx, ticketID, employeeNPK, userNPK); err != nil {
+		return nil, err
+	}
+	if err := s.outboxService.RecordTicketEvent(ctx, tx, "TICKET_UPDATED", ticketID, dto.TicketOutboxPayload{ActorNPK: userNPK}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	s.outboxService.Wake()
+
+	return s.watcherRepo.FindByTicketID(ctx, ticketID)
+}
+
+// GET ALL
+func (s *TicketWatcherService) GetWatchers(ctx context.Context, ticketID int) ([]dto.TicketWatcherResponse, error) {
+	if _, err := s.ticketRepo.FindByIDAsStruct(ctx, ticketID); err != nil {
+		return nil, errors.New("ticket not found")
+	}
+	return s.watcherRepo.FindByTicketID(ctx, ticketID)
+}
+
+// DELETE
+func (s *TicketWatcherService) RemoveWatcher(ctx context.Context, ticketID int, userNPK string, employeeNPK string) error {
+	if employeeNPK == "" {
+		employeeNPK = userNPK
+	}
+	if err := s.ensureCanManageWatchers(ctx, ticketID, userNPK, employeeNPK); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := s.watcherRepo.Delete(ctx, tx, ticketID, employeeNPK); err != nil {
+		return err
+	}
+	if err := s.outboxService.RecordTicketEvent(ctx, tx, "TICKET_UPDATED", ticketID, dto.TicketOutboxPayload{ActorNPK: userNPK}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.outboxService.Wake()
+	return nil
+}
@@ -16,6 +16,12 @@ import (
 	"github.com/lib/pq"
 )
 
+// Actions behind the cancel and reopen commands
+const (
+	actionNameCancel = "Batalkan"
+	actionNameReopen = "Buka Kembali"
+)
+
 type TicketWorkflowService struct {
 	db                    *sql.DB
 	ticketRepo            *repository.TicketRepository
@@ -28,9 +34,11 @@ type TicketWorkflowService struct {
 	actorRoleMappingRepo  *repository.ActorRoleMappingRepository
 	ticketActionLogRepo   *repository.TicketActionLogRepository
 	workflowRepo          *repository.WorkflowRepository
+	workflowVersionRepo   *repository.WorkflowVersionRepository
 	actionService         *TicketActionService
 	hub                   *websocket.Hub
 	queryService          *TicketQueryService
+	outboxService         *OutboxService
 }
 
 type TicketWorkflowServiceConfig struct {
@@ -45,9 +53,11 @@ type TicketWorkflowServiceConfig struct {
 	ActorRoleMappingRepo  *repository.ActorRoleMappingRepository
 	TicketActionLogRepo   *repository.TicketActionLogRepository
 	WorkflowRepo          *repository.WorkflowRepository
+	WorkflowVersionRepo   *repository.WorkflowVersionRepository
 	ActionService         *TicketActionService
 	Hub                   *websocket.Hub
 	QueryService          *TicketQueryService
+	OutboxService         *OutboxService
 }
 
 func NewTicketWorkflowService(cfg *TicketWorkflowServiceConfig) *TicketWorkflowService {
@@ -63,22 +73,51 @@ func NewTicketWorkflowService(cfg *TicketWorkflowServiceConfig) *TicketWorkflowS
 		actorRoleMappingRepo:  cfg.ActorRoleMappingRepo,
 		ticketActionLogRepo:   cfg.TicketActionLogRepo,
 		workflowRepo:          cfg.WorkflowRepo,
+		workflowVersionRepo:   cfg.WorkflowVersionRepo,
 		actionService:         cfg.ActionService,
 		hub:                   cfg.Hub,
 		queryService:          cfg.QueryService,
+		outboxService:         cfg.OutboxService,
 	}
 }
 
 // EXECUTE ACTION TO GET TO THE NEXT STATUS BASED ON STATE
 func (s *TicketWorkflowService) ExecuteAction(ctx context.Context, ticketID int, userNPK string, req dto.ExecuteActionRequest, filesMetadata []model.FileMetadata) error {
+	return s.executeAction(ctx, ticketID, userNPK, req, filesMetadata, nil)
+}
+
+// executeAction runs the action, inTx is called after the action is logged so commands built on
+// an action can write their own rows in the same transaction.
+// The ticket is locked before the action is validated, so concurrent actions are validated one after the other
+// against the status the previous one left the ticket in.
+func (s *TicketWorkflowService) executeAction(ctx context.Context, ticketID int, userNPK string, req dto.ExecuteActionRequest, filesMetadata []model.FileMetadata, inTx func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := s.ticketRepo.LockByID(ctx, tx, ticketID); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("ticket not found")
+		}
+		return err
+	}
+
 	availableActions, err := s.actionService.GetAvailableActions(ctx, ticketID, userNPK)
 	if err != nil {
 		return err
 	}
 
 	var selectedAction *dto.AvailableTicketActionResponse
+	var blockedAction *dto.AvailableTicketActionResponse
 	for _, action := range availableActions {
 		if action.ActionName == req.ActionName {
+			if action.IsBlocked {
+				act := action
+				blockedAction = &act
+				continue
+			}
 			act := action
 			selectedAction = &act
 			break
@@ -86,6 +125,12 @@ func (s *TicketWorkflowService) ExecuteAction(ctx context.Context, ticketID int,
 	}
 
 	if selectedAction == nil {
+		if blockedAction != nil {
+			if blockedAction.IsBlockedByTicket && blockedAction.BlockedReason != nil {
+				return errors.New(*blockedAction.BlockedReason)
+			}
+			return errors.New("transition prerequisite has not been met")
+		}
 		return errors.New("user does not have the required role or action is not allowed from the current status")
 	}
 
@@ -98,7 +143,32 @@ func (s *TicketWorkflowService) ExecuteAction(ctx context.Context, ticketID int,
 	}
 
 	if selectedAction.RequireFile && len(filesMetadata) == 0 {
-		return errors.New("file upload is required for this action")
+		// Jobs completed on their own already brought their report files
+		openJobCount := 1
+		if req.ActionName == "Selesaikan Job" {
+			openJobs, err := s.jobRepo.FindOpenByTicketIDForUpdate(ctx, tx, ticketID)
+			if err != nil {
+				return errors.New("failed to retrieve existing job data")
+			}
+			openJobCount = len(openJobs)
+		}
+		if openJobCount > 0 {
+			return errors.New("file upload is required for this action")
+		}
+	}
+
+	reasonCode, err := selectReasonCode(selectedAction.ReasonCodes, req.ReasonCodeID)
+	if err != nil {
+		return err
+	}
+	var reasonCodeID sql.NullInt32
+	notificationReason := req.Reason
+	if reasonCode != nil {
+		reasonCodeID = sql.NullInt32{Int32: int32(reasonCode.ID), Valid: true}
+		notificationReason = reasonCode.Name
+		if req.Reason != "" {
+			notificationReason += " - " + req.Reason
+		}
 	}
 
 	var finalToStatusID int
@@ -109,7 +179,12 @@ func (s *TicketWorkflowService) ExecuteAction(ctx context.Context, ticketID int,
 			return errors.New("user not found")
 		}
 
-		initialStatusID, err := s.workflowRepo.GetInitialStatusByPosition(ctx, user.Position.ID)
+		ticket, err := s.ticketRepo.FindByIDAsStruct(ctx, ticketID)
+		if err != nil {
+			return errors.New("ticket not found")
+		}
+
+		initialStatusID, err := ticketInitialStatusID(ctx, s.workflowRepo, s.workflowVersionRepo, ticket, user.Position.ID)
 		if err != nil {
 			return errors.New("no workflow defined for this user's position")
 		}
@@ -118,42 +193,88 @@ func (s *TicketWorkflowService) ExecuteAction(ctx context.Context, ticketID int,
 		finalToStatusID = selectedAction.ToStatusID
 	}
 
-	tx, err := s.db.BeginTx(ctx, nil)
+	// The selected transition has to start from the status the ticket is in within this transaction
+	currentStatusID, err := s.trackStatusTicketRepo.FindCurrentStatusID(ctx, tx, ticketID)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("current status not found")
+		}
 		return err
 	}
-	defer tx.Rollback()
+	if selectedAction.FromStatusID != currentStatusID {
+		return errors.New("action not allowed from the current status")
+	}
+
+	// Actions needing a quorum only move the ticket once enough distinct actor roles have approved
+	var approvalRoleID sql.NullInt32
+	isPartialApproval := false
+	if selectedAction.RequiredApprovals > 1 {
+		approvals, err := s.ticketActionLogRepo.FindPendingApprovals(ctx, tx, ticketID, currentStatusID, selectedAction.ActionID)
+		if err != nil {
+			return err
+		}
+		approvingAction, collected, err := approvalActionFor(availableActions, req.ActionName, userNPK, approvals)
+		if err != nil {
+			return err
+		}
+		selectedAction = approvingAction
+		approvalRoleID = sql.NullInt32{Int32: int32(approvingAction.ActorRoleID), Valid: true}
+		isPartialApproval = collected+1 < selectedAction.RequiredApprovals
+	}
+	if isPartialApproval {
+		finalToStatusID = currentStatusID
+	}
 
 	var oldReportFiles []model.FileMetadata
+	var completedJobID int
 
-	if req.ActionName == "Selesaikan Job" {
-		if len(filesMetadata) > 0 {
+	// The ticket is only finished once all of its jobs are done, the action completes the last open one
+	if req.ActionName == "Selesaikan Job" && !isPartialApproval {
+		openJobs, err := s.jobRepo.FindOpenByTicketIDForUpdate(ctx, tx, ticketID)
+		if err != nil {
+			return errors.New("failed to retrieve existing job data")
+		}
+		if len(openJobs) > 1 {
+			return errors.New("ticket still has unfinished jobs")
+		}
+		if len(openJobs) == 1 {
 			// Get existing report files before updating (for cleanup after commit)
-			existingJob, err := s.jobRepo.FindByTicketID(ctx, ticketID)
-			if err != nil && err != sql.ErrNoRows {
-				return errors.New("failed to retrieve existing job data")
-			}
-			if existingJob != nil {
-				oldReportFiles = existingJob.ReportFiles
+			if len(filesMetadata) > 0 {
+				oldReportFiles = openJobs[0].ReportFiles
 			}
-
-			err = s.jobRepo.UpdateJobCompletionDetails(ctx, tx, ticketID, filesMetadata, req.SpendingAmount)
-			if err != nil {
-				if err == sql.ErrNoRows {
-					return errors.New("job associated with this ticket not found")
-				}
+			if err := s.jobRepo.Complete(ctx, tx, openJobs[0].ID, filesMetadata, req.SpendingAmount, userNPK); err != nil {
 				return errors.New("failed to update job completion details")
 			}
+			completedJobID = openJobs[0].ID
 		}
 	}
 
-	currentStatusID, _, _ := s.trackStatusTicketRepo.GetCurrentStatusByTicketID(ctx, ticketID)
+	// Reopening a finished ticket puts its jobs back to work, so does sending completed jobs back
+	// (Tolak Hasil Job or any other transition out of the completed status into a status the jobs are worked in)
+	if !isPartialApproval && finalToStatusID != currentStatusID {
+		reopenJobs := req.ActionName == actionNameReopen
+		if !reopenJobs {
+			if reopenJobs, err = s.isSendingJobsBack(ctx, ticketID, currentStatusID, finalToStatusID); err != nil {
+				return err
+			}
+		}
+		if reopenJobs {
+			if err := s.jobRepo.ReopenByTicketID(ctx, tx, ticketID); err != nil {
+				return err
+			}
+		}
+	}
 
 	var filePathsForLog []string
 	for _, meta := range filesMetadata {
 		filePathsForLog = append(filePathsForLog, meta.FilePath)
 	}
 
+	var onBehalfOf sql.NullString
+	if selectedAction.OnBehalfOfNPK != nil {
+		onBehalfOf = sql.NullString{String: *selectedAction.OnBehalfOfNPK, Valid: true}
+	}
+
 	logEntry := model.TicketActionLog{
 		TicketID:       int64(ticketID),
 		ActionID:       selectedAction.ActionID,
@@ -162,18 +283,55 @@ func (s *TicketWorkflowService) ExecuteAction(ctx context.Context, ticketID int,
 		FilePath:       pq.StringArray(filePathsForLog),
 		FromStatusID:   sql.NullInt32{Int32: int32(currentStatusID), Valid: true},
 		ToStatusID:     finalToStatusID,
+
+		OnBehalfOfNpk:       onBehalfOf,
+		ApprovalActorRoleID: approvalRoleID,
+		IsPartialApproval:   isPartialApproval,
+		ReasonCodeID:        reasonCodeID,
 	}
 	if err := s.ticketActionLogRepo.Create(ctx, tx, logEntry); err != nil {
 		return err
 	}
+	if inTx != nil {
+		if err := inTx(tx); err != nil {
+			return err
+		}
+	}
+
+	if isPartialApproval {
+		err = s.outboxService.RecordTicketEvent(ctx, tx, "TICKET_UPDATED", ticketID, dto.TicketOutboxPayload{
+			ActorNPK:   userNPK,
+			ActionName: req.ActionName,
+			Reason:     notificationReason,
+		})
+		if err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		s.outboxService.Wake()
+		return nil
+	}
 
 	if err := s.trackStatusTicketRepo.UpdateStatus(ctx, tx, ticketID, finalToStatusID); err != nil {
 		return err
 	}
 
+	err = s.outboxService.RecordTicketEvent(ctx, tx, "TICKET_STATUS_CHANGED", ticketID, dto.TicketOutboxPayload{
+		ActorNPK:       userNPK,
+		ActionName:     req.ActionName,
+		Reason:         notificationReason,
+		CompletedJobID: completedJobID,
+	})
+	if err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return err
 	}
+	s.outboxService.Wake()
 
 	// After successful commit, clean up old report files from storage
 	if req.ActionName == "Selesaikan Job" && len(filesMetadata) > 0 && len(oldReportFiles) > 0 {
@@ -184,19 +342,107 @@ func (s *TicketWorkflowService) ExecuteAction(ctx context.Context, ticketID int,
 		}
 	}
 
-	updatedTicketDetail, err := s.queryService.GetTicketByID(ticketID)
+	return nil
+}
+
+// jobCompletionState tells whether the ticket's jobs can be completed in its current status
+// and whether completing them needs a report file
+func (s *TicketWorkflowService) jobCompletionState(ctx context.Context, ticketID int) (bool, bool, error) {
+	ticket, err := s.ticketRepo.FindByIDAsStruct(ctx, ticketID)
 	if err != nil {
-		log.Printf("CRITICAL: Failed to fetch updated ticket for broadcast after action. TicketID: %d, Error: %v", ticketID, err)
-	} else {
-		message, err := websocket.NewMessage("TICKET_STATUS_CHANGED", updatedTicketDetail)
-		if err != nil {
-			log.Printf("CRITICAL: Failed to create websocket message for status change: %v", err)
-		} else {
-			s.hub.BroadcastMessage(message)
+		return false, false, errors.New("ticket not found")
+	}
+	currentStatusID, _, err := s.trackStatusTicketRepo.GetCurrentStatusByTicketID(ctx, ticketID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, false, nil
 		}
+		return false, false, err
 	}
+	return jobCompletionRequirement(ctx, s.statusTransitionRepo, s.workflowVersionRepo, ticket, currentStatusID)
+}
 
-	return nil
+// isSendingJobsBack tells whether the transition leaves the status reached by completing the jobs
+// for a status the jobs are worked in
+func (s *TicketWorkflowService) isSendingJobsBack(ctx context.Context, ticketID int, fromStatusID int, toStatusID int) (bool, error) {
+	ticket, err := s.ticketRepo.FindByIDAsStruct(ctx, ticketID)
+	if err != nil {
+		return false, errors.New("ticket not found")
+	}
+	isCompleted, err := isJobCompletedStatus(ctx, s.statusTransitionRepo, s.workflowVersionRepo, ticket, fromStatusID)
+	if err != nil || !isCompleted {
+		return false, err
+	}
+	isWorkStatus, _, err := jobCompletionRequirement(ctx, s.statusTransitionRepo, s.workflowVersionRepo, ticket, toStatusID)
+	return isWorkStatus, err
+}
+
+// CANCEL TICKET
+// The requestor's cancel command, executed as the cancel action so its transitions and actor roles apply
+func (s *TicketWorkflowService) CancelTicket(ctx context.Context, ticketID int, userNPK string, req dto.TicketReasonRequest) error {
+	return s.ExecuteAction(ctx, ticketID, userNPK, dto.ExecuteActionRequest{
+		ActionName:   actionNameCancel,
+		Reason:       req.Reason,
+		ReasonCodeID: req.ReasonCodeID,
+	}, nil)
+}
+
+// REOPEN TICKET
+// The target department's reopen command for a closed job, executed as the reopen action
+func (s *TicketWorkflowService) ReopenTicket(ctx context.Context, ticketID int, userNPK string, req dto.TicketReasonRequest) error {
+	return s.ExecuteAction(ctx, ticketID, userNPK, dto.ExecuteActionRequest{
+		ActionName:   actionNameReopen,
+		Reason:       req.Reason,
+		ReasonCodeID: req.ReasonCodeID,
+	}, nil)
+}
+
+// HELPER
+// selectReasonCode checks the given code against the active codes of the action, one is required when the action has any
+func selectReasonCode(reasonCodes []dto.ReasonCodeOption, reasonCodeID *int) (*dto.ReasonCodeOption, error) {
+	if reasonCodeID == nil {
+		if len(reasonCodes) > 0 {
+			return nil, errors.New("reason code is required for this action")
+		}
+		return nil, nil
+	}
+	for _, reasonCode := range reasonCodes {
+		if reasonCode.ID == *reasonCodeID {
+			rc := reasonCode
+			return &rc, nil
+		}
+	}
+	return nil, errors.New("invalid reason code for this action")
+}
+
+// approvalActionFor picks the actor role the user approves as, a user approves only once and every role counts once.
+// A delegated role is skipped when the employee it belongs to has approved already.
+// It also returns how many distinct roles have approved already.
+func approvalActionFor(availableActions []dto.AvailableTicketActionResponse, actionName string, userNPK string, approvals []repository.TicketApproval) (*dto.AvailableTicketActionResponse, int, error) {
+	approvedRoles := make(map[int]bool, len(approvals))
+	approvedBy := make(map[string]bool, len(approvals))
+	for _, approval := range approvals {
+		if approval.PerformedByNPK == userNPK {
+			return nil, 0, errors.New("user has already approved this action")
+		}
+		approvedRoles[approval.ActorRoleID] = true
+		approvedBy[approval.PerformedByNPK] = true
+		if approval.OnBehalfOfNPK.Valid {
+			approvedBy[approval.OnBehalfOfNPK.String] = true
+		}
+	}
+
+	for _, action := range availableActions {
+		if action.ActionName != actionName || action.IsBlocked || approvedRoles[action.ActorRoleID] {
+			continue
+		}
+		if action.OnBehalfOfNPK != nil && approvedBy[*action.OnBehalfOfNPK] {
+			continue
+		}
+		act := action
+		return &act, len(approvedRoles), nil
+	}
+	return nil, 0, errors.New("actor role of the user has already approved this action")
 }
 
 func (s *TicketWorkflowService) ValidateAndGetTransition(ctx context.Context, currentStatusID int, actionName string) (toStatusID int, allowedRoleIDs []int, err error) {
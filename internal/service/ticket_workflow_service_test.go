@@ -0,0 +1,159 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/repository"
+)
+
+func TestApprovalActionFor(t *testing.T) {
+	const actionName = "Setujui"
+	actions := []dto.AvailableTicketActionResponse{
+		{ActionName: "Tolak", ActorRoleID: 1},
+		{ActionName: actionName, ActorRoleID: 1},
+		{ActionName: actionName, ActorRoleID: 2, OnBehalfOfNPK: stringPtr("E002")},
+	}
+
+	tests := []struct {
+		name          string
+		actions       []dto.AvailableTicketActionResponse
+		approvals     []repository.TicketApproval
+		wantRoleID    int
+		wantApprovals int
+		wantErr       string
+	}{
+		{
+			name:       "first approval takes the first matching role",
+			actions:    actions,
+			wantRoleID: 1,
+		},
+		{
+			name:    "approved roles are skipped",
+			actions: actions,
+			approvals: []repository.TicketApproval{
+				{ActorRoleID: 1, PerformedByNPK: "E003"},
+			},
+			wantRoleID:    2,
+			wantApprovals: 1,
+		},
+		{
+			name:    "user approves only once",
+			actions: actions,
+			approvals: []repository.TicketApproval{
+				{ActorRoleID: 3, PerformedByNPK: "E001"},
+			},
+			wantErr: "user has already approved this action",
+		},
+		{
+			name:    "delegated role skipped once its owner approved",
+			actions: actions,
+			approvals: []repository.TicketApproval{
+				{ActorRoleID: 1, PerformedByNPK: "E002"},
+			},
+			wantErr: "actor role of the user has already approved this action",
+		},
+		{
+			name:    "delegated role skipped once approved on behalf of its owner",
+			actions: actions,
+			approvals: []repository.TicketApproval{
+				{ActorRoleID: 1, PerformedByNPK: "E004", OnBehalfOfNPK: sql.NullString{String: "E002", Valid: true}},
+			},
+			wantErr: "actor role of the user has already approved this action",
+		},
+		{
+			name: "blocked actions are not picked",
+			actions: []dto.AvailableTicketActionResponse{
+				{ActionName: actionName, ActorRoleID: 1, IsBlocked: true},
+			},
+			wantErr: "actor role of the user has already approved this action",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, approvedCount, err := approvalActionFor(tt.actions, actionName, "E001", tt.approvals)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if action.ActionName != actionName || action.ActorRoleID != tt.wantRoleID {
+				t.Errorf("action = %s as role %d, want %s as role %d", action.ActionName, action.ActorRoleID, actionName, tt.wantRoleID)
+			}
+			if approvedCount != tt.wantApprovals {
+				t.Errorf("approved roles = %d, want %d", approvedCount, tt.wantApprovals)
+			}
+		})
+	}
+}
+
+func TestSelectReasonCode(t *testing.T) {
+	reasonCodes := []dto.ReasonCodeOption{
+		{ID: 1, Code: "DUP", Name: "Duplicate request"},
+		{ID: 2, Code: "OBS", Name: "No longer needed"},
+	}
+
+	tests := []struct {
+		name         string
+		reasonCodes  []dto.ReasonCodeOption
+		reasonCodeID *int
+		wantID       int
+		wantErr      string
+	}{
+		{
+			name: "no code for an action without codes",
+		},
+		{
+			name:        "code required when the action has codes",
+			reasonCodes: reasonCodes,
+			wantErr:     "reason code is required for this action",
+		},
+		{
+			name:         "code of the action",
+			reasonCodes:  reasonCodes,
+			reasonCodeID: intPtr(2),
+			wantID:       2,
+		},
+		{
+			name:         "code of another action",
+			reasonCodes:  reasonCodes,
+			reasonCodeID: intPtr(9),
+			wantErr:      "invalid reason code for this action",
+		},
+		{
+			name:         "code given to an action without codes",
+			reasonCodeID: intPtr(1),
+			wantErr:      "invalid reason code for this action",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasonCode, err := selectReasonCode(tt.reasonCodes, tt.reasonCodeID)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantID == 0 {
+				if reasonCode != nil {
+					t.Errorf("reason code = %d, want none", reasonCode.ID)
+				}
+				return
+			}
+			if reasonCode == nil || reasonCode.ID != tt.wantID {
+				t.Errorf("reason code = %v, want %d", reasonCode, tt.wantID)
+			}
+		})
+	}
+}
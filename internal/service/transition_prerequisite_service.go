@@ -0,0 +1,71 @@
+package service
+
+import (
+	"errors"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type TransitionPrerequisiteService struct {
+	repo *repository.TransitionPrerequisiteRepository
+}
+
+func NewTransitionPrerequisiteService(repo *repository.TransitionPrerequisiteRepository) *TransitionPrerequisiteService {
+	return &TransitionPrerequisiteService{repo: repo}
+}
+
+// HELPER
+func mapTransitionPrerequisiteError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return errors.New("prerequisite already exists for this transition")
+		case "23503":
+			return errors.New("invalid transition_id or required_status_id")
+		}
+	}
+	return err
+}
+
+// CREATE
+func (s *TransitionPrerequisiteService) CreateTransitionPrerequisite(req dto.CreateTransitionPrerequisiteRequest) (*model.TransitionPrerequisite, error) {
+	newPrerequisite, err := s.repo.Create(req)
+	if err != nil {
+		return nil, mapTransitionPrerequisiteError(err)
+	}
+	return newPrerequisite, nil
+}
+
+// GET ALL
+func (s *TransitionPrerequisiteService) GetAllTransitionPrerequisites(filters dto.TransitionPrerequisiteFilter) ([]dto.TransitionPrerequisiteDetailResponse, error) {
+	return s.repo.FindAll(filters)
+}
+
+// GET BY TRANSITION ID
+func (s *TransitionPrerequisiteService) GetTransitionPrerequisiteByTransitionID(transitionID int) (*dto.TransitionPrerequisiteDetailResponse, error) {
+	return s.repo.FindByTransitionID(transitionID)
+}
+
+// UPDATE
+func (s *TransitionPrerequisiteService) UpdateTransitionPrerequisite(transitionID int, req dto.UpdateTransitionPrerequisiteRequest) (*model.TransitionPrerequisite, error) {
+	updated, err := s.repo.Update(transitionID, req)
+	if err != nil {
+		return nil, mapTransitionPrerequisiteError(err)
+	}
+	return updated, nil
+}
+
+// CHANGE ACTIVE STATUS
+func (s *TransitionPrerequisiteService) UpdateTransitionPrerequisiteActiveStatus(transitionID int, req dto.UpdateTransitionPrerequisiteStatusRequest) error {
+	return s.repo.UpdateActiveStatus(transitionID, req.IsActive)
+}
+
+// DELETE
+func (s *TransitionPrerequisiteService) DeleteTransitionPrerequisite(transitionID int) error {
+	return s.repo.Delete(transitionID)
+}
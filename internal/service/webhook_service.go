@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+	"e-memo-job-reservation-api/internal/websocket"
+)
+
+// Events a webhook endpoint can subscribe to. Most carry the same message as the hub broadcast,
+// JOB_PIC_ASSIGNED and JOB_COMPLETED are published for webhooks only.
+// JOB_COMPLETED is published once per completed job, whether its PIC completed it through /jobs/:id/complete
+// or the Selesaikan Job action completed the last open job. Its payload is the ticket with completed_job_id.
+var webhookEvents = map[string]bool{
+	"TICKET_CREATED":          true,
+	"TICKET_UPDATED":          true,
+	"TICKET_STATUS_CHANGED":   true,
+	"TICKET_PRIORITY_UPDATED": true,
+	"TICKET_SLA_WARNING":      true,
+	"TICKET_SLA_BREACHED":     true,
+	"JOB_PIC_ASSIGNED":        true,
+	"JOB_COMPLETED":           true,
+	"JOB_PRIORITY_UPDATED":    true,
+}
+
+type WebhookService struct {
+	endpointRepo *repository.WebhookEndpointRepository
+	deliveryRepo *repository.WebhookDeliveryRepository
+}
+
+func NewWebhookService(endpointRepo *repository.WebhookEndpointRepository, deliveryRepo *repository.WebhookDeliveryRepository) *WebhookService {
+	return &WebhookService{endpointRepo: endpointRepo, deliveryRepo: deliveryRepo}
+}
+
+// HELPER
+func validateWebhookEvents(events []string) error {
+	for _, event := range events {
+		if !webhookEvents[event] {
+			return errors.New("events contain an unsupported event type")
+		}
+	}
+	return nil
+}
+
+// PUBLISH EVENT
+// Queues a delivery for every subscribed endpoint, the message has the same shape as a hub broadcast.
+// The error is returned so the caller (usually the outbox dispatcher) can retry instead of losing the webhook.
+func (s *WebhookService) PublishEvent(event string, data interface{}, outboxEventID *int64) error {
+	if !webhookEvents[event] {
+		return nil
+	}
+	message, err := websocket.NewMessage(event, data)
+	if err != nil {
+		return err
+	}
+	_, err = s.deliveryRepo.EnqueueForEvent(context.Background(), event, message, outboxEventID)
+	return err
+}
+
+// PUBLISH TICKET EVENT
+func (s *WebhookService) PublishTicketEvent(event string, ticket *dto.TicketDetailResponse, outboxEventID int64) error {
+	return s.PublishEvent(event, ticket, &outboxEventID)
+}
+
+// CREATE
+func (s *WebhookService) CreateEndpoint(req dto.CreateWebhookEndpointRequest) (*model.WebhookEndpoint, error) {
+	if err := validateWebhookEvents(req.Events); err != nil {
+		return nil, err
+	}
+	return s.endpointRepo.Create(req)
+}
+
+// GET ALL
+func (s *WebhookService) GetAllEndpoints() ([]model.WebhookEndpoint, error) {
+	return s.endpointRepo.FindAll()
+}
+
+// GET BY ID
+func (s *WebhookService) GetEndpointByID(id int) (*model.WebhookEndpoint, error) {
+	return s.endpointRepo.FindByID(id)
+}
+
+// UPDATE
+func (s *WebhookService) UpdateEndpoint(id int, req dto.UpdateWebhookEndpointRequest) (*model.WebhookEndpoint, error) {
+	if err := validateWebhookEvents(req.Events); err != nil {
+		return nil, err
+	}
+	return s.endpointRepo.Update(id, req)
+}
+
+// CHANGE ACTIVE STATUS
+func (s *WebhookService) UpdateEndpointActiveStatus(id int, req dto.UpdateWebhookEndpointStatusRequest) error {
+	return s.endpointRepo.UpdateActiveStatus(id, req.IsActive)
+}
+
+// DELETE
+func (s *WebhookService) DeleteEndpoint(id int) error {
+	return s.endpointRepo.Delete(id)
+}
+
+// GET DELIVERIES
+func (s *WebhookService) GetDeliveries(ctx context.Context, filters dto.WebhookDeliveryFilter) (*dto.PaginatedWebhookDeliveryResponse, error) {
+	if filters.Page <= 0 {
+		filters.Page = 1
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = 10
+	}
+
+	deliveries, totalItems, err := s.deliveryRepo.FindAll(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	if deliveries == nil {
+		deliveries = []dto.WebhookDeliveryResponse{}
+	}
+
+	totalPages := 0
+	if totalItems > 0 {
+		totalPages = int((totalItems + int64(filters.Limit) - 1) / int64(filters.Limit))
+	}
+
+	return &dto.PaginatedWebhookDeliveryResponse{
+		Data: deliveries,
+		Pagination: dto.Pagination{
+			CurrentPage: filters.Page,
+			TotalPages:  totalPages,
+			TotalItems:  totalItems,
+			PageSize:    filters.Limit,
+		},
+	}, nil
+}
+
+// REDELIVER
+func (s *WebhookService) Redeliver(ctx context.Context, deliveryID int64) (*model.WebhookDelivery, error) {
+	return s.deliveryRepo.CreateRedelivery(ctx, deliveryID)
+}
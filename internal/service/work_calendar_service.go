@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type WorkCalendarService struct {
+	repo *repository.WorkCalendarRepository
+	db   *sql.DB
+}
+
+func NewWorkCalendarService(repo *repository.WorkCalendarRepository, db *sql.DB) *WorkCalendarService {
+	return &WorkCalendarService{repo: repo, db: db}
+}
+
+// HELPER
+func parseHolidayDate(value string) (time.Time, error) {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, errors.New("invalid holiday_date format, expected YYYY-MM-DD")
+	}
+	return date, nil
+}
+
+func mapHolidayError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return errors.New("holiday on this date already exists")
+	}
+	return err
+}
+
+// GET WORK DAYS
+func (s *WorkCalendarService) GetWorkDays() ([]model.WorkDay, error) {
+	return s.repo.FindAllWorkDays()
+}
+
+// UPDATE WORK DAYS
+func (s *WorkCalendarService) UpdateWorkDays(ctx context.Context, req dto.UpdateWorkDaysRequest) ([]model.WorkDay, error) {
+	current, err := s.repo.FindAllWorkDays()
+	if err != nil {
+		return nil, err
+	}
+
+	isWorkDay := make(map[int]bool)
+	for _, wd := range current {
+		isWorkDay[wd.DayOfWeek] = wd.IsWorkDay
+	}
+	for _, item := range req.WorkDays {
+		isWorkDay[*item.DayOfWeek] = item.IsWorkDay
+	}
+
+	hasWorkDay := false
+	for _, value := range isWorkDay {
+		if value {
+			hasWorkDay = true
+			break
+		}
+	}
+	if !hasWorkDay {
+		return nil, errors.New("at least one work day is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	for _, item := range req.WorkDays {
+		if err := s.repo.UpsertWorkDay(ctx, tx, *item.DayOfWeek, item.IsWorkDay); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindAllWorkDays()
+}
+
+// CREATE HOLIDAY
+func (s *WorkCalendarService) CreateHoliday(req dto.CreateHolidayRequest) (*model.Holiday, error) {
+	holidayDate, err := parseHolidayDate(req.HolidayDate)
+	if err != nil {
+		return nil, err
+	}
+
+	newHoliday, err := s.repo.CreateHoliday(holidayDate, req.Name)
+	if err != nil {
+		return nil, mapHolidayError(err)
+	}
+	return newHoliday, nil
+}
+
+// GET ALL HOLIDAYS
+func (s *WorkCalendarService) GetAllHolidays(filters dto.HolidayFilter) ([]model.Holiday, error) {
+	return s.repo.FindAllHolidays(filters)
+}
+
+// GET HOLIDAY BY ID
+func (s *WorkCalendarService) GetHolidayByID(id int) (*model.Holiday, error) {
+	return s.repo.FindHolidayByID(id)
+}
+
+// UPDATE HOLIDAY
+func (s *WorkCalendarService) UpdateHoliday(id int, req dto.UpdateHolidayRequest) (*model.Holiday, error) {
+	holidayDate, err := parseHolidayDate(req.HolidayDate)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.UpdateHoliday(id, holidayDate, req.Name, req.IsActive)
+	if err != nil {
+		return nil, mapHolidayError(err)
+	}
+	return updated, nil
+}
+
+// CHANGE HOLIDAY ACTIVE STATUS
+func (s *WorkCalendarService) UpdateHolidayActiveStatus(id int, req dto.UpdateHolidayStatusRequest) error {
+	return s.repo.UpdateHolidayActiveStatus(id, req.IsActive)
+}
+
+// DELETE HOLIDAY
+func (s *WorkCalendarService) DeleteHoliday(id int) error {
+	return s.repo.DeleteHoliday(id)
+}
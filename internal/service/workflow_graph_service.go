@@ -0,0 +1,461 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+)
+
+type WorkflowGraphService struct {
+	db                      *sql.DB
+	workflowRepo            *repository.WorkflowRepository
+	stepRepo                *repository.WorkflowStepRepository
+	statusTicketRepo        *repository.StatusTicketRepository
+	statusTransitionRepo    *repository.StatusTransitionRepository
+	actionRepo              *repository.ActionRepository
+	actorRoleRepo           *repository.ActorRoleRepository
+	statusTransitionService *StatusTransitionService
+}
+
+type WorkflowGraphServiceConfig struct {
+	DB                      *sql.DB
+	WorkflowRepo            *repository.WorkflowRepository
+	StepRepo                *repository.WorkflowStepRepository
+	StatusTicketRepo        *repository.StatusTicketRepository
+	StatusTransitionRepo    *repository.StatusTransitionRepository
+	ActionRepo              *repository.ActionRepository
+	ActorRoleRepo           *repository.ActorRoleRepository
+	StatusTransitionService *StatusTransitionService
+}
+
+func NewWorkflowGraphService(cfg *WorkflowGraphServiceConfig) *WorkflowGraphService {
+	return &WorkflowGraphService{
+		db:                      cfg.DB,
+		workflowRepo:            cfg.WorkflowRepo,
+		stepRepo:                cfg.StepRepo,
+		statusTicketRepo:        cfg.StatusTicketRepo,
+		statusTransitionRepo:    cfg.StatusTransitionRepo,
+		actionRepo:              cfg.ActionRepo,
+		actorRoleRepo:           cfg.ActorRoleRepo,
+		statusTransitionService: cfg.StatusTransitionService,
+	}
+}
+
+// EXPORT GRAPH
+// The steps in order followed by every transition leaving a step, or starting a ticket in one
+func (s *WorkflowGraphService) ExportGraph(ctx context.Context, workflowID int) (*dto.WorkflowGraphExport, error) {
+	workflow, err := s.workflowRepo.FindByID(workflowID)
+	if err != nil {
+		return nil, err
+	}
+	steps, err := s.stepRepo.FindByWorkflowID(workflowID)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := s.statusTicketRepo.FindAll(dto.StatusTicketFilter{})
+	if err != nil {
+		return nil, err
+	}
+	transitions, err := s.statusTransitionRepo.FindAll(dto.StatusTransitionFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	statusByID := make(map[int]model.StatusTicket, len(statuses))
+	for _, st := range statuses {
+		statusByID[st.ID] = st
+	}
+
+	graph := &dto.WorkflowGraphExport{
+		Workflow:    workflow.Name,
+		Statuses:    []dto.WorkflowGraphStatus{},
+		Transitions: []dto.WorkflowGraphEdgeByName{},
+	}
+	inWorkflow := make(map[int]bool)
+	listed := make(map[int]bool)
+	for _, step := range steps {
+		sequence := step.StepSequence
+		st := statusByID[step.StatusTicketID]
+		graph.Statuses = append(graph.Statuses, dto.WorkflowGraphStatus{
+			Name:         st.Name,
+			StepSequence: &sequence,
+			HexColor:     st.HexColor,
+			IsTerminal:   st.IsTerminal,
+		})
+		inWorkflow[step.StatusTicketID] = true
+		listed[step.StatusTicketID] = true
+	}
+
+	for _, t := range transitions {
+		if !workflowGraphInScope(t, inWorkflow) {
+			continue
+		}
+		if !listed[t.ToStatusID] {
+			st := statusByID[t.ToStatusID]
+			graph.Statuses = append(graph.Statuses, dto.WorkflowGraphStatus{
+				Name:       st.Name,
+				HexColor:   st.HexColor,
+				IsTerminal: st.IsTerminal,
+			})
+			listed[t.ToStatusID] = true
+		}
+		graph.Transitions = append(graph.Transitions, dto.WorkflowGraphEdgeByName{
+			FromStatus:        t.FromStatusName,
+			ToStatus:          t.ToStatusName,
+			Action:            t.ActionName,
+			ActorRole:         t.ActorRoleName,
+			RequireReason:     t.RequireReason,
+			ReasonLabel:       t.ReasonLabel,
+			RequireFile:       t.RequireFile,
+			IsActive:          t.IsActive,
+			RequiredApprovals: t.RequiredApprovals,
+		})
+	}
+
+	return graph, nil
+}
+
+// IMPORT GRAPH
+// Resolves an exported graph by name, then replaces the steps of the workflow and the transitions
+// leaving them in one transaction. Statuses, actions and actor roles must already exist.
+func (s *WorkflowGraphService) ImportGraph(ctx context.Context, workflowID int, req dto.WorkflowGraphExport) (*dto.ImportWorkflowGraphResponse, error) {
+	workflow, err := s.workflowRepo.FindByID(workflowID)
+	if err != nil {
+		return nil, err
+	}
+	currentSteps, err := s.stepRepo.FindByWorkflowID(workflowID)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := s.statusTicketRepo.FindAll(dto.StatusTicketFilter{})
+	if err != nil {
+		return nil, err
+	}
+	actions, err := s.actionRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	actorRoles, err := s.actorRoleRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	statusIDByName := make(map[string]int, len(statuses))
+	for _, st := range statuses {
+		statusIDByName[st.Name] = st.ID
+	}
+	actionIDByName := make(map[string]int, len(actions))
+	for _, a := range actions {
+		actionIDByName[a.Name] = a.ID
+	}
+	actorRoleIDByName := make(map[string]int, len(actorRoles))
+	for _, ar := range actorRoles {
+		actorRoleIDByName[ar.Name] = ar.ID
+	}
+
+	var issues []dto.WorkflowGraphIssue
+	unknown := func(code, kind, name string) {
+		issues = append(issues, dto.WorkflowGraphIssue{
+			Code:    code,
+			Message: fmt.Sprintf("%s '%s' does not exist", kind, name),
+		})
+	}
+
+	var stepStatuses []dto.WorkflowGraphStatus
+	for _, st := range req.Statuses {
+		if st.StepSequence != nil {
+			stepStatuses = append(stepStatuses, st)
+		}
+	}
+	sort.SliceStable(stepStatuses, func(i, j int) bool {
+		return *stepStatuses[i].StepSequence < *stepStatuses[j].StepSequence
+	})
+	if len(stepStatuses) == 0 {
+		issues = append(issues, dto.WorkflowGraphIssue{
+			Code:    "NO_STEPS",
+			Message: "at least one status needs a step_sequence",
+		})
+	}
+
+	var stepStatusIDs []int
+	inWorkflow := make(map[int]bool)
+	for _, st := range stepStatuses {
+		id, ok := statusIDByName[st.Name]
+		if !ok {
+			unknown("UNKNOWN_STATUS", "status", st.Name)
+			continue
+		}
+		if inWorkflow[id] {
+			statusID := id
+			issues = append(issues, dto.WorkflowGraphIssue{
+				Code:     "DUPLICATE_STEP",
+				Message:  fmt.Sprintf("status '%s' is listed as a step more than once", st.Name),
+				StatusID: &statusID,
+			})
+			continue
+		}
+		inWorkflow[id] = true
+		stepStatusIDs = append(stepStatusIDs, id)
+	}
+
+	var transitions []dto.WorkflowGraphTransition
+	for _, edge := range req.Transitions {
+		t := dto.WorkflowGraphTransition{
+			RequireReason:     edge.RequireReason,
+			ReasonLabel:       edge.ReasonLabel,
+			RequireFile:       edge.RequireFile,
+			IsActive:          edge.IsActive,
+			RequiredApprovals: edge.RequiredApprovals,
+		}
+		resolved := true
+		if edge.FromStatus != nil {
+			id, ok := statusIDByName[*edge.FromStatus]
+			if !ok {
+				unknown("UNKNOWN_STATUS", "status", *edge.FromStatus)
+				resolved = false
+			}
+			t.FromStatusID = &id
+		}
+		if id, ok := statusIDByName[edge.ToStatus]; ok {
+			t.ToStatusID = id
+		} else {
+			unknown("UNKNOWN_STATUS", "status", edge.ToStatus)
+			resolved = false
+		}
+		if id, ok := actionIDByName[edge.Action]; ok {
+			t.ActionID = id
+		} else {
+			unknown("UNKNOWN_ACTION", "action", edge.Action)
+			resolved = false
+		}
+		if id, ok := actorRoleIDByName[edge.ActorRole]; ok {
+			t.ActorRoleID = id
+		} else {
+			unknown("UNKNOWN_ACTOR_ROLE", "actor role", edge.ActorRole)
+			resolved = false
+		}
+		if !resolved {
+			continue
+		}
+
+		if !workflowGraphInScope(dto.StatusTransitionDetailResponse{FromStatusID: t.FromStatusID, ToStatusID: t.ToStatusID}, inWorkflow) {
+			actionID := t.ActionID
+			issues = append(issues, dto.WorkflowGraphIssue{
+				Code:     "OUTSIDE_WORKFLOW",
+				Message:  fmt.Sprintf("transition '%s' does not leave a step of this workflow", edge.Action),
+				StatusID: t.FromStatusID,
+				ActionID: &actionID,
+			})
+			continue
+		}
+		transitions = append(transitions, t)
+	}
+
+	if len(issues) > 0 {
+		return nil, &WorkflowGraphInvalidError{Issues: issues}
+	}
+
+	// Transitions of the steps being dropped are replaced as well
+	scope := make(map[int]bool, len(inWorkflow)+len(currentSteps))
+	for id := range inWorkflow {
+		scope[id] = true
+	}
+	for _, step := range currentSteps {
+		scope[step.StatusTicketID] = true
+	}
+
+	initialStatusIDs, err := s.workflowRepo.FindInitialStatusIDs(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+	initialStatusIDs = append(initialStatusIDs, stepStatusIDs[0])
+
+	plan, err := s.statusTransitionService.planGraph(ctx, transitions, func(t dto.StatusTransitionDetailResponse) bool {
+		return workflowGraphInScope(t, scope)
+	}, initialStatusIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := s.stepRepo.DeleteByWorkflowID(ctx, tx, workflowID); err != nil {
+		return nil, err
+	}
+	for i, statusID := range stepStatusIDs {
+		if err := s.stepRepo.Create(ctx, tx, workflowID, statusID, i); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.stepRepo.UpdateActiveStatusByWorkflowID(ctx, tx, workflowID, workflow.IsActive); err != nil {
+		return nil, err
+	}
+
+	result, err := s.statusTransitionService.writeGraph(ctx, tx, plan)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &dto.ImportWorkflowGraphResponse{Steps: len(stepStatusIDs), Transitions: *result}, nil
+}
+
+// HELPER
+// A transition belongs to a workflow when it leaves one of its statuses, or starts a ticket in one
+func workflowGraphInScope(t dto.StatusTransitionDetailResponse, statusIDs map[int]bool) bool {
+	if t.FromStatusID == nil {
+		return statusIDs[t.ToStatusID]
+	}
+	return statusIDs[*t.FromStatusID]
+}
+
+func workflowGraphColor(hexColor string) string {
+	if hexColor == "" || strings.HasPrefix(hexColor, "#") {
+		return hexColor
+	}
+	return "#" + hexColor
+}
+
+func workflowGraphStatusLabel(st dto.WorkflowGraphStatus) string {
+	if st.StepSequence == nil {
+		return st.Name
+	}
+	return fmt.Sprintf("%d. %s", *st.StepSequence+1, st.Name)
+}
+
+func workflowGraphEdgeLabelLines(edge dto.WorkflowGraphEdgeByName) []string {
+	lines := []string{edge.Action, "(" + edge.ActorRole + ")"}
+	if edge.RequireReason {
+		if edge.ReasonLabel != nil && *edge.ReasonLabel != "" {
+			lines = append(lines, "reason: "+*edge.ReasonLabel)
+		} else {
+			lines = append(lines, "reason required")
+		}
+	}
+	if edge.RequireFile {
+		lines = append(lines, "file required")
+	}
+	if edge.RequiredApprovals > 1 {
+		lines = append(lines, fmt.Sprintf("%d approvals required", edge.RequiredApprovals))
+	}
+	return lines
+}
+
+// workflowGraphNodeIDs gives every status a short identifier, renderers cannot use names as ids
+func workflowGraphNodeIDs(graph *dto.WorkflowGraphExport) map[string]string {
+	ids := make(map[string]string, len(graph.Statuses))
+	for i, st := range graph.Statuses {
+		ids[st.Name] = fmt.Sprintf("s%d", i)
+	}
+	return ids
+}
+
+func workflowGraphHasStart(graph *dto.WorkflowGraphExport) bool {
+	for _, edge := range graph.Transitions {
+		if edge.FromStatus == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// RENDER DOT
+func RenderWorkflowGraphDOT(graph *dto.WorkflowGraphExport) string {
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	ids := workflowGraphNodeIDs(graph)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph \"%s\" {\n", escape.Replace(graph.Workflow))
+	b.WriteString("    rankdir=LR;\n")
+	b.WriteString("    node [shape=box, style=\"rounded,filled\", fillcolor=\"#FFFFFF\", fontname=\"Helvetica\"];\n")
+	b.WriteString("    edge [fontname=\"Helvetica\", fontsize=10];\n")
+
+	if workflowGraphHasStart(graph) {
+		b.WriteString("    start [shape=circle, label=\"\", width=0.25, fillcolor=\"#000000\"];\n")
+	}
+	for _, st := range graph.Statuses {
+		attrs := []string{fmt.Sprintf("label=\"%s\"", escape.Replace(workflowGraphStatusLabel(st)))}
+		if color := workflowGraphColor(st.HexColor); color != "" {
+			attrs = append(attrs, fmt.Sprintf("fillcolor=\"%s\"", escape.Replace(color)))
+		}
+		if st.IsTerminal {
+			attrs = append(attrs, "peripheries=2")
+		}
+		fmt.Fprintf(&b, "    %s [%s];\n", ids[st.Name], strings.Join(attrs, ", "))
+	}
+
+	for _, edge := range graph.Transitions {
+		from := "start"
+		if edge.FromStatus != nil {
+			from = ids[*edge.FromStatus]
+		}
+		lines := workflowGraphEdgeLabelLines(edge)
+		for i := range lines {
+			lines[i] = escape.Replace(lines[i])
+		}
+		attrs := []string{fmt.Sprintf("label=\"%s\"", strings.Join(lines, `\n`))}
+		if !edge.IsActive {
+			attrs = append(attrs, "style=dashed", "color=\"#999999\"", "fontcolor=\"#999999\"")
+		}
+		fmt.Fprintf(&b, "    %s -> %s [%s];\n", from, ids[edge.ToStatus], strings.Join(attrs, ", "))
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// RENDER MERMAID
+func RenderWorkflowGraphMermaid(graph *dto.WorkflowGraphExport) string {
+	escape := strings.NewReplacer(`"`, "#quot;")
+	ids := workflowGraphNodeIDs(graph)
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	if workflowGraphHasStart(graph) {
+		b.WriteString("    start((Start))\n")
+	}
+	for _, st := range graph.Statuses {
+		label := escape.Replace(workflowGraphStatusLabel(st))
+		if st.IsTerminal {
+			fmt.Fprintf(&b, "    %s([\"%s\"])\n", ids[st.Name], label)
+		} else {
+			fmt.Fprintf(&b, "    %s[\"%s\"]\n", ids[st.Name], label)
+		}
+	}
+
+	for _, edge := range graph.Transitions {
+		from := "start"
+		if edge.FromStatus != nil {
+			from = ids[*edge.FromStatus]
+		}
+		lines := workflowGraphEdgeLabelLines(edge)
+		for i := range lines {
+			lines[i] = escape.Replace(lines[i])
+		}
+		arrow := "-->"
+		if !edge.IsActive {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "    %s %s|\"%s\"| %s\n", from, arrow, strings.Join(lines, "<br/>"), ids[edge.ToStatus])
+	}
+
+	for _, st := range graph.Statuses {
+		if color := workflowGraphColor(st.HexColor); color != "" {
+			fmt.Fprintf(&b, "    style %s fill:%s\n", ids[st.Name], color)
+		}
+	}
+	return b.String()
+}
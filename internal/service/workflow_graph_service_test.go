@@ -0,0 +1,116 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"e-memo-job-reservation-api/internal/dto"
+)
+
+func testWorkflowGraph() *dto.WorkflowGraphExport {
+	return &dto.WorkflowGraphExport{
+		Workflow: `Maintenance "A"`,
+		Statuses: []dto.WorkflowGraphStatus{
+			{Name: "Approval", StepSequence: intPtr(0), HexColor: "FFCC00"},
+			{Name: "Selesai", StepSequence: intPtr(1), HexColor: "#00AA00", IsTerminal: true},
+		},
+		Transitions: []dto.WorkflowGraphEdgeByName{
+			{ToStatus: "Approval", Action: "Buat Tiket", ActorRole: "Requestor", IsActive: true},
+			{FromStatus: stringPtr("Approval"), ToStatus: "Selesai", Action: "Setujui", ActorRole: "Manager", RequireReason: true, RequiredApprovals: 2, IsActive: true},
+			{FromStatus: stringPtr("Approval"), ToStatus: "Selesai", Action: "Tutup", ActorRole: "Admin", RequireFile: true},
+		},
+	}
+}
+
+func TestWorkflowGraphEdgeLabelLines(t *testing.T) {
+	tests := []struct {
+		name string
+		edge dto.WorkflowGraphEdgeByName
+		want []string
+	}{
+		{
+			name: "plain transition",
+			edge: dto.WorkflowGraphEdgeByName{Action: "Setujui", ActorRole: "Manager", RequiredApprovals: 1},
+			want: []string{"Setujui", "(Manager)"},
+		},
+		{
+			name: "labelled reason",
+			edge: dto.WorkflowGraphEdgeByName{Action: "Tolak", ActorRole: "Manager", RequireReason: true, ReasonLabel: stringPtr("Rejection note")},
+			want: []string{"Tolak", "(Manager)", "reason: Rejection note"},
+		},
+		{
+			name: "every requirement",
+			edge: dto.WorkflowGraphEdgeByName{Action: "Setujui", ActorRole: "Manager", RequireReason: true, RequireFile: true, RequiredApprovals: 3},
+			want: []string{"Setujui", "(Manager)", "reason required", "file required", "3 approvals required"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := workflowGraphEdgeLabelLines(tt.edge)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("label = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderWorkflowGraph(t *testing.T) {
+	tests := []struct {
+		name   string
+		render func(*dto.WorkflowGraphExport) string
+		want   []string
+	}{
+		{
+			name:   "DOT",
+			render: RenderWorkflowGraphDOT,
+			want: []string{
+				`digraph "Maintenance \"A\"" {`,
+				`start [shape=circle`,
+				`s0 [label="1. Approval", fillcolor="#FFCC00"];`,
+				`s1 [label="2. Selesai", fillcolor="#00AA00", peripheries=2];`,
+				`start -> s0 [label="Buat Tiket\n(Requestor)"];`,
+				`s0 -> s1 [label="Setujui\n(Manager)\nreason required\n2 approvals required"];`,
+				`s0 -> s1 [label="Tutup\n(Admin)\nfile required", style=dashed`,
+			},
+		},
+		{
+			name:   "Mermaid",
+			render: RenderWorkflowGraphMermaid,
+			want: []string{
+				"flowchart LR\n",
+				`start((Start))`,
+				`s0["1. Approval"]`,
+				`s1(["2. Selesai"])`,
+				`start -->|"Buat Tiket<br/>(Requestor)"| s0`,
+				`s0 -->|"Setujui<br/>(Manager)<br/>reason required<br/>2 approvals required"| s1`,
+				`s0 -.->|"Tutup<br/>(Admin)<br/>file required"| s1`,
+				`style s0 fill:#FFCC00`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.render(testWorkflowGraph())
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output does not contain %q:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderWorkflowGraphWithoutStart(t *testing.T) {
+	graph := testWorkflowGraph()
+	graph.Transitions = graph.Transitions[1:]
+
+	if got := RenderWorkflowGraphDOT(graph); strings.Contains(got, "start") {
+		t.Errorf("DOT output has a start node:\n%s", got)
+	}
+	if got := RenderWorkflowGraphMermaid(graph); strings.Contains(got, "start") {
+		t.Errorf("Mermaid output has a start node:\n%s", got)
+	}
+}
@@ -143,6 +143,13 @@ func (s *WorkflowService) DeleteWorkflow(id int) error {
 
 // DELETE WORKFLOW STEP
 func (s *WorkflowService) DeleteWorkflowStep(id int) error {
+	openTickets, err := s.stepRepo.CountUnpinnedOpenTickets(id)
+	if err != nil {
+		return err
+	}
+	if openTickets > 0 {
+		return errors.New("workflow step still has open tickets, publish a version and migrate them first")
+	}
 	return s.stepRepo.Delete(id)
 }
 
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+)
+
+type WorkflowSimulationService struct {
+	ticketRepo            *repository.TicketRepository
+	jobRepo               *repository.JobRepository
+	employeeRepo          *repository.EmployeeRepository
+	employeePositionRepo  *repository.EmployeePositionRepository
+	trackStatusTicketRepo *repository.TrackStatusTicketRepository
+	statusTicketRepo      *repository.StatusTicketRepository
+	statusTransitionRepo  *repository.StatusTransitionRepository
+	workflowRepo          *repository.WorkflowRepository
+	workflowVersionRepo   *repository.WorkflowVersionRepository
+	actionService         *TicketActionService
+}
+
+type WorkflowSimulationServiceConfig struct {
+	TicketRepo            *repository.TicketRepository
+	JobRepo               *repository.JobRepository
+	EmployeeRepo          *repository.EmployeeRepository
+	EmployeePositionRepo  *repository.EmployeePositionRepository
+	TrackStatusTicketRepo *repository.TrackStatusTicketRepository
+	StatusTicketRepo      *repository.StatusTicketRepository
+	StatusTransitionRepo  *repository.StatusTransitionRepository
+	WorkflowRepo          *repository.WorkflowRepository
+	WorkflowVersionRepo   *repository.WorkflowVersionRepository
+	ActionService         *TicketActionService
+}
+
+func NewWorkflowSimulationService(cfg *WorkflowSimulationServiceConfig) *WorkflowSimulationService {
+	return &WorkflowSimulationService{
+		ticketRepo:            cfg.TicketRepo,
+		jobRepo:               cfg.JobRepo,
+		employeeRepo:          cfg.EmployeeRepo,
+		employeePositionRepo:  cfg.EmployeePositionRepo,
+		trackStatusTicketRepo: cfg.TrackStatusTicketRepo,
+		statusTicketRepo:      cfg.StatusTicketRepo,
+		statusTransitionRepo:  cfg.StatusTransitionRepo,
+		workflowRepo:          cfg.WorkflowRepo,
+		workflowVersionRepo:   cfg.WorkflowVersionRepo,
+		actionService:         cfg.ActionService,
+	}
+}
+
+// SIMULATE
+// Walks the requested actions from the ticket's current status the way ExecuteAction would,
+// without writing anything. The walk stops at the first blocked action.
+func (s *WorkflowSimulationService) Simulate(ctx context.Context, req dto.SimulateWorkflowRequest) (*dto.SimulateWorkflowResponse, error) {
+	user, err := s.employeeRepo.FindByNPK(req.NPK)
+	if err != nil {
+		return nil, errors.New("user employee not found")
+	}
+	simulatedUser := *user
+	if req.PositionID != nil {
+		position, err := s.employeePositionRepo.FindByID(*req.PositionID)
+		if err != nil {
+			return nil, errors.New("employee position not found")
+		}
+		simulatedUser.Position = model.Position{ID: position.ID, Name: position.Name}
+	}
+
+	ticket, err := s.ticketRepo.FindByIDAsStruct(ctx, req.TicketID)
+	if err != nil {
+		return nil, errors.New("ticket not found")
+	}
+
+	requestor, err := s.employeeRepo.FindByNPK(ticket.Requestor)
+	if err != nil {
+		return nil, errors.New("requestor employee not found")
+	}
+
+	jobs, err := s.jobRepo.FindAllByTicketID(ctx, req.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	userContexts, userRoleIDs, err := s.actionService.resolveActorRoles(&simulatedUser, ticket, requestor, jobs)
+	if err != nil {
+		return nil, err
+	}
+
+	currentStatusID, currentStatusName, err := s.trackStatusTicketRepo.GetCurrentStatusByTicketID(ctx, req.TicketID)
+	if err != nil {
+		return nil, errors.New("current status not found")
+	}
+
+	visitedStatusIDs, err := s.trackStatusTicketRepo.GetVisitedStatusIDs(ctx, req.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := s.statusTicketRepo.FindAll(dto.StatusTicketFilter{})
+	if err != nil {
+		return nil, err
+	}
+	statusNames := make(map[int]string, len(statuses))
+	for _, st := range statuses {
+		statusNames[st.ID] = st.Name
+	}
+
+	// Pinned tickets follow their workflow version unless the live draft is being tried out
+	findTransitions := s.statusTransitionRepo.FindAvailableTransitionsForRoles
+	findRequiredRoles := s.findLiveRequiredRoles
+	if req.IncludeInactive {
+		findTransitions = s.statusTransitionRepo.FindTransitionsForRolesIncludingInactive
+	} else if ticket.WorkflowVersionID.Valid {
+		versionID := int(ticket.WorkflowVersionID.Int64)
+		findTransitions = func(fromStatusID int, roleIDs []int) ([]dto.AvailableTicketActionResponse, error) {
+			return s.workflowVersionRepo.FindTransitionsForRoles(versionID, fromStatusID, roleIDs)
+		}
+		findRequiredRoles = func(fromStatusID int, actionName string) ([]string, error) {
+			return s.workflowVersionRepo.FindActorRoleNamesForAction(versionID, fromStatusID, actionName)
+		}
+	}
+
+	result := &dto.SimulateWorkflowResponse{
+		TicketID:        req.TicketID,
+		NPK:             simulatedUser.NPK,
+		PositionID:      simulatedUser.Position.ID,
+		UserContexts:    userContexts,
+		ActorRoleIDs:    userRoleIDs,
+		StartStatusID:   currentStatusID,
+		StartStatusName: currentStatusName,
+		Steps:           []dto.SimulatedStepResponse{},
+	}
+
+	isCompleted := true
+	for i, action := range req.Actions {
+		step := dto.SimulatedStepResponse{
+			Sequence:       i + 1,
+			ActionName:     action.ActionName,
+			FromStatusID:   currentStatusID,
+			FromStatusName: currentStatusName,
+		}
+
+		availableActions, err := findTransitions(currentStatusID, userRoleIDs)
+		if err != nil {
+			return nil, err
+		}
+		availableActions = applyTransitionPrerequisites(availableActions, visitedStatusIDs)
+
+		selectedAction, blockedReason, err := selectSimulatedAction(currentStatusID, currentStatusName, availableActions, action, findRequiredRoles)
+		if err != nil {
+			return nil, err
+		}
+
+		toStatusID := 0
+		if blockedReason == "" {
+			toStatusID = selectedAction.ToStatusID
+			if action.ActionName == "Revisi" {
+				revisedTicket := ticket
+				if req.IncludeInactive {
+					// The live draft sends the ticket back to the first live step
+					revisedTicket = &model.Ticket{}
+				}
+				toStatusID, err = ticketInitialStatusID(ctx, s.workflowRepo, s.workflowVersionRepo, revisedTicket, simulatedUser.Position.ID)
+				if err != nil {
+					blockedReason = "no workflow defined for this user's position"
+				}
+			}
+		}
+
+		if blockedReason != "" {
+			step.IsBlocked = true
+			step.BlockedReason = &blockedReason
+			result.Steps = append(result.Steps, step)
+			isCompleted = false
+			break
+		}
+
+		// The walk assumes the other approvals of a quorum are given
+		if selectedAction.RequiredApprovals > 1 {
+			step.RequiredApprovals = selectedAction.RequiredApprovals
+		}
+
+		toStatusName := statusNames[toStatusID]
+		step.ToStatusID = &toStatusID
+		step.ToStatusName = &toStatusName
+		result.Steps = append(result.Steps, step)
+
+		visitedStatusIDs = append(visitedStatusIDs, toStatusID)
+		currentStatusID = toStatusID
+		currentStatusName = toStatusName
+	}
+
+	availableActions, err := findTransitions(currentStatusID, userRoleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result.FinalStatusID = currentStatusID
+	result.FinalStatusName = currentStatusName
+	result.IsCompleted = isCompleted
+	result.AvailableActions = applyTransitionPrerequisites(availableActions, visitedStatusIDs)
+	return result, nil
+}
+
+// HELPER
+// selectSimulatedAction picks the action like ExecuteAction does and explains why it is refused otherwise
+func selectSimulatedAction(statusID int, statusName string, availableActions []dto.AvailableTicketActionResponse, req dto.SimulatedActionRequest, findRequiredRoles func(int, string) ([]string, error)) (*dto.AvailableTicketActionResponse, string, error) {
+	var selectedAction *dto.AvailableTicketActionResponse
+	var prerequisiteReason *string
+	for _, action := range availableActions {
+		if action.ActionName != req.ActionName {
+			continue
+		}
+		if action.IsBlocked {
+			prerequisiteReason = action.BlockedReason
+			continue
+		}
+		act := action
+		selectedAction = &act
+		break
+	}
+
+	if selectedAction == nil {
+		if prerequisiteReason != nil {
+			return nil, *prerequisiteReason, nil
+		}
+
+		requiredRoles, err := findRequiredRoles(statusID, req.ActionName)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(requiredRoles) > 0 {
+			return nil, fmt.Sprintf("action '%s' from status '%s' requires actor role %s, which this user does not have", req.ActionName, statusName, strings.Join(requiredRoles, ", ")), nil
+		}
+		return nil, fmt.Sprintf("action '%s' is not allowed from status '%s'", req.ActionName, statusName), nil
+	}
+
+	if selectedAction.RequireReason && req.Reason == "" {
+		if selectedAction.ReasonLabel != nil {
+			return nil, fmt.Sprintf("%s is required", *selectedAction.ReasonLabel), nil
+		}
+		return nil, "reason is required for this action", nil
+	}
+
+	if selectedAction.RequireFile && !req.HasFile {
+		return nil, "file upload is required for this action", nil
+	}
+
+	return selectedAction, "", nil
+}
+
+func (s *WorkflowSimulationService) findLiveRequiredRoles(fromStatusID int, actionName string) ([]string, error) {
+	transitions, err := s.statusTransitionRepo.FindPossibleTransitionsWithDetails(fromStatusID)
+	if err != nil {
+		return nil, err
+	}
+	var requiredRoles []string
+	for _, t := range transitions {
+		if t.ActionDetail.ActionName == actionName {
+			requiredRoles = append(requiredRoles, t.RequiredActorRole)
+		}
+	}
+	return requiredRoles, nil
+}
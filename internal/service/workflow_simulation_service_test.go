@@ -0,0 +1,110 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"e-memo-job-reservation-api/internal/dto"
+)
+
+func TestSelectSimulatedAction(t *testing.T) {
+	prerequisiteReason := "ticket must pass through status 'Review' before this action can be performed"
+	actions := []dto.AvailableTicketActionResponse{
+		{ActionName: "Setujui", ActorRoleID: 1, IsBlocked: true, BlockedReason: &prerequisiteReason},
+		{ActionName: "Tolak", ActorRoleID: 1, RequireReason: true, ReasonLabel: stringPtr("Rejection note")},
+		{ActionName: "Revisi", ActorRoleID: 1, RequireReason: true},
+		{ActionName: "Selesaikan Job", ActorRoleID: 2, RequireFile: true},
+	}
+	requiredRoles := func(roles ...string) func(int, string) ([]string, error) {
+		return func(int, string) ([]string, error) {
+			return roles, nil
+		}
+	}
+
+	tests := []struct {
+		name              string
+		req               dto.SimulatedActionRequest
+		findRequiredRoles func(int, string) ([]string, error)
+		wantAction        string
+		wantRefusal       string
+		wantErr           bool
+	}{
+		{
+			name:        "blocked by a prerequisite",
+			req:         dto.SimulatedActionRequest{ActionName: "Setujui"},
+			wantRefusal: prerequisiteReason,
+		},
+		{
+			name:              "action of another actor role",
+			req:               dto.SimulatedActionRequest{ActionName: "Kerjakan"},
+			findRequiredRoles: requiredRoles("PIC", "Supervisor"),
+			wantRefusal:       "action 'Kerjakan' from status 'Approval' requires actor role PIC, Supervisor, which this user does not have",
+		},
+		{
+			name:              "action not allowed from the status",
+			req:               dto.SimulatedActionRequest{ActionName: "Kerjakan"},
+			findRequiredRoles: requiredRoles(),
+			wantRefusal:       "action 'Kerjakan' is not allowed from status 'Approval'",
+		},
+		{
+			name: "required roles lookup fails",
+			req:  dto.SimulatedActionRequest{ActionName: "Kerjakan"},
+			findRequiredRoles: func(int, string) ([]string, error) {
+				return nil, errors.New("connection refused")
+			},
+			wantErr: true,
+		},
+		{
+			name:        "labelled reason missing",
+			req:         dto.SimulatedActionRequest{ActionName: "Tolak"},
+			wantRefusal: "Rejection note is required",
+		},
+		{
+			name:        "reason missing",
+			req:         dto.SimulatedActionRequest{ActionName: "Revisi"},
+			wantRefusal: "reason is required for this action",
+		},
+		{
+			name:        "file missing",
+			req:         dto.SimulatedActionRequest{ActionName: "Selesaikan Job"},
+			wantRefusal: "file upload is required for this action",
+		},
+		{
+			name:       "action with its reason",
+			req:        dto.SimulatedActionRequest{ActionName: "Tolak", Reason: "out of scope"},
+			wantAction: "Tolak",
+		},
+		{
+			name:       "action with its file",
+			req:        dto.SimulatedActionRequest{ActionName: "Selesaikan Job", HasFile: true},
+			wantAction: "Selesaikan Job",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, refusal, err := selectSimulatedAction(3, "Approval", actions, tt.req, tt.findRequiredRoles)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if refusal != tt.wantRefusal {
+				t.Errorf("refusal = %q, want %q", refusal, tt.wantRefusal)
+			}
+			if tt.wantAction == "" {
+				if action != nil {
+					t.Errorf("action = %s, want none", action.ActionName)
+				}
+				return
+			}
+			if action == nil || action.ActionName != tt.wantAction {
+				t.Errorf("action = %v, want %s", action, tt.wantAction)
+			}
+		})
+	}
+}
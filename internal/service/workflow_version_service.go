@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"e-memo-job-reservation-api/internal/dto"
+	"e-memo-job-reservation-api/internal/model"
+	"e-memo-job-reservation-api/internal/repository"
+)
+
+type WorkflowVersionService struct {
+	db                      *sql.DB
+	versionRepo             *repository.WorkflowVersionRepository
+	workflowRepo            *repository.WorkflowRepository
+	stepRepo                *repository.WorkflowStepRepository
+	trackStatusTicketRepo   *repository.TrackStatusTicketRepository
+	statusTransitionService *StatusTransitionService
+	outboxService           *OutboxService
+}
+
+type WorkflowVersionServiceConfig struct {
+	DB                      *sql.DB
+	VersionRepo             *repository.WorkflowVersionRepository
+	WorkflowRepo            *repository.WorkflowRepository
+	StepRepo                *repository.WorkflowStepRepository
+	TrackStatusTicketRepo   *repository.TrackStatusTicketRepository
+	StatusTransitionService *StatusTransitionService
+	OutboxService           *OutboxService
+}
+
+func NewWorkflowVersionService(cfg *WorkflowVersionServiceConfig) *WorkflowVersionService {
+	return &WorkflowVersionService{
+		db:                      cfg.DB,
+		versionRepo:             cfg.VersionRepo,
+		workflowRepo:            cfg.WorkflowRepo,
+		stepRepo:                cfg.StepRepo,
+		trackStatusTicketRepo:   cfg.TrackStatusTicketRepo,
+		statusTransitionService: cfg.StatusTransitionService,
+		outboxService:           cfg.OutboxService,
+	}
+}
+
+// PUBLISH
+// Freezes the current steps and transitions of the workflow as its next version, new tickets are pinned to it.
+// Problems the graph validation finds around the workflow's statuses refuse the publication.
+func (s *WorkflowVersionService) Publish(ctx context.Context, workflowID int, req dto.PublishWorkflowVersionRequest, publishedByNPK string) (*dto.WorkflowVersionDetailResponse, error) {
+	if _, err := s.workflowRepo.FindByID(workflowID); err != nil {
+		return nil, err
+	}
+
+	steps, err := s.stepRepo.FindByWorkflowID(workflowID)
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) == 0 {
+		return nil, errors.New("workflow has no steps to publish")
+	}
+
+	validation, err := s.statusTransitionService.ValidateGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inWorkflow := make(map[int]bool, len(steps))
+	for _, step := range steps {
+		inWorkflow[step.StatusTicketID] = true
+	}
+	var issues []dto.WorkflowGraphIssue
+	for _, issue := range validation.Issues {
+		if issue.StatusID == nil || inWorkflow[*issue.StatusID] {
+			issues = append(issues, issue)
+		}
+	}
+	if len(issues) > 0 {
+		return nil, &WorkflowGraphInvalidError{Issues: issues}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	version, err := s.versionRepo.Create(ctx, tx, workflowID, req.Note, publishedByNPK)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := s.versionRepo.Snapshot(ctx, tx, version.ID, workflowID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.GetVersionByID(version.ID)
+}
+
+// GET ALL BY WORKFLOW ID
+func (s *WorkflowVersionService) GetVersionsByWorkflowID(workflowID int) ([]model.WorkflowVersion, error) {
+	if _, err := s.workflowRepo.FindByID(workflowID); err != nil {
+		return nil, err
+	}
+	return s.versionRepo.FindByWorkflowID(workflowID)
+}
+
+// GET BY ID
+func (s *WorkflowVersionService) GetVersionByID(id int) (*dto.WorkflowVersionDetailResponse, error) {
+	version, err := s.versionRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	steps, err := s.versionRepo.FindSteps(id)
+	if err != nil {
+		return nil, err
+	}
+	transitions, err := s.versionRepo.FindTransitions(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if steps == nil {
+		steps = []dto.WorkflowVersionStepResponse{}
+	}
+	if transitions == nil {
+		transitions = []dto.WorkflowVersionTransitionResponse{}
+	}
+	return &dto.WorkflowVersionDetailResponse{
+		ID:             version.ID,
+		WorkflowID:     version.WorkflowID,
+		VersionNumber:  version.VersionNumber,
+		Note:           version.Note,
+		PublishedByNPK: version.PublishedByNPK,
+		PublishedAt:    version.PublishedAt,
+		Steps:          steps,
+		Transitions:    transitions,
+	}, nil
+}
+
+// MIGRATE TICKETS
+// Pins the open tickets of the source version to the target version. A ticket whose status is mapped moves
+// to the mapped status, the others keep their status when the target version knows it and are skipped otherwise.
+// A dry run goes through the same steps and rolls them back.
+func (s *WorkflowVersionService) MigrateTickets(ctx context.Context, workflowID int, req dto.MigrateWorkflowTicketsRequest, migratedByNPK string) (*dto.MigrateWorkflowTicketsResponse, error) {
+	if _, err := s.workflowRepo.FindByID(workflowID); err != nil {
+		return nil, err
+	}
+	if req.FromVersionID != nil && *req.FromVersionID == req.ToVersionID {
+		return nil, errors.New("source and target version are the same")
+	}
+	for _, versionID := range []*int{req.FromVersionID, &req.ToVersionID} {
+		if versionID == nil {
+			continue
+		}
+		version, err := s.versionRepo.FindByID(*versionID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, errors.New("workflow version not found")
+			}
+			return nil, err
+		}
+		if version.WorkflowID != workflowID {
+			return nil, errors.New("workflow version does not belong to this workflow")
+		}
+	}
+
+	targetStatusIDs, err := s.versionRepo.FindStatusIDs(ctx, req.ToVersionID)
+	if err != nil {
+		return nil, err
+	}
+	inTarget := make(map[int]bool, len(targetStatusIDs))
+	for _, id := range targetStatusIDs {
+		inTarget[id] = true
+	}
+
+	mapping := make(map[int]int, len(req.StatusMappings))
+	for _, m := range req.StatusMappings {
+		if _, exists := mapping[m.FromStatusID]; exists {
+			return nil, errors.New("status mapping lists a status more than once")
+		}
+		if !inTarget[m.ToStatusID] {
+			return nil, errors.New("status mapping points to a status outside the target version")
+		}
+		mapping[m.FromStatusID] = m.ToStatusID
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	candidates, err := s.versionRepo.FindMigrationCandidates(ctx, tx, workflowID, req.FromVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.MigrateWorkflowTicketsResponse{
+		DryRun:   req.DryRun,
+		Migrated: []dto.MigratedTicketResponse{},
+		Skipped:  []dto.MigratedTicketResponse{},
+	}
+	for _, candidate := range candidates {
+		item := dto.MigratedTicketResponse{TicketID: candidate.TicketID, FromStatusID: candidate.CurrentStatusID}
+
+		toStatusID, isMapped := mapping[candidate.CurrentStatusID]
+		if !isMapped {
+			toStatusID = candidate.CurrentStatusID
+		}
+		if !inTarget[toStatusID] {
+			reason := fmt.Sprintf("status %d is not part of the target version and has no mapping", candidate.CurrentStatusID)
+			item.Reason = &reason
+			result.Skipped = append(result.Skipped, item)
+			continue
+		}
+
+		if toStatusID != candidate.CurrentStatusID {
+			if err := s.trackStatusTicketRepo.UpdateStatus(ctx, tx, candidate.TicketID, toStatusID); err != nil {
+				return nil, err
+			}
+			err := s.outboxService.RecordTicketEvent(ctx, tx, "TICKET_UPDATED", candidate.TicketID, dto.TicketOutboxPayload{ActorNPK: migratedByNPK})
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := s.versionRepo.PinTicket(ctx, tx, candidate.TicketID, req.ToVersionID); err != nil {
+			return nil, err
+		}
+		if err := s.versionRepo.RecordMigration(ctx, tx, candidate.TicketID, req.FromVersionID, req.ToVersionID, candidate.CurrentStatusID, toStatusID, migratedByNPK); err != nil {
+			return nil, err
+		}
+
+		item.ToStatusID = &toStatusID
+		result.Migrated = append(result.Migrated, item)
+	}
+
+	if req.DryRun {
+		return result, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	s.outboxService.Wake()
+	return result, nil
+}
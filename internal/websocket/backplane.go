@@ -0,0 +1,195 @@
+package websocket
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+const (
+	backplaneChannel = "websocket_events"
+	// NOTIFY payloads are limited to 8000 bytes, bigger events are stored in websocket_event and sent by reference
+	maxNotifyPayload     = 7900
+	eventReferencePrefix = "ref:"
+	reconnectDelay       = 5 * time.Second
+	publishQueueSize     = 1024
+	eventCleanupInterval = 10 * time.Minute
+)
+
+// Backplane fans hub events out to every process through Postgres LISTEN/NOTIFY
+type Backplane struct {
+	db     *sql.DB
+	hub    *Hub
+	origin string
+	events chan backplaneEnvelope // drained by runPublisher, so publishing never waits on the database
+}
+
+type backplaneEnvelope struct {
+	Origin        string          `json:"origin"`
+	Kind          string          `json:"kind"`
+	Audience      *TicketAudience `json:"audience,omitempty"`
+	UserID        int             `json:"user_id,omitempty"`
+	Message       json.RawMessage `json:"message"`
+	PublicMessage json.RawMessage `json:"public_message,omitempty"`
+}
+
+func NewBackplane(db *sql.DB, hub *Hub) *Backplane {
+	b := &Backplane{db: db, hub: hub, origin: uuid.New().String(), events: make(chan backplaneEnvelope, publishQueueSize)}
+	hub.backplane = b
+	go b.runPublisher()
+	return b
+}
+
+// PUBLISH EVENT TO OTHER PROCESSES, THE EVENT IS QUEUED AND SENT BY runPublisher
+func (b *Backplane) publish(envelope backplaneEnvelope) {
+	envelope.Origin = b.origin
+	select {
+	case b.events <- envelope:
+	default:
+		log.Printf("WARNING: Backplane publish queue is full, dropping %s event", envelope.Kind)
+	}
+}
+
+// runPublisher sends the queued events in order and periodically removes old stored events
+func (b *Backplane) runPublisher() {
+	ticker := time.NewTicker(eventCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case envelope := <-b.events:
+			b.send(envelope)
+		case <-ticker.C:
+			b.cleanupStoredEvents()
+		}
+	}
+}
+
+func (b *Backplane) send(envelope backplaneEnvelope) {
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		log.Printf("CRITICAL: Failed to marshal backplane event: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	notification := string(payload)
+	if len(payload) > maxNotifyPayload {
+		var eventID int64
+		err := b.db.QueryRowContext(ctx, "INSERT INTO websocket_event (payload) VALUES ($1) RETURNING id", string(payload)).Scan(&eventID)
+		if err != nil {
+			log.Printf("ERROR: Failed to store large backplane event: %v", err)
+			return
+		}
+		notification = eventReferencePrefix + strconv.FormatInt(eventID, 10)
+	}
+
+	if _, err := b.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", backplaneChannel, notification); err != nil {
+		log.Printf("ERROR: Failed to publish backplane event: %v", err)
+	}
+}
+
+func (b *Backplane) cleanupStoredEvents() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := b.db.ExecContext(ctx, "DELETE FROM websocket_event WHERE created_at < NOW() - interval '1 hour'"); err != nil {
+		log.Printf("WARNING: Failed to clean up old backplane events: %v", err)
+	}
+}
+
+// LISTEN FOR EVENTS FROM OTHER PROCESSES, RECONNECTS UNTIL CTX IS DONE
+func (b *Backplane) Listen(ctx context.Context) {
+	for {
+		err := b.listenOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("WARNING: Backplane listener stopped: %v, reconnecting in %s", err, reconnectDelay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (b *Backplane) listenOnce(ctx context.Context) error {
+	conn, err := b.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errors.New("backplane requires the pgx stdlib driver")
+		}
+		pgxConn := stdConn.Conn()
+
+		if _, err := pgxConn.Exec(ctx, "LISTEN "+backplaneChannel); err != nil {
+			return err
+		}
+		log.Printf("Backplane listening on channel '%s'", backplaneChannel)
+
+		for {
+			notification, err := pgxConn.WaitForNotification(ctx)
+			if err != nil {
+				return err
+			}
+			b.handleNotification(ctx, notification.Payload)
+		}
+	})
+}
+
+func (b *Backplane) handleNotification(ctx context.Context, notification string) {
+	payload := []byte(notification)
+	if strings.HasPrefix(notification, eventReferencePrefix) {
+		eventID, err := strconv.ParseInt(strings.TrimPrefix(notification, eventReferencePrefix), 10, 64)
+		if err != nil {
+			log.Printf("Invalid backplane event reference: %s", notification)
+			return
+		}
+		if err := b.db.QueryRowContext(ctx, "SELECT payload FROM websocket_event WHERE id = $1", eventID).Scan(&payload); err != nil {
+			log.Printf("ERROR: Failed to load backplane event %d: %v", eventID, err)
+			return
+		}
+	}
+
+	var envelope backplaneEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		log.Printf("Error unmarshalling backplane event: %v", err)
+		return
+	}
+
+	// Own events were already delivered locally when published
+	if envelope.Origin == b.origin {
+		return
+	}
+
+	switch envelope.Kind {
+	case "broadcast":
+		b.hub.Broadcast <- envelope.Message
+	case "ticket":
+		if envelope.Audience == nil {
+			envelope.Audience = &TicketAudience{}
+		}
+		b.hub.ticketBroadcast <- ticketMessage{audience: *envelope.Audience, message: envelope.Message, publicMessage: envelope.PublicMessage}
+	case "user":
+		b.hub.userMessages <- userMessage{userID: envelope.UserID, message: envelope.Message}
+	default:
+		log.Printf("Unknown backplane event kind: %s", envelope.Kind)
+	}
+}
@@ -13,11 +13,13 @@ const (
 )
 
 type Client struct {
-	ID     string // Unique client ID (UUID)
-	Hub    *Hub
-	Conn   *websocket.Conn
-	Send   chan []byte
-	UserID int // 0 for public/anonymous users, >0 for authenticated users
+	ID           string // Unique client ID (UUID)
+	Hub          *Hub
+	Conn         *websocket.Conn
+	Send         chan []byte
+	UserID       int           // 0 for public/anonymous users, >0 for authenticated users
+	EmployeeNPK  string        // Empty for public/anonymous users
+	Subscription *Subscription // nil means receive every ticket message, only touched by Hub.Run
 }
 
 // SEND MESSAGE FROM WEBSOCKET CONNECTION TO HUB
@@ -2,10 +2,12 @@ package websocket
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
-	"fmt"
 	"log"
+	"time"
 
+	"e-memo-job-reservation-api/internal/model"
 	"e-memo-job-reservation-api/internal/repository"
 
 	"github.com/gin-gonic/gin"
@@ -13,27 +15,40 @@ import (
 
 type Hub struct {
 	Broadcast        chan []byte
+	ticketBroadcast  chan ticketMessage
+	userMessages     chan userMessage
 	Register         chan *Client
 	Unregister       chan *Client
-	Clients          map[string]*Client // Map by client ID (UUID) for all clients
-	UserClients      map[int]*Client    // Map by UserID for authenticated users (for backward compatibility)
+	Clients          map[string]*Client         // Map by client ID (UUID) for all clients
+	UserClients      map[int]map[string]*Client // Map by UserID to every connection (device) of an authenticated user
 	incomingMessages chan clientMessage
-	editingSessions  map[string]*Client
-	sessionCommands  chan sessionCommand
+	lockCommands     chan sessionCommand // drained by runLockWorker, so lock queries never hold up Run
+	lockResults      chan lockResult
 	authRepo         *repository.AuthRepository
+	editingLockRepo  *repository.EditingLockRepository // editing sessions are persisted as locks, see EditingLockTTL
+	backplane        *Backplane                        // optional, shares events with the other API instances and the worker
 }
 
-type EditingSession struct {
-	UserID    int
-	Entity    string
-	ContextID int
-}
+// An editing lock expires when its holder stops sending HEARTBEAT_EDITING for this long
+const (
+	EditingLockTTL           = 2 * time.Minute
+	editingLockSweepInterval = 30 * time.Second
+	editingLockQueueSize     = 256
+)
 
 type sessionCommand struct {
-	action     string
-	client     *Client
-	sessionKey string
-	payload    map[string]interface{}
+	action    string
+	client    *Client
+	entity    string
+	contextID int
+	payload   map[string]interface{}
+}
+
+// lockResult holds the messages produced by a lock operation, Run delivers them
+type lockResult struct {
+	client *Client
+	direct []byte
+	others [][]byte
 }
 
 type clientMessage struct {
@@ -41,92 +56,99 @@ type clientMessage struct {
 	message []byte
 }
 
-func NewHub(authRepo *repository.AuthRepository) *Hub {
+type userMessage struct {
+	userID  int
+	message []byte
+}
+
+func NewHub(authRepo *repository.AuthRepository, editingLockRepo *repository.EditingLockRepository) *Hub {
 	return &Hub{
 		Broadcast:        make(chan []byte),
+		ticketBroadcast:  make(chan ticketMessage),
+		userMessages:     make(chan userMessage),
 		Register:         make(chan *Client),
 		Unregister:       make(chan *Client),
 		Clients:          make(map[string]*Client),
-		UserClients:      make(map[int]*Client),
+		UserClients:      make(map[int]map[string]*Client),
 		incomingMessages: make(chan clientMessage),
-		editingSessions:  make(map[string]*Client),
-		sessionCommands:  make(chan sessionCommand),
+		lockCommands:     make(chan sessionCommand, editingLockQueueSize),
+		lockResults:      make(chan lockResult),
 		authRepo:         authRepo,
+		editingLockRepo:  editingLockRepo,
 	}
 }
 
 // RUN HUB AS GOROUTINE
 func (h *Hub) Run() {
+	go h.expireEditingLocks()
+	go h.runLockWorker()
+
 	for {
 		select {
 		case client := <-h.Register:
 			// Register client by unique ID (supports multiple public users)
 			h.Clients[client.ID] = client
-			
+
 			// For authenticated users (UserID > 0), also store in UserClients map
-			// If an old connection exists for this user, close it
+			// A user may be connected from several devices at the same time
 			if client.UserID > 0 {
-				if oldClient, ok := h.UserClients[client.UserID]; ok {
-					close(oldClient.Send)
-					delete(h.Clients, oldClient.ID)
+				if _, ok := h.UserClients[client.UserID]; !ok {
+					h.UserClients[client.UserID] = make(map[string]*Client)
 				}
-				h.UserClients[client.UserID] = client
-				log.Printf("WebSocket client registered: ClientID=%s, UserID=%d (authenticated)", client.ID, client.UserID)
+				h.UserClients[client.UserID][client.ID] = client
+				log.Printf("WebSocket client registered: ClientID=%s, UserID=%d (authenticated, %d device(s))", client.ID, client.UserID, len(h.UserClients[client.UserID]))
 			} else {
 				log.Printf("WebSocket client registered: ClientID=%s, UserID=%d (public/anonymous)", client.ID, client.UserID)
 			}
-			
+
 		case client := <-h.Unregister:
-			// Remove from Clients map
 			if _, ok := h.Clients[client.ID]; ok {
 				h.cleanupClientSessions(client)
-				delete(h.Clients, client.ID)
-				close(client.Send)
-				
-				// For authenticated users, also remove from UserClients map
+				h.removeClient(client)
 				if client.UserID > 0 {
-					if registeredClient, ok := h.UserClients[client.UserID]; ok && registeredClient.ID == client.ID {
-						delete(h.UserClients, client.UserID)
-					}
 					log.Printf("WebSocket client unregistered: ClientID=%s, UserID=%d (authenticated)", client.ID, client.UserID)
 				} else {
 					log.Printf("WebSocket client unregistered: ClientID=%s, UserID=%d (public/anonymous)", client.ID, client.UserID)
 				}
 			}
-			
+
 		case message := <-h.Broadcast:
 			// Broadcast to all clients (both authenticated and public)
-			for clientID, client := range h.Clients {
-				select {
-				case client.Send <- message:
-				default:
-					close(client.Send)
-					delete(h.Clients, clientID)
-					if client.UserID > 0 {
-						delete(h.UserClients, client.UserID)
+			for _, client := range h.Clients {
+				h.send(client, message)
+			}
+
+		case ticketMsg := <-h.ticketBroadcast:
+			// Deliver only to subscribed clients, public clients get the redacted message
+			for _, client := range h.Clients {
+				if !client.wantsTicketMessage(ticketMsg.audience) {
+					continue
+				}
+				message := ticketMsg.message
+				if client.UserID == 0 {
+					if ticketMsg.publicMessage == nil {
+						continue
 					}
+					message = ticketMsg.publicMessage
 				}
+				h.send(client, message)
+			}
+
+		case userMsg := <-h.userMessages:
+			for _, client := range h.UserClients[userMsg.userID] {
+				h.send(client, userMsg.message)
 			}
-			
+
 		case clientMsg := <-h.incomingMessages:
 			h.handleIncomingMessage(clientMsg.client, clientMsg.message)
-			
-		case cmd := <-h.sessionCommands:
-			switch cmd.action {
-			case "start":
-				if _, exists := h.editingSessions[cmd.sessionKey]; !exists {
-					h.editingSessions[cmd.sessionKey] = cmd.client
-					log.Printf("User %d started editing %s", cmd.client.UserID, cmd.sessionKey)
-					broadcastMsg, _ := NewMessage("EDITING_STARTED", cmd.payload)
-					h.broadcastToOthers(broadcastMsg, cmd.client)
-				}
-			case "finish":
-				if holder, exists := h.editingSessions[cmd.sessionKey]; exists && holder == cmd.client {
-					delete(h.editingSessions, cmd.sessionKey)
-					log.Printf("User %d finished editing %s", cmd.client.UserID, cmd.sessionKey)
-					broadcastMsg, _ := NewMessage("EDITING_FINISHED", cmd.payload)
-					h.broadcastToOthers(broadcastMsg, cmd.client)
-				}
+
+		case result := <-h.lockResults:
+			// The client may have disconnected while its lock was being handled
+			if current, ok := h.Clients[result.client.ID]; ok && current == result.client && result.direct != nil {
+				h.sendDirect(result.client, result.direct)
+			}
+			for _, message := range result.others {
+				h.broadcastToOthers(message, result.client)
 			}
 		}
 	}
@@ -134,6 +156,51 @@ func (h *Hub) Run() {
 
 func (h *Hub) BroadcastMessage(message []byte) {
 	h.Broadcast <- message
+	if h.backplane != nil {
+		h.backplane.publish(backplaneEnvelope{Kind: "broadcast", Message: message})
+	}
+}
+
+// BroadcastTicketMessage sends message to authenticated clients and publicMessage to public clients
+// whose subscription matches the audience, a nil publicMessage is not sent to public clients
+func (h *Hub) BroadcastTicketMessage(audience TicketAudience, message []byte, publicMessage []byte) {
+	h.ticketBroadcast <- ticketMessage{audience: audience, message: message, publicMessage: publicMessage}
+	if h.backplane != nil {
+		h.backplane.publish(backplaneEnvelope{Kind: "ticket", Audience: &audience, Message: message, PublicMessage: publicMessage})
+	}
+}
+
+// SendToUser delivers message to every connected device of the user
+func (h *Hub) SendToUser(userID int, message []byte) {
+	h.userMessages <- userMessage{userID: userID, message: message}
+	if h.backplane != nil {
+		h.backplane.publish(backplaneEnvelope{Kind: "user", UserID: userID, Message: message})
+	}
+}
+
+// send drops the client when its buffer is full, must only be called from Run
+func (h *Hub) send(client *Client, message []byte) {
+	select {
+	case client.Send <- message:
+	default:
+		h.cleanupClientSessions(client)
+		h.removeClient(client)
+	}
+}
+
+func (h *Hub) removeClient(client *Client) {
+	if _, ok := h.Clients[client.ID]; !ok {
+		return
+	}
+	delete(h.Clients, client.ID)
+	close(client.Send)
+
+	if devices, ok := h.UserClients[client.UserID]; ok {
+		delete(devices, client.ID)
+		if len(devices) == 0 {
+			delete(h.UserClients, client.UserID)
+		}
+	}
 }
 
 func (h *Hub) handleIncomingMessage(client *Client, rawMessage []byte) {
@@ -143,6 +210,12 @@ func (h *Hub) handleIncomingMessage(client *Client, rawMessage []byte) {
 		return
 	}
 
+	switch msg.Event {
+	case "SUBSCRIBE", "UNSUBSCRIBE":
+		h.handleSubscription(client, msg)
+		return
+	}
+
 	payload, ok := msg.Payload.(map[string]interface{})
 	if !ok {
 		log.Printf("Invalid payload format for event: %s", msg.Event)
@@ -156,7 +229,6 @@ func (h *Hub) handleIncomingMessage(client *Client, rawMessage []byte) {
 		return
 	}
 	contextID := int(contextIDFloat)
-	sessionKey := fmt.Sprintf("%s:%d", entity, contextID)
 
 	switch msg.Event {
 	case "START_EDITING":
@@ -165,19 +237,13 @@ func (h *Hub) handleIncomingMessage(client *Client, rawMessage []byte) {
 			log.Printf("Public user attempted to start editing session - rejected")
 			return
 		}
+		h.enqueueLockCommand(sessionCommand{action: "start", client: client, entity: entity, contextID: contextID, payload: payload})
 
-		if _, exists := h.editingSessions[sessionKey]; !exists {
-			h.editingSessions[sessionKey] = client
-			log.Printf("User %d started editing %s", client.UserID, sessionKey)
-
-			broadcastMsg, err := NewMessage("EDITING_STARTED", payload)
-
-			if err != nil {
-				log.Printf("CRITICAL: Failed to create broadcast message for start reorder: %v", err)
-			} else {
-				h.broadcastToOthers(broadcastMsg, client)
-			}
+	case "HEARTBEAT_EDITING":
+		if client.UserID == 0 {
+			return
 		}
+		h.enqueueLockCommand(sessionCommand{action: "heartbeat", client: client, entity: entity, contextID: contextID})
 
 	case "FINISH_EDITING":
 		// Only authenticated users can finish editing sessions
@@ -185,67 +251,259 @@ func (h *Hub) handleIncomingMessage(client *Client, rawMessage []byte) {
 			log.Printf("Public user attempted to finish editing session - rejected")
 			return
 		}
+		h.enqueueLockCommand(sessionCommand{action: "finish", client: client, entity: entity, contextID: contextID, payload: payload})
+	}
+}
 
-		if holder, exists := h.editingSessions[sessionKey]; exists && holder == client {
-			delete(h.editingSessions, sessionKey)
-			log.Printf("User %d finished editing %s", client.UserID, sessionKey)
+// enqueueLockCommand hands a lock operation to the lock worker without blocking Run,
+// a dropped heartbeat or release is covered by EditingLockTTL
+func (h *Hub) enqueueLockCommand(cmd sessionCommand) {
+	if h.editingLockRepo == nil {
+		return
+	}
+	select {
+	case h.lockCommands <- cmd:
+	default:
+		log.Printf("WARNING: Editing lock queue is full, dropping %s from client %s", cmd.action, cmd.client.ID)
+	}
+}
 
-			broadcastMsg, err := NewMessage("EDITING_FINISHED", payload)
+// runLockWorker runs the editing lock queries in the order the clients sent them
+// and passes the resulting messages back to Run
+func (h *Hub) runLockWorker() {
+	if h.editingLockRepo == nil {
+		return
+	}
 
-			if err != nil {
-				log.Printf("CRITICAL: Failed to create broadcast message for finish reorder: %v", err)
-			} else {
-				h.broadcastToOthers(broadcastMsg, client)
-			}
+	for cmd := range h.lockCommands {
+		var result lockResult
+		switch cmd.action {
+		case "start":
+			result = h.startEditing(cmd.client, cmd.entity, cmd.contextID, cmd.payload)
+		case "heartbeat":
+			result = h.heartbeatEditing(cmd.client, cmd.entity, cmd.contextID)
+		case "finish":
+			result = h.finishEditing(cmd.client, cmd.entity, cmd.contextID, cmd.payload)
+		case "release_client":
+			result = h.releaseClientLocks(cmd.client)
+		default:
+			continue
+		}
+		if result.direct != nil || len(result.others) > 0 {
+			result.client = cmd.client
+			h.lockResults <- result
+		}
+	}
+}
 
+func (h *Hub) startEditing(client *Client, entity string, contextID int, payload map[string]interface{}) lockResult {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The same user reopening the editor on another device takes the lock over
+	lock, err := h.editingLockRepo.Acquire(ctx, entity, contextID, client.UserID, client.EmployeeNPK, client.ID, EditingLockTTL)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("ERROR: Failed to acquire editing lock %s for user %d: %v", model.EditingLockKey(entity, contextID), client.UserID, err)
+			return lockResult{}
+		}
+		rejectedPayload := map[string]interface{}{
+			"entity":     entity,
+			"context_id": contextID,
+		}
+		if holder, err := h.editingLockRepo.FindActiveByKey(ctx, model.EditingLockKey(entity, contextID)); err == nil {
+			rejectedPayload["holder_name"] = holder.HolderName
+			rejectedPayload["expires_at"] = holder.ExpiresAt
 		}
+		message, _ := NewMessage("EDITING_REJECTED", rejectedPayload)
+		return lockResult{direct: message}
 	}
+	log.Printf("User %d started editing %s from client %s", client.UserID, lock.LockKey, client.ID)
+
+	var result lockResult
+	result.direct, _ = NewMessage("EDITING_LOCK_ACQUIRED", lock)
+
+	payload["user_id"] = client.UserID
+	payload["expires_at"] = lock.ExpiresAt
+	broadcastMsg, err := NewMessage("EDITING_STARTED", payload)
+	if err != nil {
+		log.Printf("CRITICAL: Failed to create broadcast message for start reorder: %v", err)
+	} else {
+		result.others = append(result.others, broadcastMsg)
+	}
+	return result
 }
 
-func (h *Hub) cleanupClientSessions(client *Client) {
-	for key, holder := range h.editingSessions {
-		if holder == client {
-			delete(h.editingSessions, key)
-			log.Printf("Cleaned up editing session %s for disconnected user %d", key, client.UserID)
+func (h *Hub) heartbeatEditing(client *Client, entity string, contextID int) lockResult {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	lock, err := h.editingLockRepo.Heartbeat(ctx, model.EditingLockKey(entity, contextID), client.UserID, EditingLockTTL)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("ERROR: Failed to extend editing lock %s for user %d: %v", model.EditingLockKey(entity, contextID), client.UserID, err)
+			return lockResult{}
 		}
+		// The lock expired or was force released, the editor has to start again
+		payload := map[string]interface{}{
+			"entity":     entity,
+			"context_id": contextID,
+		}
+		message, _ := NewMessage("EDITING_LOCK_LOST", payload)
+		return lockResult{direct: message}
 	}
+
+	message, _ := NewMessage("EDITING_LOCK_EXTENDED", lock)
+	return lockResult{direct: message}
 }
 
-func (h *Hub) broadcastToOthers(message []byte, exclude *Client) {
-	for _, client := range h.Clients {
-		if client.ID != exclude.ID {
-			client.Send <- message
+func (h *Hub) finishEditing(client *Client, entity string, contextID int, payload map[string]interface{}) lockResult {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	lock, err := h.editingLockRepo.Release(ctx, model.EditingLockKey(entity, contextID), client.UserID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("ERROR: Failed to release editing lock %s for user %d: %v", model.EditingLockKey(entity, contextID), client.UserID, err)
 		}
+		return lockResult{}
 	}
+	log.Printf("User %d finished editing %s from client %s", client.UserID, lock.LockKey, client.ID)
+
+	broadcastMsg, err := NewMessage("EDITING_FINISHED", payload)
+	if err != nil {
+		log.Printf("CRITICAL: Failed to create broadcast message for finish reorder: %v", err)
+		return lockResult{}
+	}
+	return lockResult{others: [][]byte{broadcastMsg}}
 }
 
-func (h *Hub) ReleaseLock(client *Client, entity string, contextID int) {
-	sessionKey := fmt.Sprintf("%s:%d", entity, contextID)
+// expireEditingLocks announces locks whose holder stopped sending heartbeats,
+// every API instance sweeps but a lock is only deleted (and announced) once
+func (h *Hub) expireEditingLocks() {
+	if h.editingLockRepo == nil {
+		return
+	}
 
-	if holder, exists := h.editingSessions[sessionKey]; exists && holder == client {
-		delete(h.editingSessions, sessionKey)
-		log.Printf("User %d released lock for %s", client.UserID, sessionKey)
+	ticker := time.NewTicker(editingLockSweepInterval)
+	defer ticker.Stop()
 
-		payload := map[string]interface{}{
-			"entity":     entity,
-			"context_id": contextID,
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		expired, err := h.editingLockRepo.DeleteExpired(ctx)
+		cancel()
+		if err != nil {
+			log.Printf("ERROR: Failed to delete expired editing locks: %v", err)
+			continue
+		}
+
+		for _, lock := range expired {
+			log.Printf("Editing lock %s held by user %d expired", lock.LockKey, lock.UserID)
+			payload := map[string]interface{}{
+				"entity":     lock.Entity,
+				"context_id": lock.ContextID,
+				"expired":    true,
+			}
+			message, err := NewMessage("EDITING_FINISHED", payload)
+			if err != nil {
+				log.Printf("CRITICAL: Failed to create websocket message for expired editing lock: %v", err)
+				continue
+			}
+			h.BroadcastMessage(message)
 		}
-		broadcastMsg, err := NewMessage("EDITING_FINISHED", payload)
+	}
+}
 
+func (h *Hub) handleSubscription(client *Client, msg Message) {
+	if msg.Event == "UNSUBSCRIBE" {
+		client.Subscription = nil
+	} else {
+		var req subscriptionRequest
+		rawPayload, err := json.Marshal(msg.Payload)
+		if err == nil {
+			err = json.Unmarshal(rawPayload, &req)
+		}
 		if err != nil {
-			log.Printf("CRITICAL: Failed to create websocket message for finish reorder: %v", err)
-		} else {
-			h.broadcastToOthers(broadcastMsg, client)
+			log.Printf("Invalid subscription payload from client %s: %v", client.ID, err)
+			return
+		}
+		if client.UserID == 0 {
+			// Public clients have no employee identity to be involved with
+			req.Involved = false
+		}
+		client.Subscription = newSubscription(req)
+	}
+
+	var payload interface{}
+	if client.Subscription != nil {
+		payload = client.Subscription.toResponse()
+	}
+	message, err := NewMessage("SUBSCRIPTION_UPDATED", payload)
+	if err != nil {
+		log.Printf("CRITICAL: Failed to create subscription updated message: %v", err)
+		return
+	}
+	select {
+	case client.Send <- message:
+	default:
+	}
+}
+
+// cleanupClientSessions runs on Run when a client goes away, the lock worker releases its locks
+func (h *Hub) cleanupClientSessions(client *Client) {
+	if client.UserID == 0 {
+		return
+	}
+	h.enqueueLockCommand(sessionCommand{action: "release_client", client: client})
+}
+
+// Only the connection that owns a lock releases it on disconnect, locks taken over by other devices are kept
+func (h *Hub) releaseClientLocks(client *Client) lockResult {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	released, err := h.editingLockRepo.ReleaseByClient(ctx, client.ID)
+	if err != nil {
+		log.Printf("ERROR: Failed to release editing locks of client %s: %v", client.ID, err)
+		return lockResult{}
+	}
+
+	var result lockResult
+	for _, lock := range released {
+		log.Printf("Cleaned up editing session %s for disconnected user %d", lock.LockKey, client.UserID)
+
+		payload := map[string]interface{}{
+			"entity":     lock.Entity,
+			"context_id": lock.ContextID,
+		}
+		if broadcastMsg, err := NewMessage("EDITING_FINISHED", payload); err == nil {
+			result.others = append(result.others, broadcastMsg)
 		}
+	}
+	return result
+}
 
+// broadcastToOthers also reaches the clients of the other API instances through the backplane
+func (h *Hub) broadcastToOthers(message []byte, exclude *Client) {
+	for _, client := range h.Clients {
+		if client.ID != exclude.ID {
+			select {
+			case client.Send <- message:
+			default:
+			}
+		}
+	}
+	if h.backplane != nil {
+		h.backplane.publish(backplaneEnvelope{Kind: "broadcast", Message: message})
 	}
 }
 
-func (h *Hub) GetClientByUserID(userID int) *Client {
-	if client, ok := h.UserClients[userID]; ok {
-		return client
+// sendDirect answers a single client without dropping it when its buffer is full
+func (h *Hub) sendDirect(client *Client, message []byte) {
+	select {
+	case client.Send <- message:
+	default:
 	}
-	return nil
 }
 
 func (h *Hub) SendConnectionEstablished(client *Client) {
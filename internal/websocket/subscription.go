@@ -0,0 +1,89 @@
+package websocket
+
+// TicketAudience describes who a ticket related message is meant for
+type TicketAudience struct {
+	TicketID           int      `json:"ticket_id,omitempty"`
+	DepartmentTargetID int      `json:"department_target_id,omitempty"`
+	InvolvedNPKs       []string `json:"involved_npks,omitempty"`
+	// InvolvedOnly restricts the message to the clients of InvolvedNPKs, whatever their subscription
+	InvolvedOnly bool `json:"involved_only,omitempty"`
+}
+
+// Subscription filters ticket messages for a client, a client without subscription receives everything
+type Subscription struct {
+	DepartmentTargetIDs map[int]bool
+	TicketIDs           map[int]bool
+	Involved            bool
+}
+
+type subscriptionRequest struct {
+	DepartmentTargetIDs []int `json:"department_target_ids"`
+	TicketIDs           []int `json:"ticket_ids"`
+	Involved            bool  `json:"involved"`
+}
+
+type ticketMessage struct {
+	audience      TicketAudience
+	message       []byte
+	publicMessage []byte
+}
+
+func newSubscription(req subscriptionRequest) *Subscription {
+	sub := &Subscription{
+		DepartmentTargetIDs: make(map[int]bool),
+		TicketIDs:           make(map[int]bool),
+		Involved:            req.Involved,
+	}
+	for _, id := range req.DepartmentTargetIDs {
+		sub.DepartmentTargetIDs[id] = true
+	}
+	for _, id := range req.TicketIDs {
+		sub.TicketIDs[id] = true
+	}
+	return sub
+}
+
+func (s *Subscription) toResponse() map[string]interface{} {
+	departmentIDs := make([]int, 0, len(s.DepartmentTargetIDs))
+	for id := range s.DepartmentTargetIDs {
+		departmentIDs = append(departmentIDs, id)
+	}
+	ticketIDs := make([]int, 0, len(s.TicketIDs))
+	for id := range s.TicketIDs {
+		ticketIDs = append(ticketIDs, id)
+	}
+	return map[string]interface{}{
+		"department_target_ids": departmentIDs,
+		"ticket_ids":            ticketIDs,
+		"involved":              s.Involved,
+	}
+}
+
+// WANTS TICKET MESSAGE
+func (c *Client) wantsTicketMessage(audience TicketAudience) bool {
+	if audience.InvolvedOnly {
+		return c.EmployeeNPK != "" && containsNPK(audience.InvolvedNPKs, c.EmployeeNPK)
+	}
+	if c.Subscription == nil {
+		return true
+	}
+	if audience.DepartmentTargetID != 0 && c.Subscription.DepartmentTargetIDs[audience.DepartmentTargetID] {
+		return true
+	}
+	if audience.TicketID != 0 && c.Subscription.TicketIDs[audience.TicketID] {
+		return true
+	}
+	if c.Subscription.Involved && c.EmployeeNPK != "" {
+		return containsNPK(audience.InvolvedNPKs, c.EmployeeNPK)
+	}
+	return false
+}
+
+func containsNPK(npks []string, npk string) bool {
+	for _, n := range npks {
+		if n == npk {
+			return true
+		}
+	}
+	return false
+}
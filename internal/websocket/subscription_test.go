@@ -0,0 +1,41 @@
+package websocket
+
+import "testing"
+
+func TestClientWantsTicketMessage(t *testing.T) {
+	audience := TicketAudience{TicketID: 7, DepartmentTargetID: 3, InvolvedNPKs: []string{"E001", "E002"}}
+	involvedOnly := audience
+	involvedOnly.InvolvedOnly = true
+
+	tests := []struct {
+		name         string
+		npk          string
+		subscription *subscriptionRequest
+		audience     TicketAudience
+		want         bool
+	}{
+		{name: "no subscription receives everything", audience: audience, want: true},
+		{name: "subscribed department", subscription: &subscriptionRequest{DepartmentTargetIDs: []int{3}}, audience: audience, want: true},
+		{name: "other department", subscription: &subscriptionRequest{DepartmentTargetIDs: []int{4}}, audience: audience},
+		{name: "subscribed ticket", subscription: &subscriptionRequest{TicketIDs: []int{7}}, audience: audience, want: true},
+		{name: "other ticket", subscription: &subscriptionRequest{TicketIDs: []int{8}}, audience: audience},
+		{name: "involved employee", npk: "E002", subscription: &subscriptionRequest{Involved: true}, audience: audience, want: true},
+		{name: "employee not involved", npk: "E003", subscription: &subscriptionRequest{Involved: true}, audience: audience},
+		{name: "public client asking for involved tickets", subscription: &subscriptionRequest{Involved: true}, audience: audience},
+		{name: "involved only to an involved employee", npk: "E001", audience: involvedOnly, want: true},
+		{name: "involved only ignores the subscription", npk: "E003", subscription: &subscriptionRequest{TicketIDs: []int{7}}, audience: involvedOnly},
+		{name: "involved only never reaches public clients", audience: involvedOnly},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{EmployeeNPK: tt.npk}
+			if tt.subscription != nil {
+				client.Subscription = newSubscription(*tt.subscription)
+			}
+			if got := client.wantsTicketMessage(tt.audience); got != tt.want {
+				t.Errorf("wantsTicketMessage = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}